@@ -6,8 +6,8 @@ import (
 
 var analyzeCmd = &cobra.Command{
 	Use:   "analyze",
-	Short: "Analyze skill content or contamination",
-	Long:  "Parent command for content and contamination analysis subcommands.",
+	Short: "Analyze skill content, contamination, loading cost, or sections",
+	Long:  "Parent command for content, contamination, progressive-disclosure loading, and section breakdown analysis subcommands.",
 }
 
 func init() {
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/agent-ecosystem/skill-validator/orchestrate"
+	"github.com/agent-ecosystem/skill-validator/structure"
+	"github.com/agent-ecosystem/skill-validator/types"
+)
+
+var (
+	loadingTokenizer     string
+	loadingContextWindow string
+)
+
+var analyzeLoadingCmd = &cobra.Command{
+	Use:   "loading <path>",
+	Short: "Simulate progressive disclosure: startup, activation, and on-demand token costs",
+	Long: "Follows the references between SKILL.md and the skill's files to compute what an agent loads at startup (name and description), " +
+		"on activation (SKILL.md body), and on demand along each path through the references, and warns when a single likely path loads most of the skill.",
+	Args: cobra.ExactArgs(1),
+	RunE: runAnalyzeLoading,
+}
+
+func init() {
+	registerTokenFlags(analyzeLoadingCmd, &loadingTokenizer, &loadingContextWindow)
+	analyzeCmd.AddCommand(analyzeLoadingCmd)
+}
+
+func runAnalyzeLoading(cmd *cobra.Command, args []string) error {
+	tokenizer, window, err := resolveTokenBudget(loadingTokenizer, loadingContextWindow)
+	if err != nil {
+		return err
+	}
+	_, mode, dirs, err := detectAndResolve(args)
+	if err != nil {
+		return err
+	}
+
+	opts := structure.Options{Tokenizer: tokenizer, ContextWindow: window}
+	switch mode {
+	case types.SingleSkill:
+		return outputReport(orchestrate.RunLoadingAnalysis(dirs[0], opts))
+	case types.MultiSkill:
+		return outputMultiReport(orchestrate.RunLoadingAnalysisMulti(dirs, opts))
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/agent-ecosystem/skill-validator/orchestrate"
+	"github.com/agent-ecosystem/skill-validator/structure"
+	"github.com/agent-ecosystem/skill-validator/types"
+)
+
+var (
+	sectionsTokenizer     string
+	sectionsContextWindow string
+)
+
+var analyzeSectionsCmd = &cobra.Command{
+	Use:   "sections <path>",
+	Short: "Break SKILL.md and references down by heading, with tokens and content metrics per section",
+	Long: "Splits SKILL.md and each markdown file in references/ at their headings and reports each section's tokens, word count, " +
+		"code block ratio, imperative ratio, and weak markers, then ranks the largest SKILL.md sections: the candidates for moving into references/.",
+	Args: cobra.ExactArgs(1),
+	RunE: runAnalyzeSections,
+}
+
+func init() {
+	registerTokenFlags(analyzeSectionsCmd, &sectionsTokenizer, &sectionsContextWindow)
+	analyzeCmd.AddCommand(analyzeSectionsCmd)
+}
+
+func runAnalyzeSections(cmd *cobra.Command, args []string) error {
+	tokenizer, window, err := resolveTokenBudget(sectionsTokenizer, sectionsContextWindow)
+	if err != nil {
+		return err
+	}
+	_, mode, dirs, err := detectAndResolve(args)
+	if err != nil {
+		return err
+	}
+
+	opts := structure.Options{Tokenizer: tokenizer, ContextWindow: window}
+	switch mode {
+	case types.SingleSkill:
+		return outputReport(orchestrate.RunSectionAnalysis(dirs[0], opts))
+	case types.MultiSkill:
+		mr := &types.MultiReport{}
+		for _, dir := range dirs {
+			r := orchestrate.RunSectionAnalysis(dir, opts)
+			mr.Skills = append(mr.Skills, r)
+			mr.Errors += r.Errors
+			mr.Warnings += r.Warnings
+		}
+		return outputMultiReport(mr)
+	}
+	return nil
+}
@@ -21,19 +21,28 @@ var (
 	checkAllowExtraFrontmatter bool
 	checkAllowFlatLayouts      bool
 	checkAllowDirs             []string
+	checkToolPlatform          string
+	checkKnownTools            []string
+	checkAllowedLicenses       []string
+	checkFrontmatterSchema     string
+	checkProfile               string
+	checkTokenizer             string
+	checkContextWindow         string
+	checkDescriptionBudget     int
+	checkLinkFlags             linkFlags
 )
 
 var checkCmd = &cobra.Command{
 	Use:   "check <path>",
-	Short: "Run all checks (structure + links + content + contamination)",
+	Short: "Run all checks (structure + links + content + contamination + security)",
 	Long:  "Runs all validation and analysis checks. Use --only or --skip to select specific check groups.",
 	Args:  cobra.ExactArgs(1),
 	RunE:  runCheck,
 }
 
 func init() {
-	checkCmd.Flags().StringSliceVar(&checkOnly, "only", nil, "check groups to run: structure,links,content,contamination (comma-separated or repeatable)")
-	checkCmd.Flags().StringSliceVar(&checkSkip, "skip", nil, "check groups to skip: structure,links,content,contamination (comma-separated or repeatable)")
+	checkCmd.Flags().StringSliceVar(&checkOnly, "only", nil, "check groups to run: structure,links,content,contamination,security (comma-separated or repeatable)")
+	checkCmd.Flags().StringSliceVar(&checkSkip, "skip", nil, "check groups to skip: structure,links,content,contamination,security (comma-separated or repeatable)")
 	checkCmd.Flags().BoolVar(&perFileCheck, "per-file", false, "show per-file reference analysis")
 	checkCmd.Flags().BoolVar(&checkSkipOrphans, "skip-orphans", false,
 		"skip orphan file detection (unreferenced files in scripts/, references/, assets/)")
@@ -44,6 +53,13 @@ func init() {
 		"allow files at the skill root without warnings and treat them as standard content for token counting")
 	checkCmd.Flags().StringSliceVar(&checkAllowDirs, "allow-dirs", nil,
 		"comma-separated list of directory names to accept without warnings (e.g. --allow-dirs=evals,testing)")
+	registerToolFlags(checkCmd, &checkToolPlatform, &checkKnownTools)
+	registerLicenseFlag(checkCmd, &checkAllowedLicenses)
+	registerSchemaFlag(checkCmd, &checkFrontmatterSchema)
+	registerProfileFlag(checkCmd, &checkProfile, "")
+	registerTokenFlags(checkCmd, &checkTokenizer, &checkContextWindow)
+	registerDescriptionBudgetFlag(checkCmd, &checkDescriptionBudget)
+	checkLinkFlags.register(checkCmd)
 	rootCmd.AddCommand(checkCmd)
 }
 
@@ -52,6 +68,7 @@ var validGroups = map[orchestrate.CheckGroup]bool{
 	orchestrate.GroupLinks:         true,
 	orchestrate.GroupContent:       true,
 	orchestrate.GroupContamination: true,
+	orchestrate.GroupSecurity:      true,
 }
 
 func runCheck(cmd *cobra.Command, args []string) error {
@@ -63,6 +80,20 @@ func runCheck(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	if err := validateToolPlatform(checkToolPlatform); err != nil {
+		return err
+	}
+	if err := validateProfile(checkProfile); err != nil {
+		return err
+	}
+	schema, err := loadFrontmatterSchema(checkFrontmatterSchema)
+	if err != nil {
+		return err
+	}
+	tokenizer, window, err := resolveTokenBudget(checkTokenizer, checkContextWindow)
+	if err != nil {
+		return err
+	}
 
 	_, mode, dirs, err := detectAndResolve(args)
 	if err != nil {
@@ -76,8 +107,21 @@ func runCheck(cmd *cobra.Command, args []string) error {
 			AllowExtraFrontmatter: checkAllowExtraFrontmatter,
 			AllowFlatLayouts:      checkAllowFlatLayouts,
 			AllowDirs:             checkAllowDirs,
+			ToolPlatform:          checkToolPlatform,
+			KnownTools:            checkKnownTools,
+			AllowedLicenses:       checkAllowedLicenses,
+			FrontmatterSchema:     schema,
+			Profile:               checkProfile,
+			Tokenizer:             tokenizer,
+			ContextWindow:         window,
+			DescriptionBudget:     checkDescriptionBudget,
 		},
 	}
+	if enabled[orchestrate.GroupLinks] {
+		var saveCache func()
+		opts.LinkOpts, saveCache = checkLinkFlags.options()
+		defer saveCache()
+	}
 	eopts := exitOpts{strict: strictCheck}
 	ctx := context.Background()
 
@@ -93,6 +137,9 @@ func runCheck(cmd *cobra.Command, args []string) error {
 			mr.Errors += r.Errors
 			mr.Warnings += r.Warnings
 		}
+		if enabled[orchestrate.GroupStructure] {
+			mr.AddCollection(structure.CheckCollection(dirs, opts.StructOpts))
+		}
 		return outputMultiReportWithExitOpts(mr, perFileCheck, eopts)
 	}
 	return nil
@@ -110,7 +157,7 @@ func resolveCheckGroups(only, skip []string) (map[orchestrate.CheckGroup]bool, e
 			g = strings.TrimSpace(g)
 			cg := orchestrate.CheckGroup(g)
 			if !validGroups[cg] {
-				return nil, fmt.Errorf("unknown check group %q (valid: structure, links, content, contamination)", g)
+				return nil, fmt.Errorf("unknown check group %q (valid: structure, links, content, contamination, security)", g)
 			}
 			enabled[cg] = true
 		}
@@ -121,7 +168,7 @@ func resolveCheckGroups(only, skip []string) (map[orchestrate.CheckGroup]bool, e
 			g = strings.TrimSpace(g)
 			cg := orchestrate.CheckGroup(g)
 			if !validGroups[cg] {
-				return nil, fmt.Errorf("unknown check group %q (valid: structure, links, content, contamination)", g)
+				return nil, fmt.Errorf("unknown check group %q (valid: structure, links, content, contamination, security)", g)
 			}
 			enabled[cg] = false
 		}
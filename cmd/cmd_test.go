@@ -322,6 +322,7 @@ func TestResolveCheckGroups(t *testing.T) {
 		for _, g := range []orchestrate.CheckGroup{
 			orchestrate.GroupStructure, orchestrate.GroupLinks,
 			orchestrate.GroupContent, orchestrate.GroupContamination,
+			orchestrate.GroupSecurity,
 		} {
 			if !enabled[g] {
 				t.Errorf("expected %s enabled by default", g)
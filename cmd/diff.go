@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agent-ecosystem/skill-validator/diff"
+	"github.com/agent-ecosystem/skill-validator/orchestrate"
+	"github.com/agent-ecosystem/skill-validator/report"
+	"github.com/agent-ecosystem/skill-validator/structure"
+)
+
+var (
+	diffTokenizer       string
+	diffContextWindow   string
+	diffMaxBodyGrowth   string
+	diffMaxTotalGrowth  string
+	diffFailOnNewErrors bool
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <old> <new>",
+	Short: "Compare token counts, metrics, and findings between two versions of a skill",
+	Long: `Compares two versions of a skill: the token count of each file, SKILL.md
+content and contamination metrics, and the errors and warnings each version
+has. Each version is a skill directory, an archive (.zip, .skill, .tar,
+.tar.gz, .tgz), or a git ref. A ref may name a path as ref:path; without one,
+the skill is taken from the same path as the other version, so
+"diff main skills/my-skill" compares the working copy with main.
+
+Growth thresholds fail the comparison (exit 1), for use in CI.`,
+	Args: cobra.ExactArgs(2),
+	RunE: runDiff,
+}
+
+func init() {
+	registerTokenFlags(diffCmd, &diffTokenizer, &diffContextWindow)
+	diffCmd.Flags().StringVar(&diffMaxBodyGrowth, "max-body-growth", "", "fail if the SKILL.md body grows by more than this, as a percentage (20%) or tokens (500)")
+	diffCmd.Flags().StringVar(&diffMaxTotalGrowth, "max-total-growth", "", "fail if the tokens an agent can read grow by more than this, as a percentage (20%) or tokens (5k)")
+	diffCmd.Flags().BoolVar(&diffFailOnNewErrors, "fail-on-new-errors", false, "fail if the new version has errors the old one did not")
+	rootCmd.AddCommand(diffCmd)
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	tokenizer, window, err := resolveTokenBudget(diffTokenizer, diffContextWindow)
+	if err != nil {
+		return err
+	}
+	diffOpts := diff.Options{FailOnNewErrors: diffFailOnNewErrors}
+	if diffOpts.MaxBodyGrowth, err = parseDiffLimit("--max-body-growth", diffMaxBodyGrowth); err != nil {
+		return err
+	}
+	if diffOpts.MaxTotalGrowth, err = parseDiffLimit("--max-total-growth", diffMaxTotalGrowth); err != nil {
+		return err
+	}
+
+	oldDir, cleanupOld, err := diff.Resolve(args[0], args[1])
+	defer cleanupOld()
+	if err != nil {
+		return err
+	}
+	newDir, cleanupNew, err := diff.Resolve(args[1], args[0])
+	defer cleanupNew()
+	if err != nil {
+		return err
+	}
+
+	opts := orchestrate.Options{
+		Enabled: map[orchestrate.CheckGroup]bool{
+			orchestrate.GroupStructure:     true,
+			orchestrate.GroupContent:       true,
+			orchestrate.GroupContamination: true,
+			orchestrate.GroupSecurity:      true,
+		},
+		StructOpts: structure.Options{Tokenizer: tokenizer, ContextWindow: window},
+	}
+	ctx := context.Background()
+	d := diff.Compare(args[0], orchestrate.RunAllChecks(ctx, oldDir, opts),
+		args[1], orchestrate.RunAllChecks(ctx, newDir, opts), diffOpts)
+
+	if err := report.FormatDiff(os.Stdout, d, outputFormat); err != nil {
+		return fmt.Errorf("writing %s: %w", outputFormat, err)
+	}
+	if emitAnnotations {
+		wd, _ := os.Getwd()
+		report.PrintDiffAnnotations(os.Stdout, d, wd)
+	}
+	if code := (exitOpts{}).resolve(d.Errors, d.Warnings); code != 0 {
+		return exitCodeError{code: code}
+	}
+	return nil
+}
+
+// parseDiffLimit parses the value of a growth threshold flag; an empty value
+// sets no threshold.
+func parseDiffLimit(flag, value string) (*diff.Limit, error) {
+	if value == "" {
+		return nil, nil
+	}
+	l, err := diff.ParseLimit(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", flag, err)
+	}
+	return &l, nil
+}
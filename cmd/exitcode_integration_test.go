@@ -59,9 +59,14 @@ func TestExitCodes(t *testing.T) {
 	}{
 		{
 			name:     "clean skill exits 0",
-			args:     []string{"check", fixture(t, "valid-skill")},
+			args:     []string{"check", fixture(t, "rich-skill")},
 			wantCode: 0,
 		},
+		{
+			name:     "unrestricted Bash grant exits 2",
+			args:     []string{"check", fixture(t, "valid-skill")},
+			wantCode: 2,
+		},
 		{
 			name:     "errors exit 1",
 			args:     []string{"check", fixture(t, "invalid-skill")},
@@ -92,6 +97,31 @@ func TestExitCodes(t *testing.T) {
 			args:     []string{"validate", "structure", fixture(t, "warnings-only-skill")},
 			wantCode: 2,
 		},
+		{
+			name:     "validate security with injection exits 1",
+			args:     []string{"validate", "security", fixture(t, "injection-skill")},
+			wantCode: 1,
+		},
+		{
+			name:     "validate security clean skill exits 0",
+			args:     []string{"validate", "security", fixture(t, "valid-skill")},
+			wantCode: 0,
+		},
+		{
+			name:     "diff of identical skills exits 0",
+			args:     []string{"diff", "--fail-on-new-errors", "--max-body-growth=0", fixture(t, "valid-skill"), fixture(t, "valid-skill")},
+			wantCode: 0,
+		},
+		{
+			name:     "diff with new errors exits 1",
+			args:     []string{"diff", "--fail-on-new-errors", fixture(t, "valid-skill"), fixture(t, "invalid-skill")},
+			wantCode: 1,
+		},
+		{
+			name:     "diff with invalid limit exits 3",
+			args:     []string{"diff", "--max-body-growth=lots", fixture(t, "valid-skill"), fixture(t, "valid-skill")},
+			wantCode: 3,
+		},
 	}
 
 	for _, tt := range tests {
@@ -116,32 +146,35 @@ func TestSliceFlags(t *testing.T) {
 		wantStdout string // substring that must appear in combined output
 		noStdout   string // substring that must NOT appear in combined output
 	}{
+		// valid-skill and allowed-dirs-skill grant unrestricted Bash, which
+		// is a warning, so checks that include structure exit 2.
+
 		// --only: comma-separated
 		{
 			name:       "only comma-separated runs selected groups",
 			args:       []string{"check", "--only=structure,content", fixture(t, "valid-skill")},
-			wantCode:   0,
+			wantCode:   2,
 			wantStdout: "SKILL.md found",
 		},
 		// --only: repeated flag
 		{
 			name:       "only repeated flag runs selected groups",
 			args:       []string{"check", "--only=structure", "--only=content", fixture(t, "valid-skill")},
-			wantCode:   0,
+			wantCode:   2,
 			wantStdout: "SKILL.md found",
 		},
 		// --skip: comma-separated
 		{
 			name:       "skip comma-separated excludes groups",
 			args:       []string{"check", "--skip=links,content,contamination", fixture(t, "valid-skill")},
-			wantCode:   0,
+			wantCode:   2,
 			wantStdout: "SKILL.md found",
 		},
 		// --skip: repeated flag
 		{
 			name:       "skip repeated flag excludes groups",
 			args:       []string{"check", "--skip=links", "--skip=content", "--skip=contamination", fixture(t, "valid-skill")},
-			wantCode:   0,
+			wantCode:   2,
 			wantStdout: "SKILL.md found",
 		},
 		// --only and --skip mutual exclusion
@@ -154,14 +187,14 @@ func TestSliceFlags(t *testing.T) {
 		{
 			name:     "allow-dirs comma-separated suppresses warnings",
 			args:     []string{"check", "--only=structure", "--allow-dirs=evals,testing", fixture(t, "allowed-dirs-skill")},
-			wantCode: 0,
+			wantCode: 2,
 			noStdout: "unknown directory",
 		},
 		// --allow-dirs: repeated flag
 		{
 			name:     "allow-dirs repeated flag suppresses warnings",
 			args:     []string{"check", "--only=structure", "--allow-dirs=evals", "--allow-dirs=testing", fixture(t, "allowed-dirs-skill")},
-			wantCode: 0,
+			wantCode: 2,
 			noStdout: "unknown directory",
 		},
 		// --allow-dirs: partial (only one of two unknown dirs)
@@ -195,3 +228,98 @@ func TestSliceFlags(t *testing.T) {
 		})
 	}
 }
+
+func TestLinksCachePrune(t *testing.T) {
+	bin := buildBinary(t)
+	cachePath := filepath.Join(t.TempDir(), "links.json")
+	entries := `{"entries": {
+  "https://fresh.example": {"url": "https://fresh.example", "status": 200, "checked_at": "2999-01-01T00:00:00Z"},
+  "https://stale.example": {"url": "https://stale.example", "status": 200, "checked_at": "2000-01-01T00:00:00Z"}
+}}`
+	if err := os.WriteFile(cachePath, []byte(entries), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := exec.Command(bin, "links", "cache", "prune", "--link-cache", cachePath).CombinedOutput()
+	if err != nil {
+		t.Fatalf("prune failed: %v\n%s", err, out)
+	}
+	if !strings.Contains(string(out), "Removed 1 entry") || !strings.Contains(string(out), "(1 remaining)") {
+		t.Errorf("unexpected prune output: %s", out)
+	}
+
+	out, err = exec.Command(bin, "links", "cache", "prune", "--all", "--link-cache", cachePath).CombinedOutput()
+	if err != nil {
+		t.Fatalf("prune --all failed: %v\n%s", err, out)
+	}
+	if !strings.Contains(string(out), "(0 remaining)") {
+		t.Errorf("unexpected prune --all output: %s", out)
+	}
+}
+
+func TestValidateLinksOfflinePolicy(t *testing.T) {
+	bin := buildBinary(t)
+	dir := filepath.Join(t.TempDir(), "policy-skill")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	skill := `---
+name: policy-skill
+description: A skill whose links exercise link policy rules.
+---
+# Policy Skill
+
+See [the docs](https://docs.example.com/guide), [the wiki](http://wiki.corp/page),
+and [this short link](https://bit.ly/abc).
+`
+	if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(skill), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command(bin, "validate", "links", "--offline", dir)
+	out, _ := cmd.CombinedOutput()
+	if got := cmd.ProcessState.ExitCode(); got != 0 {
+		t.Errorf("offline without policy: exit code = %d, want 0\noutput: %s", got, out)
+	}
+
+	cmd = exec.Command(bin, "validate", "links", "--offline",
+		"--require-https", "--deny-internal-hosts", "--deny-shorteners", "--allow-domains=example.com", dir)
+	out, _ = cmd.CombinedOutput()
+	if got := cmd.ProcessState.ExitCode(); got != 1 {
+		t.Errorf("offline with policy: exit code = %d, want 1\noutput: %s", got, out)
+	}
+	for _, want := range []string{"internal host wiki.corp", "URL shortener bit.ly"} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(string(out), "docs.example.com/guide (policy") {
+		t.Errorf("allowed HTTPS link should pass policy, got:\n%s", out)
+	}
+}
+
+func TestFix(t *testing.T) {
+	bin := buildBinary(t)
+	dir := filepath.Join(t.TempDir(), "fix-skill")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	skill := "---\nname: fix-skill\ndescription: A skill without external links.\n---\n# Fix Skill\n\nNothing to fix here.\n"
+	if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(skill), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := exec.Command(bin, "fix", "--dry-run", dir).CombinedOutput()
+	if err != nil {
+		t.Fatalf("fix --dry-run failed: %v\n%s", err, out)
+	}
+	if !strings.Contains(string(out), "Would update 0 links") {
+		t.Errorf("unexpected fix output: %s", out)
+	}
+
+	cmd := exec.Command(bin, "fix", "--offline", dir)
+	out, _ = cmd.CombinedOutput()
+	if got := cmd.ProcessState.ExitCode(); got != 3 {
+		t.Errorf("fix --offline: exit code = %d, want 3\noutput: %s", got, out)
+	}
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agent-ecosystem/skill-validator/fix"
+	"github.com/agent-ecosystem/skill-validator/orchestrate"
+	"github.com/agent-ecosystem/skill-validator/util"
+)
+
+var (
+	fixDryRun    bool
+	fixLinkFlags linkFlags
+)
+
+var fixCmd = &cobra.Command{
+	Use:   "fix <path>",
+	Short: "Apply automatic fixes to a skill",
+	Long: `Runs the checks that can suggest mechanical fixes and applies them in place.
+Currently this replaces external links that permanently redirect (HTTP 301 or
+308) with their new location, in SKILL.md and in markdown files under
+references/ and assets/. Use --dry-run to list the changes without writing.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runFix,
+}
+
+func init() {
+	fixCmd.Flags().BoolVar(&fixDryRun, "dry-run", false, "list the changes without modifying any files")
+	fixLinkFlags.register(fixCmd)
+	rootCmd.AddCommand(fixCmd)
+}
+
+func runFix(cmd *cobra.Command, args []string) error {
+	_, _, dirs, err := detectAndResolve(args)
+	if err != nil {
+		return err
+	}
+	if fixLinkFlags.offline {
+		return fmt.Errorf("--offline cannot be used with fix: redirects are only found over the network")
+	}
+
+	opts, saveCache := fixLinkFlags.options()
+	defer saveCache()
+	ctx := context.Background()
+
+	verb := "Updated"
+	if fixDryRun {
+		verb = "Would update"
+	}
+
+	total := 0
+	for _, dir := range dirs {
+		r := orchestrate.RunLinkChecksWithOptions(ctx, dir, opts)
+		changes, err := fix.Apply(dir, r.Results, fixDryRun)
+		if err != nil {
+			return err
+		}
+		for _, c := range changes {
+			_, _ = fmt.Fprintf(os.Stdout, "%s:%d: %s → %s", filepath.Join(filepath.Base(dir), c.File), c.Line, c.Old, c.New)
+			if c.Count > 1 {
+				_, _ = fmt.Fprintf(os.Stdout, " (%d occurrences)", c.Count)
+			}
+			_, _ = fmt.Fprintln(os.Stdout)
+			total += c.Count
+		}
+	}
+
+	_, _ = fmt.Fprintf(os.Stdout, "%s %d link%s\n", verb, total, util.PluralS(total))
+	return nil
+}
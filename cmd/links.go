@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agent-ecosystem/skill-validator/links"
+)
+
+var linksCmd = &cobra.Command{
+	Use:   "links",
+	Short: "Manage link checking state",
+	Long:  "Parent command for managing state used by external link checks, such as the persistent link cache.",
+}
+
+func init() {
+	rootCmd.AddCommand(linksCmd)
+}
+
+// linkFlags holds the link checking flags shared by check and validate links.
+type linkFlags struct {
+	noCache    bool
+	cachePath  string
+	successTTL time.Duration
+	failureTTL time.Duration
+
+	concurrency     int
+	hostConcurrency int
+	retries         int
+
+	offline        bool
+	requireHTTPS   bool
+	denyInternal   bool
+	denyShorteners bool
+	allowDomains   []string
+	denyDomains    []string
+	skipDomains    []string
+}
+
+// registerCacheFlags adds the flags that locate and configure the link cache.
+func (f *linkFlags) registerCacheFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.cachePath, "link-cache", "",
+		"path to the link check cache file (default: skill-validator/links.json in the user cache directory)")
+	cmd.Flags().DurationVar(&f.successTTL, "link-cache-ttl", links.DefaultSuccessTTL,
+		"how long reachable links are served from the link cache")
+	cmd.Flags().DurationVar(&f.failureTTL, "link-cache-failure-ttl", links.DefaultFailureTTL,
+		"how long failed links are served from the link cache")
+}
+
+// register adds all link checking flags to cmd.
+func (f *linkFlags) register(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&f.noCache, "no-link-cache", false, "check every link over the network, ignoring the link cache")
+	f.registerCacheFlags(cmd)
+	cmd.Flags().IntVar(&f.concurrency, "link-concurrency", links.DefaultMaxConcurrency,
+		"maximum simultaneous link requests across all hosts")
+	cmd.Flags().IntVar(&f.hostConcurrency, "link-host-concurrency", links.DefaultMaxPerHost,
+		"maximum simultaneous link requests to a single host")
+	cmd.Flags().IntVar(&f.retries, "link-retries", links.DefaultRetries,
+		"extra attempts for links that are rate limited (429), unavailable (503), or time out")
+	cmd.Flags().BoolVar(&f.offline, "offline", false,
+		"check link syntax and policy only, without network requests")
+	cmd.Flags().BoolVar(&f.requireHTTPS, "require-https", false, "fail on links that do not use HTTPS")
+	cmd.Flags().BoolVar(&f.denyInternal, "deny-internal-hosts", false,
+		"fail on links to localhost, private IPs, and internal hostnames agents cannot reach")
+	cmd.Flags().BoolVar(&f.denyShorteners, "deny-shorteners", false, "fail on links through URL shorteners (bit.ly, t.co, ...)")
+	cmd.Flags().StringSliceVar(&f.allowDomains, "allow-domains", nil,
+		"fail on links to domains not in this list; subdomains match (e.g. --allow-domains=github.com,go.dev)")
+	cmd.Flags().StringSliceVar(&f.denyDomains, "deny-domains", nil, "fail on links to these domains or their subdomains")
+	cmd.Flags().StringSliceVar(&f.skipDomains, "skip-domains", nil, "never fetch links to these known-flaky domains")
+}
+
+// openCache opens the link cache at the configured path with the configured TTLs.
+func (f *linkFlags) openCache() (*links.Cache, error) {
+	path := f.cachePath
+	if path == "" {
+		var err error
+		if path, err = links.DefaultCachePath(); err != nil {
+			return nil, err
+		}
+	}
+	cache, err := links.OpenCache(path)
+	if err != nil {
+		return nil, err
+	}
+	cache.SuccessTTL = f.successTTL
+	cache.FailureTTL = f.failureTTL
+	return cache, nil
+}
+
+// options builds links.Options from the flags. The returned function saves
+// the cache and must be called once link checking is done. Cache problems
+// are reported on stderr and never fail the run; checking proceeds uncached.
+func (f *linkFlags) options() (links.Options, func()) {
+	opts := links.Options{
+		MaxConcurrency: f.concurrency,
+		MaxPerHost:     f.hostConcurrency,
+		Retries:        f.retries,
+		Offline:        f.offline,
+		Policy: links.Policy{
+			RequireHTTPS:      f.requireHTTPS,
+			DenyInternalHosts: f.denyInternal,
+			DenyShorteners:    f.denyShorteners,
+			AllowDomains:      f.allowDomains,
+			DenyDomains:       f.denyDomains,
+			SkipDomains:       f.skipDomains,
+		},
+	}
+	// On the command line 0 means no retries; in links.Options it means
+	// the default.
+	if f.retries <= 0 {
+		opts.Retries = -1
+	}
+	if f.noCache || f.offline {
+		return opts, func() {}
+	}
+	cache, err := f.openCache()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: link cache disabled: %v\n", err)
+		return opts, func() {}
+	}
+	opts.Cache = cache
+	return opts, func() {
+		if err := cache.Save(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: saving link cache: %v\n", err)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agent-ecosystem/skill-validator/util"
+)
+
+var (
+	cacheLinkFlags linkFlags
+	pruneAll       bool
+)
+
+var linksCacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the persistent link check cache",
+	Long:  "Parent command for managing the on-disk cache of external link check results.",
+}
+
+var linksCachePruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove expired entries from the link cache",
+	Long: `Removes link cache entries older than their TTL. Reachable links expire
+after --link-cache-ttl and failed links after --link-cache-failure-ttl.
+Use --all to empty the cache entirely.`,
+	Args: cobra.NoArgs,
+	RunE: runLinksCachePrune,
+}
+
+func init() {
+	cacheLinkFlags.registerCacheFlags(linksCachePruneCmd)
+	linksCachePruneCmd.Flags().BoolVar(&pruneAll, "all", false, "remove every entry, not just expired ones")
+	linksCacheCmd.AddCommand(linksCachePruneCmd)
+	linksCmd.AddCommand(linksCacheCmd)
+}
+
+func runLinksCachePrune(cmd *cobra.Command, args []string) error {
+	cache, err := cacheLinkFlags.openCache()
+	if err != nil {
+		return err
+	}
+
+	var removed int
+	if pruneAll {
+		removed = cache.Clear()
+	} else {
+		removed = cache.Prune()
+	}
+	if err := cache.Save(); err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(os.Stdout, "Removed %d entr%s from %s (%d remaining)\n",
+		removed, util.YSuffix(removed), cache.Path(), cache.Len())
+	return nil
+}
@@ -12,8 +12,8 @@ import (
 
 var validateCmd = &cobra.Command{
 	Use:   "validate",
-	Short: "Validate skill structure or links",
-	Long:  "Parent command for structure and link validation subcommands.",
+	Short: "Validate skill structure, links, or security",
+	Long:  "Parent command for structure, link, and security validation subcommands.",
 }
 
 func init() {
@@ -12,12 +12,15 @@ import (
 var validateLinksCmd = &cobra.Command{
 	Use:   "links <path>",
 	Short: "Check external link validity (HTTP/HTTPS)",
-	Long:  "Validates external (HTTP/HTTPS) links in SKILL.md. Internal (relative) links are checked by validate structure.",
+	Long:  "Validates external (HTTP/HTTPS) links in SKILL.md and in markdown files under references/ and assets/. Internal (relative) links are checked by validate structure.",
 	Args:  cobra.ExactArgs(1),
 	RunE:  runValidateLinks,
 }
 
+var validateLinkFlags linkFlags
+
 func init() {
+	validateLinkFlags.register(validateLinksCmd)
 	validateCmd.AddCommand(validateLinksCmd)
 }
 
@@ -27,16 +30,18 @@ func runValidateLinks(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	opts, saveCache := validateLinkFlags.options()
+	defer saveCache()
 	ctx := context.Background()
 
 	switch mode {
 	case types.SingleSkill:
-		r := orchestrate.RunLinkChecks(ctx, dirs[0])
+		r := orchestrate.RunLinkChecksWithOptions(ctx, dirs[0], opts)
 		return outputReport(r)
 	case types.MultiSkill:
 		mr := &types.MultiReport{}
 		for _, dir := range dirs {
-			r := orchestrate.RunLinkChecks(ctx, dir)
+			r := orchestrate.RunLinkChecksWithOptions(ctx, dir, opts)
 			mr.Skills = append(mr.Skills, r)
 			mr.Errors += r.Errors
 			mr.Warnings += r.Warnings
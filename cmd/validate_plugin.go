@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/agent-ecosystem/skill-validator/plugin"
+	"github.com/agent-ecosystem/skill-validator/structure"
+)
+
+var (
+	strictPlugin                bool
+	pluginSkipOrphans           bool
+	pluginAllowExtraFrontmatter bool
+	pluginProfile               string
+)
+
+var validatePluginCmd = &cobra.Command{
+	Use:   "plugin <path>",
+	Short: "Validate a plugin or marketplace manifest and the skills it ships",
+	Long:  "Checks .claude-plugin/plugin.json and .claude-plugin/marketplace.json against their schemas, verifies that every path they reference exists, runs structure validation on every skill they load, and flags skills on disk that no manifest loads.",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runValidatePlugin,
+}
+
+func init() {
+	validatePluginCmd.Flags().BoolVar(&strictPlugin, "strict", false, "treat warnings as errors (exit 1 instead of 2)")
+	validatePluginCmd.Flags().BoolVar(&pluginSkipOrphans, "skip-orphans", false,
+		"skip orphan file detection (unreferenced files in scripts/, references/, assets/)")
+	validatePluginCmd.Flags().BoolVar(&pluginAllowExtraFrontmatter, "allow-extra-frontmatter", false,
+		"suppress warnings for non-spec frontmatter fields")
+	registerProfileFlag(validatePluginCmd, &pluginProfile, "claude")
+	validateCmd.AddCommand(validatePluginCmd)
+}
+
+func runValidatePlugin(cmd *cobra.Command, args []string) error {
+	if err := validateProfile(pluginProfile); err != nil {
+		return err
+	}
+	dir, err := resolvePath(args)
+	if err != nil {
+		return err
+	}
+
+	mr, err := plugin.Validate(dir, structure.Options{
+		SkipOrphans:           pluginSkipOrphans,
+		AllowExtraFrontmatter: pluginAllowExtraFrontmatter,
+		Profile:               pluginProfile,
+	})
+	if err != nil {
+		return err
+	}
+	return outputMultiReportWithExitOpts(mr, false, exitOpts{strict: strictPlugin})
+}
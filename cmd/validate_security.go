@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/agent-ecosystem/skill-validator/orchestrate"
+	"github.com/agent-ecosystem/skill-validator/types"
+)
+
+var validateSecurityCmd = &cobra.Command{
+	Use:   "security <path>",
+	Short: "Scan for prompt injection, deceptive Unicode, secrets, and risky scripts",
+	Long:  "Scans SKILL.md and text files under references/ and assets/ for prompt-injection phrases, instructions hidden in HTML comments, and content aimed at the agent rather than the user's task. Every text file is also checked for invisible or look-alike Unicode, and SKILL.md, references/, scripts/, and assets/ for pasted credentials. Shell and Python scripts are rated low, medium, or high risk.",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runValidateSecurity,
+}
+
+func init() {
+	validateCmd.AddCommand(validateSecurityCmd)
+}
+
+func runValidateSecurity(cmd *cobra.Command, args []string) error {
+	_, mode, dirs, err := detectAndResolve(args)
+	if err != nil {
+		return err
+	}
+
+	switch mode {
+	case types.SingleSkill:
+		return outputReport(orchestrate.RunSecurityChecks(dirs[0]))
+	case types.MultiSkill:
+		mr := &types.MultiReport{}
+		for _, dir := range dirs {
+			r := orchestrate.RunSecurityChecks(dir)
+			mr.Skills = append(mr.Skills, r)
+			mr.Errors += r.Errors
+			mr.Warnings += r.Warnings
+		}
+		return outputMultiReport(mr)
+	}
+	return nil
+}
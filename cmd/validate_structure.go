@@ -1,6 +1,10 @@
 package cmd
 
 import (
+	"fmt"
+	"slices"
+	"strings"
+
 	"github.com/spf13/cobra"
 
 	"github.com/agent-ecosystem/skill-validator/structure"
@@ -13,12 +17,20 @@ var (
 	structAllowExtraFrontmatter bool
 	structAllowFlatLayouts      bool
 	structAllowDirs             []string
+	structToolPlatform          string
+	structKnownTools            []string
+	structAllowedLicenses       []string
+	structFrontmatterSchema     string
+	structProfile               string
+	structTokenizer             string
+	structContextWindow         string
+	structDescriptionBudget     int
 )
 
 var validateStructureCmd = &cobra.Command{
 	Use:   "structure <path>",
-	Short: "Validate skill structure (spec compliance, tokens, code fences, internal links)",
-	Long:  "Checks that a skill directory conforms to the spec: structure, frontmatter fields, token limits, skill ratio, code fence integrity, and internal link validity.",
+	Short: "Validate skill structure (spec compliance, tokens, duplicates, code fences, internal links)",
+	Long:  "Checks that a skill directory conforms to the spec: structure, frontmatter fields, token limits, duplicated content, skill ratio, code fence integrity, heading and numbered-list structure, and internal link validity.",
 	Args:  cobra.ExactArgs(1),
 	RunE:  runValidateStructure,
 }
@@ -33,10 +45,31 @@ func init() {
 		"allow files at the skill root without warnings and treat them as standard content for token counting")
 	validateStructureCmd.Flags().StringSliceVar(&structAllowDirs, "allow-dirs", nil,
 		"comma-separated list of directory names to accept without warnings (e.g. --allow-dirs=evals,testing)")
+	registerToolFlags(validateStructureCmd, &structToolPlatform, &structKnownTools)
+	registerLicenseFlag(validateStructureCmd, &structAllowedLicenses)
+	registerSchemaFlag(validateStructureCmd, &structFrontmatterSchema)
+	registerProfileFlag(validateStructureCmd, &structProfile, "")
+	registerTokenFlags(validateStructureCmd, &structTokenizer, &structContextWindow)
+	registerDescriptionBudgetFlag(validateStructureCmd, &structDescriptionBudget)
 	validateCmd.AddCommand(validateStructureCmd)
 }
 
 func runValidateStructure(cmd *cobra.Command, args []string) error {
+	if err := validateToolPlatform(structToolPlatform); err != nil {
+		return err
+	}
+	if err := validateProfile(structProfile); err != nil {
+		return err
+	}
+	schema, err := loadFrontmatterSchema(structFrontmatterSchema)
+	if err != nil {
+		return err
+	}
+	tokenizer, window, err := resolveTokenBudget(structTokenizer, structContextWindow)
+	if err != nil {
+		return err
+	}
+
 	_, mode, dirs, err := detectAndResolve(args)
 	if err != nil {
 		return err
@@ -47,6 +80,14 @@ func runValidateStructure(cmd *cobra.Command, args []string) error {
 		AllowExtraFrontmatter: structAllowExtraFrontmatter,
 		AllowFlatLayouts:      structAllowFlatLayouts,
 		AllowDirs:             structAllowDirs,
+		ToolPlatform:          structToolPlatform,
+		KnownTools:            structKnownTools,
+		AllowedLicenses:       structAllowedLicenses,
+		FrontmatterSchema:     schema,
+		Profile:               structProfile,
+		Tokenizer:             tokenizer,
+		ContextWindow:         window,
+		DescriptionBudget:     structDescriptionBudget,
 	}
 	eopts := exitOpts{strict: strictStructure}
 
@@ -60,3 +101,91 @@ func runValidateStructure(cmd *cobra.Command, args []string) error {
 	}
 	return nil
 }
+
+// registerToolFlags adds the allowed-tools vocabulary flags to cmd.
+func registerToolFlags(cmd *cobra.Command, platform *string, known *[]string) {
+	cmd.Flags().StringVar(platform, "tool-platform", "",
+		"validate allowed-tools against one platform's tool names: "+strings.Join(structure.ToolPlatforms(), ", ")+" (default: any)")
+	cmd.Flags().StringSliceVar(known, "known-tools", nil,
+		"additional tool names to accept in allowed-tools (comma-separated or repeatable)")
+}
+
+// registerLicenseFlag adds the allowed-license policy flag to cmd.
+func registerLicenseFlag(cmd *cobra.Command, allowed *[]string) {
+	cmd.Flags().StringSliceVar(allowed, "allowed-licenses", nil,
+		"SPDX license identifiers every skill's license must be satisfiable with (e.g. --allowed-licenses=MIT,Apache-2.0)")
+}
+
+// registerSchemaFlag adds the frontmatter JSON Schema flag to cmd.
+func registerSchemaFlag(cmd *cobra.Command, path *string) {
+	cmd.Flags().StringVar(path, "frontmatter-schema", "",
+		"path to a JSON Schema file that every skill's frontmatter must satisfy")
+}
+
+// registerProfileFlag adds the platform profile flag to cmd, defaulting to
+// def.
+func registerProfileFlag(cmd *cobra.Command, profile *string, def string) {
+	cmd.Flags().StringVar(profile, "profile", def,
+		"validate for one agent platform's frontmatter extensions, limits, tools, and skills directory: "+strings.Join(structure.Profiles(), ", "))
+}
+
+// registerTokenFlags adds the tokenizer and context window flags to cmd.
+func registerTokenFlags(cmd *cobra.Command, tokenizer, window *string) {
+	cmd.Flags().StringVar(tokenizer, "tokenizer", "",
+		"count tokens with: "+strings.Join(structure.Tokenizers(), ", ")+" (default: the --context-window model's, else "+structure.DefaultTokenizer+")")
+	cmd.Flags().StringVar(window, "context-window", "",
+		"scale token limits to a context window and report each file's share of it: a size (e.g. 128k, 1m) or a model ("+strings.Join(structure.ContextPresets(), ", ")+")")
+}
+
+// registerDescriptionBudgetFlag adds the collection description budget flag
+// to cmd.
+func registerDescriptionBudgetFlag(cmd *cobra.Command, budget *int) {
+	cmd.Flags().IntVar(budget, "description-budget", 0,
+		"for a multi-skill directory, error when all skills' names and descriptions total more than this many tokens")
+}
+
+// resolveTokenBudget validates the tokenizer and context window flags. An
+// explicit tokenizer overrides the one a model preset implies.
+func resolveTokenBudget(tokenizer, window string) (string, int, error) {
+	if tokenizer != "" && !slices.Contains(structure.Tokenizers(), tokenizer) {
+		return "", 0, fmt.Errorf("unknown tokenizer %q (valid: %s)", tokenizer, strings.Join(structure.Tokenizers(), ", "))
+	}
+	if window == "" {
+		return tokenizer, 0, nil
+	}
+	size, presetTokenizer, err := structure.ParseContextWindow(window)
+	if err != nil {
+		return "", 0, err
+	}
+	if tokenizer == "" {
+		tokenizer = presetTokenizer
+	}
+	return tokenizer, size, nil
+}
+
+// loadFrontmatterSchema compiles the schema at path, or returns nil if path
+// is empty.
+func loadFrontmatterSchema(path string) (*structure.FrontmatterSchema, error) {
+	if path == "" {
+		return nil, nil
+	}
+	return structure.LoadFrontmatterSchema(path)
+}
+
+// validateToolPlatform returns an error if platform is set but has no
+// built-in tool vocabulary.
+func validateToolPlatform(platform string) error {
+	if platform == "" || slices.Contains(structure.ToolPlatforms(), platform) {
+		return nil
+	}
+	return fmt.Errorf("unknown tool platform %q (valid: %s)", platform, strings.Join(structure.ToolPlatforms(), ", "))
+}
+
+// validateProfile returns an error if profile is set but is not a built-in
+// platform profile.
+func validateProfile(profile string) error {
+	if profile == "" || slices.Contains(structure.Profiles(), profile) {
+		return nil
+	}
+	return fmt.Errorf("unknown profile %q (valid: %s)", profile, strings.Join(structure.Profiles(), ", "))
+}
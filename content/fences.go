@@ -0,0 +1,31 @@
+package content
+
+import "strings"
+
+// FencePrefix returns the fence character and its count if the line starts
+// with 3+ backticks or 3+ tildes. Returns (0, 0) otherwise.
+func FencePrefix(line string) (byte, int) {
+	if len(line) == 0 {
+		return 0, 0
+	}
+	ch := line[0]
+	if ch != '`' && ch != '~' {
+		return 0, 0
+	}
+	n := 0
+	for n < len(line) && line[n] == ch {
+		n++
+	}
+	if n < 3 {
+		return 0, 0
+	}
+	return ch, n
+}
+
+// ClosesFence reports whether line closes a fence opened with n ch
+// characters: the same character at least n times, with nothing after it.
+// An info string such as "```go" never closes a fence.
+func ClosesFence(line string, ch byte, n int) bool {
+	c, m := FencePrefix(line)
+	return c == ch && m >= n && strings.TrimSpace(line[m:]) == ""
+}
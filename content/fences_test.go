@@ -0,0 +1,25 @@
+package content
+
+import "testing"
+
+func TestClosesFence(t *testing.T) {
+	tests := []struct {
+		line string
+		ch   byte
+		n    int
+		want bool
+	}{
+		{"```", '`', 3, true},
+		{"````  ", '`', 3, true},
+		{"```", '`', 4, false},
+		{"```go", '`', 3, false},
+		{"~~~", '`', 3, false},
+		{"``", '`', 3, false},
+		{"text", '`', 3, false},
+	}
+	for _, tt := range tests {
+		if got := ClosesFence(tt.line, tt.ch, tt.n); got != tt.want {
+			t.Errorf("ClosesFence(%q, %q, %d) = %v, want %v", tt.line, tt.ch, tt.n, got, tt.want)
+		}
+	}
+}
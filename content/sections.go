@@ -0,0 +1,64 @@
+package content
+
+import (
+	"regexp"
+	"strings"
+)
+
+// headingPattern matches an ATX heading line, capturing the hashes and the
+// heading text without any closing hashes.
+var headingPattern = regexp.MustCompile(`^ {0,3}(#{1,6})[ \t]+(.*?)(?:[ \t]+#+)?[ \t]*$`)
+
+// Section is a heading and the text under it, up to the next heading of any
+// level.
+type Section struct {
+	// Heading is the heading text, or "" for text before the first heading.
+	Heading string
+	// Level is the heading level, 1 to 6, or 0 for text before the first
+	// heading.
+	Level int
+	// Line is the 1-based line of the heading in the split text.
+	Line int
+	// Text is the section's own text, heading line included.
+	Text string
+}
+
+// SplitSections splits markdown text at its ATX headings. Lines inside
+// fenced code blocks are never headings. Text before the first heading is
+// returned as a level-0 section unless it is blank.
+func SplitSections(text string) []Section {
+	lines := strings.Split(text, "\n")
+	var sections []Section
+	cur := Section{Line: 1}
+	start := 0
+	flush := func(end int) {
+		cur.Text = strings.Join(lines[start:end], "\n")
+		if cur.Level > 0 || strings.TrimSpace(cur.Text) != "" {
+			sections = append(sections, cur)
+		}
+	}
+
+	fence, fenceLen := byte(0), 0
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if fence != 0 {
+			if ClosesFence(trimmed, fence, fenceLen) {
+				fence = 0
+			}
+			continue
+		}
+		if ch, n := FencePrefix(trimmed); n > 0 {
+			fence, fenceLen = ch, n
+			continue
+		}
+		m := headingPattern.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		flush(i)
+		cur = Section{Heading: m[2], Level: len(m[1]), Line: i + 1}
+		start = i
+	}
+	flush(len(lines))
+	return sections
+}
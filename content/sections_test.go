@@ -0,0 +1,68 @@
+package content
+
+import (
+	"testing"
+)
+
+func TestSplitSections(t *testing.T) {
+	text := "Intro text.\n\n# Title\n\n## Setup ##\nInstall it.\n\n```bash\n# not a heading\n```\n\n### Details\nMore.\n#not-a-heading\n## Usage\n"
+	sections := SplitSections(text)
+
+	want := []struct {
+		heading string
+		level   int
+		line    int
+	}{
+		{"", 0, 1},
+		{"Title", 1, 3},
+		{"Setup", 2, 5},
+		{"Details", 3, 12},
+		{"Usage", 2, 15},
+	}
+	if len(sections) != len(want) {
+		t.Fatalf("expected %d sections, got %d: %+v", len(want), len(sections), sections)
+	}
+	for i, w := range want {
+		s := sections[i]
+		if s.Heading != w.heading || s.Level != w.level || s.Line != w.line {
+			t.Errorf("section %d = {%q %d %d}, want {%q %d %d}", i, s.Heading, s.Level, s.Line, w.heading, w.level, w.line)
+		}
+	}
+	if got := sections[2].Text; got != "## Setup ##\nInstall it.\n\n```bash\n# not a heading\n```\n" {
+		t.Errorf("Setup text = %q", got)
+	}
+	if got := sections[3].Text; got != "### Details\nMore.\n#not-a-heading" {
+		t.Errorf("Details text = %q", got)
+	}
+}
+
+func TestSplitSections_NoLeadingText(t *testing.T) {
+	sections := SplitSections("\n# Title\nBody.\n")
+	if len(sections) != 1 || sections[0].Heading != "Title" || sections[0].Line != 2 {
+		t.Errorf("expected only the Title section, got %+v", sections)
+	}
+}
+
+func TestSplitSections_Empty(t *testing.T) {
+	if sections := SplitSections(""); len(sections) != 0 {
+		t.Errorf("expected no sections, got %+v", sections)
+	}
+}
+
+func TestSplitSections_Fences(t *testing.T) {
+	t.Run("info string does not close a fence", func(t *testing.T) {
+		text := "# Title\n```bash\n# comment\n```python\n# still code\n```\n## Next\n"
+		sections := SplitSections(text)
+		if len(sections) != 2 || sections[1].Heading != "Next" || sections[1].Line != 7 {
+			t.Errorf("expected Title and Next, got %+v", sections)
+		}
+	})
+
+	t.Run("longer fence holds a shorter one", func(t *testing.T) {
+		text := "# Title\n````markdown\n```\n# example heading\n```\n````\n## Next\n"
+		sections := SplitSections(text)
+		if len(sections) != 2 || sections[1].Heading != "Next" || sections[1].Line != 7 {
+			t.Errorf("expected Title and Next, got %+v", sections)
+		}
+	})
+}
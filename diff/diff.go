@@ -0,0 +1,312 @@
+// Package diff compares two versions of a skill: the token count of each
+// file, content and contamination metrics, and validation findings. Growth
+// thresholds turn a comparison into pass/fail results for CI.
+package diff
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/agent-ecosystem/skill-validator/types"
+	"github.com/agent-ecosystem/skill-validator/util"
+)
+
+// bodyFile is the TokenCounts entry for the SKILL.md body.
+const bodyFile = "SKILL.md body"
+
+// Limit is a growth threshold: a number of tokens, or a percentage of the
+// old count.
+type Limit struct {
+	Value   float64
+	Percent bool
+}
+
+// ParseLimit parses a limit given as a percentage ("20%") or a number of
+// tokens ("3000", "3k").
+func ParseLimit(s string) (Limit, error) {
+	v := strings.ToLower(strings.TrimSpace(s))
+	l := Limit{}
+	mult := 1.0
+	switch {
+	case strings.HasSuffix(v, "%"):
+		l.Percent = true
+		v = strings.TrimSuffix(v, "%")
+	case strings.HasSuffix(v, "k"):
+		mult = 1_000
+		v = strings.TrimSuffix(v, "k")
+	}
+	n, err := strconv.ParseFloat(strings.ReplaceAll(v, "_", ""), 64)
+	if err != nil || n < 0 || math.IsInf(n, 0) || math.IsNaN(n) {
+		return Limit{}, fmt.Errorf("invalid limit %q: want a percentage (20%%) or a number of tokens (3000, 3k)", s)
+	}
+	l.Value = n * mult
+	return l, nil
+}
+
+// String formats the limit as it is shown in results.
+func (l Limit) String() string {
+	if l.Percent {
+		return strconv.FormatFloat(l.Value, 'f', -1, 64) + "%"
+	}
+	return util.FormatNumber(int(l.Value)) + "-token"
+}
+
+// exceeded reports whether the growth in d is over the limit. Shrinking
+// never is.
+func (l Limit) exceeded(d types.TokenDelta) bool {
+	if d.Delta() <= 0 {
+		return false
+	}
+	if l.Percent {
+		return d.Growth() > l.Value
+	}
+	return float64(d.Delta()) > l.Value
+}
+
+// Options sets the thresholds Compare checks. A nil limit is not checked.
+type Options struct {
+	// MaxBodyGrowth limits growth of the SKILL.md body, which agents load
+	// every time the skill activates.
+	MaxBodyGrowth *Limit
+	// MaxTotalGrowth limits growth of everything an agent can read: the
+	// body, references, assets, and other files.
+	MaxTotalGrowth *Limit
+	// FailOnNewErrors makes any error the old version did not have fail
+	// the comparison.
+	FailOnNewErrors bool
+}
+
+// Compare compares the reports for the old (before) and new (after)
+// versions of a skill and checks the thresholds in opts.
+func Compare(oldLabel string, before *types.Report, newLabel string, after *types.Report, opts Options) *types.DiffReport {
+	d := &types.DiffReport{Old: oldLabel, New: newLabel}
+
+	oldTotals, newTotals := tokenTotals(before), tokenTotals(after)
+	for i, name := range totalNames {
+		d.Totals = append(d.Totals, types.TokenDelta{Name: name, Old: oldTotals[i], New: newTotals[i]})
+	}
+	d.Files = fileDeltas(before, after)
+	d.Metrics = metricDeltas(before, after)
+	d.NewFindings = findingsOnlyIn(after, before)
+	d.ResolvedFindings = findingsOnlyIn(before, after)
+
+	ctx := types.ResultContext{Category: "Diff"}
+	if opts.MaxBodyGrowth != nil {
+		d.Results = append(d.Results, checkGrowth(ctx, "SKILL.md body", d.Totals[0], *opts.MaxBodyGrowth))
+	}
+	if opts.MaxTotalGrowth != nil {
+		d.Results = append(d.Results, checkGrowth(ctx, "total tokens", d.Totals[len(d.Totals)-1], *opts.MaxTotalGrowth))
+	}
+	if opts.FailOnNewErrors {
+		n := 0
+		for _, f := range d.NewFindings {
+			if f.Level == types.Error {
+				n++
+			}
+		}
+		if n > 0 {
+			d.Results = append(d.Results, ctx.Errorf("%d new error%s in %s", n, util.PluralS(n), newLabel))
+		} else {
+			d.Results = append(d.Results, ctx.Passf("no new errors in %s", newLabel))
+		}
+	}
+	for _, r := range d.Results {
+		switch r.Level {
+		case types.Error:
+			d.Errors++
+		case types.Warning:
+			d.Warnings++
+		}
+	}
+	return d
+}
+
+// totalNames labels the token totals, in the order tokenTotals returns them.
+var totalNames = []string{"SKILL.md body", "References and assets", "Other files", "Scripts", "Total (excluding scripts)"}
+
+// tokenTotals sums a report's token counts. Scripts are left out of the
+// total because agents run them rather than read them.
+func tokenTotals(r *types.Report) []int {
+	var body, standard, other, scripts int
+	for _, tc := range r.TokenCounts {
+		if tc.File == bodyFile {
+			body += tc.Tokens
+		} else {
+			standard += tc.Tokens
+		}
+	}
+	for _, tc := range r.OtherTokenCounts {
+		other += tc.Tokens
+	}
+	for _, tc := range r.ScriptTokenCounts {
+		scripts += tc.Tokens
+	}
+	return []int{body, standard, other, scripts, body + standard + other}
+}
+
+// fileDeltas returns the files whose token counts differ between the
+// reports, largest change first.
+func fileDeltas(before, after *types.Report) []types.TokenDelta {
+	oldCounts, newCounts := fileTokens(before), fileTokens(after)
+	var deltas []types.TokenDelta
+	for _, f := range util.SortedKeys(newCounts) {
+		n := newCounts[f]
+		o, ok := oldCounts[f]
+		switch {
+		case !ok:
+			deltas = append(deltas, types.TokenDelta{Name: f, New: n, Status: "added"})
+		case o != n:
+			deltas = append(deltas, types.TokenDelta{Name: f, Old: o, New: n, Status: "changed"})
+		}
+	}
+	for _, f := range util.SortedKeys(oldCounts) {
+		if _, ok := newCounts[f]; !ok {
+			deltas = append(deltas, types.TokenDelta{Name: f, Old: oldCounts[f], Status: "removed"})
+		}
+	}
+	slices.SortStableFunc(deltas, func(a, b types.TokenDelta) int {
+		return cmp.Compare(abs(b.Delta()), abs(a.Delta()))
+	})
+	return deltas
+}
+
+// fileTokens maps each counted file in r, other than the SKILL.md body, to
+// its tokens.
+func fileTokens(r *types.Report) map[string]int {
+	m := map[string]int{}
+	for _, counts := range [][]types.TokenCount{r.TokenCounts, r.OtherTokenCounts, r.ScriptTokenCounts} {
+		for _, tc := range counts {
+			if tc.File != bodyFile {
+				m[tc.File] = tc.Tokens
+			}
+		}
+	}
+	return m
+}
+
+// metricDeltas compares the content and contamination metrics of SKILL.md.
+// A metric missing from one report is compared as zero.
+func metricDeltas(before, after *types.Report) []types.MetricDelta {
+	var deltas []types.MetricDelta
+	if before.ContentReport != nil || after.ContentReport != nil {
+		o, n := contentMetrics(before.ContentReport), contentMetrics(after.ContentReport)
+		for i, name := range contentMetricNames {
+			deltas = append(deltas, types.MetricDelta{Name: name, Old: o[i], New: n[i]})
+		}
+	}
+	if before.ContaminationReport != nil || after.ContaminationReport != nil {
+		deltas = append(deltas, types.MetricDelta{
+			Name: "Contamination score",
+			Old:  contaminationScore(before.ContaminationReport),
+			New:  contaminationScore(after.ContaminationReport),
+		})
+	}
+	return deltas
+}
+
+// contentMetricNames labels the metrics contentMetrics returns, in order.
+var contentMetricNames = []string{
+	"Word count", "Code block ratio", "Imperative ratio",
+	"Information density", "Instruction specificity", "Sections",
+}
+
+func contentMetrics(cr *types.ContentReport) []float64 {
+	if cr == nil {
+		return make([]float64, len(contentMetricNames))
+	}
+	return []float64{
+		float64(cr.WordCount), cr.CodeBlockRatio, cr.ImperativeRatio,
+		cr.InformationDensity, cr.InstructionSpecificity, float64(cr.SectionCount),
+	}
+}
+
+func contaminationScore(cr *types.ContaminationReport) float64 {
+	if cr == nil {
+		return 0
+	}
+	return cr.ContaminationScore
+}
+
+// Line numbers in finding messages, such as "line 4", "lines 4-6",
+// "column 3", and "SKILL.md:12-30". A port in a URL is not a line.
+var (
+	messageLinePattern = regexp.MustCompile(`\b(lines?|column) \d+(?:-\d+)?\b`)
+	messageFilePattern = regexp.MustCompile(`(\.[A-Za-z]\w*):\d+(?:-\d+)?([\s,;)]|$)`)
+)
+
+// findingsOnlyIn returns the errors and warnings in a that b does not have.
+// Findings are matched on level, category, file, and message with its line
+// numbers removed; lines are ignored, since unrelated edits move them.
+func findingsOnlyIn(a, b *types.Report) []types.Result {
+	key := func(r types.Result) string {
+		return fmt.Sprintf("%d\x00%s\x00%s\x00%s", r.Level, r.Category, r.File, withoutLines(r.Message))
+	}
+	remaining := map[string]int{}
+	for _, r := range b.Results {
+		remaining[key(r)]++
+	}
+	var out []types.Result
+	for _, r := range a.Results {
+		if r.Level != types.Error && r.Level != types.Warning {
+			continue
+		}
+		k := key(r)
+		if remaining[k] > 0 {
+			remaining[k]--
+			continue
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+// withoutLines replaces the line and column numbers in a finding message
+// with "#", so a finding that moved still matches.
+func withoutLines(msg string) string {
+	msg = messageLinePattern.ReplaceAllString(msg, "$1 #")
+	return messageFilePattern.ReplaceAllString(msg, "$1:#$2")
+}
+
+// checkGrowth checks the growth of d against limit.
+func checkGrowth(ctx types.ResultContext, what string, d types.TokenDelta, limit Limit) types.Result {
+	change := FormatChange(d)
+	switch {
+	case limit.exceeded(d):
+		return ctx.Errorf("%s: %s, over the %s growth limit", what, change, limit)
+	case d.Delta() <= 0:
+		return ctx.Passf("%s: %s, no growth", what, change)
+	default:
+		return ctx.Passf("%s: %s, within the %s growth limit", what, change, limit)
+	}
+}
+
+// FormatChange formats a token change with its sign and percentage, e.g.
+// "+3,200 tokens (+25.0%)".
+func FormatChange(d types.TokenDelta) string {
+	delta := d.Delta()
+	sign := "+"
+	if delta < 0 {
+		sign = "-"
+	}
+	s := fmt.Sprintf("%s%s tokens", sign, util.FormatNumber(abs(delta)))
+	switch g := d.Growth(); {
+	case delta == 0:
+		return "0 tokens"
+	case math.IsInf(g, 1):
+		return s + " (new)"
+	default:
+		return fmt.Sprintf("%s (%+.1f%%)", s, g)
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
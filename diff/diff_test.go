@@ -0,0 +1,205 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/agent-ecosystem/skill-validator/types"
+)
+
+func TestParseLimit(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Limit
+		wantErr bool
+	}{
+		{"20%", Limit{Value: 20, Percent: true}, false},
+		{"2.5%", Limit{Value: 2.5, Percent: true}, false},
+		{"3000", Limit{Value: 3000}, false},
+		{"3k", Limit{Value: 3000}, false},
+		{"0", Limit{}, false},
+		{"", Limit{}, true},
+		{"lots", Limit{}, true},
+		{"-5%", Limit{}, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseLimit(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseLimit(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseLimit(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFormatChange(t *testing.T) {
+	tests := []struct {
+		d    types.TokenDelta
+		want string
+	}{
+		{types.TokenDelta{Old: 1000, New: 1250}, "+250 tokens (+25.0%)"},
+		{types.TokenDelta{Old: 4000, New: 1000}, "-3,000 tokens (-75.0%)"},
+		{types.TokenDelta{Old: 0, New: 800}, "+800 tokens (new)"},
+		{types.TokenDelta{Old: 500, New: 500}, "0 tokens"},
+	}
+	for _, tt := range tests {
+		if got := FormatChange(tt.d); got != tt.want {
+			t.Errorf("FormatChange(%+v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func testReports() (*types.Report, *types.Report) {
+	before := &types.Report{
+		TokenCounts: []types.TokenCount{
+			{File: "SKILL.md body", Tokens: 1000},
+			{File: "references/api.md", Tokens: 2000},
+			{File: "references/old.md", Tokens: 500},
+		},
+		ScriptTokenCounts: []types.TokenCount{{File: "scripts/run.sh", Tokens: 100}},
+		Results: []types.Result{
+			{Level: types.Pass, Category: "Structure", Message: "SKILL.md found"},
+			{Level: types.Warning, Category: "Structure", Message: "potentially unreferenced file: references/old.md", File: "references/old.md"},
+			{Level: types.Error, Category: "Links", Message: "broken link", File: "SKILL.md", Line: 4},
+		},
+		ContentReport:       &types.ContentReport{WordCount: 300, ImperativeRatio: 0.5},
+		ContaminationReport: &types.ContaminationReport{ContaminationScore: 0.1},
+	}
+	after := &types.Report{
+		TokenCounts: []types.TokenCount{
+			{File: "SKILL.md body", Tokens: 1300},
+			{File: "references/api.md", Tokens: 2100},
+			{File: "references/new.md", Tokens: 900},
+		},
+		ScriptTokenCounts: []types.TokenCount{{File: "scripts/run.sh", Tokens: 100}},
+		Results: []types.Result{
+			{Level: types.Error, Category: "Links", Message: "broken link", File: "SKILL.md", Line: 9},
+			{Level: types.Error, Category: "Frontmatter", Message: "name is missing", File: "SKILL.md"},
+		},
+		ContentReport:       &types.ContentReport{WordCount: 420, ImperativeRatio: 0.6},
+		ContaminationReport: &types.ContaminationReport{ContaminationScore: 0.3},
+	}
+	return before, after
+}
+
+func TestCompare(t *testing.T) {
+	before, after := testReports()
+	d := Compare("main", before, "HEAD", after, Options{})
+
+	want := map[string][2]int{
+		"SKILL.md body":             {1000, 1300},
+		"References and assets":     {2500, 3000},
+		"Scripts":                   {100, 100},
+		"Total (excluding scripts)": {3500, 4300},
+	}
+	for _, td := range d.Totals {
+		if w, ok := want[td.Name]; ok && (td.Old != w[0] || td.New != w[1]) {
+			t.Errorf("%s = %d → %d, want %d → %d", td.Name, td.Old, td.New, w[0], w[1])
+		}
+	}
+
+	var files []string
+	for _, f := range d.Files {
+		files = append(files, f.Name+":"+f.Status)
+	}
+	if got := strings.Join(files, ","); got != "references/new.md:added,references/old.md:removed,references/api.md:changed" {
+		t.Errorf("files = %s", got)
+	}
+
+	if len(d.Metrics) != len(contentMetricNames)+1 {
+		t.Fatalf("expected %d metrics, got %d", len(contentMetricNames)+1, len(d.Metrics))
+	}
+	if m := d.Metrics[len(d.Metrics)-1]; m.Name != "Contamination score" || m.Old != 0.1 || m.New != 0.3 {
+		t.Errorf("unexpected contamination metric %+v", m)
+	}
+
+	// The broken link moved lines but is the same finding.
+	if len(d.NewFindings) != 1 || d.NewFindings[0].Message != "name is missing" {
+		t.Errorf("new findings = %+v", d.NewFindings)
+	}
+	if len(d.ResolvedFindings) != 1 || !strings.Contains(d.ResolvedFindings[0].Message, "references/old.md") {
+		t.Errorf("resolved findings = %+v", d.ResolvedFindings)
+	}
+	if len(d.Results) != 0 || d.Errors != 0 {
+		t.Errorf("expected no threshold results without options, got %+v", d.Results)
+	}
+}
+
+func TestCompare_MovedFindings(t *testing.T) {
+	before := &types.Report{Results: []types.Result{
+		{Level: types.Error, Category: "Markdown", File: "SKILL.md", Line: 4,
+			Message: "SKILL.md has an unclosed code fence starting at line 4 — this may cause agents to misinterpret everything after it as code"},
+		{Level: types.Warning, Category: "Tokens", File: "references/b.md", Line: 3,
+			Message: "paragraph at references/b.md:3-5 duplicates SKILL.md:10, wasting 40 tokens; keep one copy and point to it"},
+		{Level: types.Error, Category: "Links", File: "SKILL.md", Line: 8,
+			Message: "http://localhost:8080/docs (HTTP 404)"},
+	}}
+	after := &types.Report{Results: []types.Result{
+		{Level: types.Error, Category: "Markdown", File: "SKILL.md", Line: 6,
+			Message: "SKILL.md has an unclosed code fence starting at line 6 — this may cause agents to misinterpret everything after it as code"},
+		{Level: types.Warning, Category: "Tokens", File: "references/b.md", Line: 4,
+			Message: "paragraph at references/b.md:4-6 duplicates SKILL.md:12, wasting 40 tokens; keep one copy and point to it"},
+		{Level: types.Error, Category: "Links", File: "SKILL.md", Line: 8,
+			Message: "http://localhost:9090/docs (HTTP 404)"},
+	}}
+	d := Compare("main", before, "HEAD", after, Options{FailOnNewErrors: true})
+
+	// Only the link, whose URL changed, is a different finding.
+	if len(d.NewFindings) != 1 || !strings.Contains(d.NewFindings[0].Message, ":9090/") {
+		t.Errorf("new findings = %+v", d.NewFindings)
+	}
+	if len(d.ResolvedFindings) != 1 || !strings.Contains(d.ResolvedFindings[0].Message, ":8080/") {
+		t.Errorf("resolved findings = %+v", d.ResolvedFindings)
+	}
+	if d.Errors != 1 {
+		t.Errorf("expected 1 error for the new link, got %d: %+v", d.Errors, d.Results)
+	}
+}
+
+func TestCompare_Thresholds(t *testing.T) {
+	before, after := testReports()
+	limit := func(s string) *Limit {
+		l, err := ParseLimit(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &l
+	}
+
+	t.Run("body growth over the limit", func(t *testing.T) {
+		d := Compare("main", before, "HEAD", after, Options{MaxBodyGrowth: limit("20%")})
+		if d.Errors != 1 || d.Results[0].Message != "SKILL.md body: +300 tokens (+30.0%), over the 20% growth limit" {
+			t.Errorf("unexpected results %+v", d.Results)
+		}
+	})
+
+	t.Run("growth within token limits", func(t *testing.T) {
+		d := Compare("main", before, "HEAD", after, Options{MaxBodyGrowth: limit("500"), MaxTotalGrowth: limit("1k")})
+		if d.Errors != 0 || len(d.Results) != 2 {
+			t.Fatalf("unexpected results %+v", d.Results)
+		}
+		if d.Results[1].Message != "total tokens: +800 tokens (+22.9%), within the 1,000-token growth limit" {
+			t.Errorf("unexpected message %q", d.Results[1].Message)
+		}
+	})
+
+	t.Run("shrinking passes any limit", func(t *testing.T) {
+		d := Compare("HEAD", after, "main", before, Options{MaxBodyGrowth: limit("0")})
+		if d.Errors != 0 || !strings.Contains(d.Results[0].Message, "no growth") {
+			t.Errorf("unexpected results %+v", d.Results)
+		}
+	})
+
+	t.Run("new errors", func(t *testing.T) {
+		d := Compare("main", before, "HEAD", after, Options{FailOnNewErrors: true})
+		if d.Errors != 1 || d.Results[0].Message != "1 new error in HEAD" {
+			t.Errorf("unexpected results %+v", d.Results)
+		}
+		d = Compare("HEAD", after, "main", before, Options{FailOnNewErrors: true})
+		if d.Errors != 0 {
+			t.Errorf("new warnings alone should not fail: %+v", d.Results)
+		}
+	})
+}
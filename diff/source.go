@@ -0,0 +1,323 @@
+package diff
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/agent-ecosystem/skill-validator/skillcheck"
+	"github.com/agent-ecosystem/skill-validator/types"
+)
+
+// maxExtractBytes caps the total size extracted from an archive or git ref,
+// so a malformed or hostile archive cannot fill the disk.
+const maxExtractBytes = 256 << 20
+
+// Resolve returns a directory holding the skill version named by spec: a
+// directory, an archive (.zip, .skill, .tar, .tar.gz, .tgz), or a git ref.
+// A git ref may name a path as ref:path, relative to the repository root;
+// without one, the skill is taken from the same place as other, the spec
+// being compared against, when that is a directory in the repository.
+// Archives and refs are extracted to a temporary directory that cleanup
+// removes; cleanup is never nil.
+func Resolve(spec, other string) (dir string, cleanup func(), err error) {
+	cleanup = func() {}
+	if info, err := os.Stat(spec); err == nil {
+		if info.IsDir() {
+			dir, err := locateSkill(spec)
+			return dir, cleanup, err
+		}
+		if archiveFormat(spec) == "" {
+			return "", cleanup, fmt.Errorf("%s is not a directory or a supported archive (.zip, .skill, .tar, .tar.gz, .tgz)", spec)
+		}
+		return extract(spec, func(tmp string) error { return extractArchive(spec, tmp) })
+	}
+
+	root, rel, err := gitTarget(spec, other)
+	if err != nil {
+		return "", cleanup, fmt.Errorf("%s is not a directory, archive, or git ref: %w", spec, err)
+	}
+	ref, _, _ := strings.Cut(spec, ":")
+	return extract(spec, func(tmp string) error { return extractGitRef(root, ref, rel, tmp) })
+}
+
+// extract runs fill on a new temporary directory and locates the skill in
+// it. The directory is removed on error.
+func extract(spec string, fill func(tmp string) error) (string, func(), error) {
+	tmp, err := os.MkdirTemp("", "skill-validator-diff-")
+	if err != nil {
+		return "", func() {}, err
+	}
+	cleanup := func() { _ = os.RemoveAll(tmp) }
+	if err := fill(tmp); err != nil {
+		cleanup()
+		return "", func() {}, fmt.Errorf("extracting %s: %w", spec, err)
+	}
+	dir, err := locateSkill(tmp)
+	if err != nil {
+		cleanup()
+		return "", func() {}, fmt.Errorf("%s: %w", spec, err)
+	}
+	return dir, cleanup, nil
+}
+
+// locateSkill returns the single skill in dir: dir itself if it holds
+// SKILL.md, or its one subdirectory that does. Extracted archives and refs
+// nest the skill under the path it was stored at, so locateSkill descends
+// through directories with a single entry until it finds one.
+func locateSkill(dir string) (string, error) {
+	for {
+		mode, dirs := skillcheck.DetectSkills(dir)
+		switch mode {
+		case types.SingleSkill:
+			return dirs[0], nil
+		case types.MultiSkill:
+			if len(dirs) == 1 {
+				return dirs[0], nil
+			}
+			return "", fmt.Errorf("found %d skills in %s; diff compares one skill at a time", len(dirs), dir)
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil || len(entries) != 1 || !entries[0].IsDir() {
+			return "", fmt.Errorf("no SKILL.md found in %s", dir)
+		}
+		dir = filepath.Join(dir, entries[0].Name())
+	}
+}
+
+// archiveFormat returns "zip" or "tar" for the archive extensions Resolve
+// accepts, or "" for anything else.
+func archiveFormat(name string) string {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, ".zip"), strings.HasSuffix(lower, ".skill"):
+		return "zip"
+	case strings.HasSuffix(lower, ".tar"), strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		return "tar"
+	}
+	return ""
+}
+
+// extractArchive extracts the archive at name into dir. An archive holding
+// SKILL.md at its root is extracted into a directory named after it, so the
+// skill keeps a name.
+func extractArchive(name, dir string) error {
+	base := filepath.Base(name)
+	for _, ext := range []string{".tar.gz", ".tgz", ".tar", ".zip", ".skill"} {
+		if strings.HasSuffix(strings.ToLower(base), ext) {
+			base = base[:len(base)-len(ext)]
+			break
+		}
+	}
+	dest := filepath.Join(dir, base)
+	if err := os.MkdirAll(dest, 0o755); err != nil {
+		return err
+	}
+
+	if archiveFormat(name) == "zip" {
+		return extractZip(name, dest)
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	var r io.Reader = f
+	if lower := strings.ToLower(name); strings.HasSuffix(lower, ".gz") || strings.HasSuffix(lower, ".tgz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = gz.Close() }()
+		r = gz
+	}
+	return extractTar(r, dest)
+}
+
+func extractZip(name, dest string) error {
+	zr, err := zip.OpenReader(name)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = zr.Close() }()
+
+	var written int64
+	for _, f := range zr.File {
+		if !f.Mode().IsRegular() {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+		n, err := writeEntry(dest, f.Name, f.Mode(), rc, maxExtractBytes-written)
+		_ = rc.Close()
+		if err != nil {
+			return err
+		}
+		written += n
+	}
+	return nil
+}
+
+func extractTar(r io.Reader, dest string) error {
+	tr := tar.NewReader(r)
+	var written int64
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		n, err := writeEntry(dest, hdr.Name, hdr.FileInfo().Mode(), tr, maxExtractBytes-written)
+		if err != nil {
+			return err
+		}
+		written += n
+	}
+}
+
+// writeEntry writes the archive entry name under dest, refusing names that
+// escape dest and content beyond limit bytes. The executable bits of mode
+// are kept, so checks on scripts see the same file as in the original.
+func writeEntry(dest, name string, mode fs.FileMode, r io.Reader, limit int64) (int64, error) {
+	slashed := strings.ReplaceAll(name, "\\", "/")
+	if slices.Contains(strings.Split(slashed, "/"), "..") {
+		return 0, fmt.Errorf("archive entry %q escapes the archive", name)
+	}
+	clean := path.Clean("/" + slashed)
+	target := filepath.Join(dest, filepath.FromSlash(clean))
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return 0, err
+	}
+	out, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644|mode&0o111)
+	if err != nil {
+		return 0, err
+	}
+	n, err := io.Copy(out, io.LimitReader(r, limit+1))
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil && n > limit {
+		err = fmt.Errorf("archive is larger than %d MB", maxExtractBytes>>20)
+	}
+	return n, err
+}
+
+// gitTarget finds the repository and the repository-relative skill path for
+// the git ref spec.
+func gitTarget(spec, other string) (root, rel string, err error) {
+	ref, rel, hasPath := strings.Cut(spec, ":")
+	base := "."
+	if !hasPath {
+		if info, err := os.Stat(other); err == nil && info.IsDir() {
+			base = other
+		}
+	}
+	root, err = git(base, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", "", err
+	}
+	if _, err := git(root, "rev-parse", "--verify", "--quiet", ref+"^{commit}"); err != nil {
+		return "", "", fmt.Errorf("unknown revision %q", ref)
+	}
+	if hasPath {
+		return root, path.Clean(strings.TrimPrefix(filepath.ToSlash(rel), "/")), nil
+	}
+	if base == "." {
+		base, _ = os.Getwd()
+	}
+	abs, err := filepath.Abs(base)
+	if err != nil {
+		return "", "", err
+	}
+	// Compare against the resolved root, which has symlinks evaluated.
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		abs = resolved
+	}
+	rel, err = filepath.Rel(root, abs)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return "", "", fmt.Errorf("%s is outside the repository at %s", base, root)
+	}
+	return root, filepath.ToSlash(rel), nil
+}
+
+// extractGitRef writes the tree at ref:rel in the repository at root to dir,
+// under a directory named after the skill.
+func extractGitRef(root, ref, rel, dir string) error {
+	name := path.Base(rel)
+	if rel == "." {
+		name = filepath.Base(root)
+	}
+	dest := filepath.Join(dir, name)
+	args := []string{"archive", "--format=tar", ref}
+	if rel != "." {
+		args = append(args, "--", rel)
+	}
+	cmd := exec.Command("git", args...)
+	cmd.Dir = root
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	// Stream the archive so maxExtractBytes bounds memory as well as disk.
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	if err := extractTar(out, dest); err != nil {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("git archive: %s", msg)
+		}
+		return err
+	}
+	if err := cmd.Wait(); err != nil {
+		return fmt.Errorf("git archive: %s", strings.TrimSpace(stderr.String()))
+	}
+	if rel == "." {
+		return nil
+	}
+	// git archive keeps the path; move the skill up to dest.
+	tmp := dest + ".tree"
+	if err := os.Rename(dest, tmp); err != nil {
+		return err
+	}
+	if err := os.Rename(filepath.Join(tmp, filepath.FromSlash(rel)), dest); err != nil {
+		return fmt.Errorf("%s not found at %s", rel, ref)
+	}
+	return os.RemoveAll(tmp)
+}
+
+// git runs a git command in dir and returns its trimmed output.
+func git(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return "", errors.New(strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,187 @@
+package diff
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const skillMD = "---\nname: my-skill\ndescription: Does things.\n---\n# My Skill\n"
+
+func writeFile(t *testing.T, dir, rel, content string) {
+	t.Helper()
+	path := filepath.Join(dir, rel)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// resolve calls Resolve and checks that the skill it returns has SKILL.md.
+func resolve(t *testing.T, spec, other string) string {
+	t.Helper()
+	dir, cleanup, err := Resolve(spec, other)
+	t.Cleanup(cleanup)
+	if err != nil {
+		t.Fatalf("Resolve(%q): %v", spec, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "SKILL.md")); err != nil {
+		t.Fatalf("no SKILL.md in %s", dir)
+	}
+	return dir
+}
+
+func TestResolve_Directory(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "my-skill/SKILL.md", skillMD)
+
+	if got := resolve(t, filepath.Join(root, "my-skill"), ""); got != filepath.Join(root, "my-skill") {
+		t.Errorf("got %s", got)
+	}
+	// A parent with a single skill resolves to it.
+	if got := resolve(t, root, ""); got != filepath.Join(root, "my-skill") {
+		t.Errorf("got %s", got)
+	}
+
+	writeFile(t, root, "other-skill/SKILL.md", skillMD)
+	if _, _, err := Resolve(root, ""); err == nil || !strings.Contains(err.Error(), "found 2 skills") {
+		t.Errorf("expected an error for two skills, got %v", err)
+	}
+}
+
+func TestResolve_Zip(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "my-skill.skill")
+	f, err := os.Create(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(f)
+	for name, content := range map[string]string{"SKILL.md": skillMD, "references/guide.md": "# Guide\n"} {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = w.Write([]byte(content))
+	}
+	hdr := &zip.FileHeader{Name: "scripts/run.sh"}
+	hdr.SetMode(0o755)
+	w, err := zw.CreateHeader(hdr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = w.Write([]byte("#!/bin/sh\n"))
+	_ = zw.Close()
+	_ = f.Close()
+
+	dir := resolve(t, archive, "")
+	if filepath.Base(dir) != "my-skill" {
+		t.Errorf("expected the skill to be named after the archive, got %s", dir)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "references", "guide.md")); err != nil {
+		t.Errorf("references/guide.md not extracted: %v", err)
+	}
+	requireExecutable(t, filepath.Join(dir, "scripts", "run.sh"))
+}
+
+// requireExecutable asserts that the file at path has its owner executable
+// bit set.
+func requireExecutable(t *testing.T, path string) {
+	t.Helper()
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode()&0o100 == 0 {
+		t.Errorf("%s is not executable: %v", path, info.Mode())
+	}
+}
+
+func writeTarGz(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		_ = tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg})
+		_, _ = tw.Write([]byte(content))
+	}
+	_ = tw.Close()
+	_ = gz.Close()
+	_ = f.Close()
+}
+
+func TestResolve_TarGz(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "release.tar.gz")
+	writeTarGz(t, archive, map[string]string{"skills/my-skill/SKILL.md": skillMD})
+	if dir := resolve(t, archive, ""); filepath.Base(dir) != "my-skill" {
+		t.Errorf("got %s", dir)
+	}
+
+	escape := filepath.Join(t.TempDir(), "escape.tgz")
+	writeTarGz(t, escape, map[string]string{"SKILL.md": skillMD, "../outside.md": "x"})
+	if _, _, err := Resolve(escape, ""); err == nil || !strings.Contains(err.Error(), "escapes the archive") {
+		t.Errorf("expected an escape error, got %v", err)
+	}
+}
+
+func TestResolve_GitRef(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	repo := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	run("init", "-q")
+	writeFile(t, repo, "skills/my-skill/SKILL.md", skillMD)
+	writeFile(t, repo, "skills/my-skill/scripts/run.sh", "#!/bin/sh\n")
+	if err := os.Chmod(filepath.Join(repo, "skills", "my-skill", "scripts", "run.sh"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	run("add", "-A")
+	run("commit", "-q", "-m", "add skill")
+	writeFile(t, repo, "skills/my-skill/SKILL.md", skillMD+"\nMore steps.\n")
+
+	t.Run("ref with path", func(t *testing.T) {
+		t.Chdir(repo)
+		dir := resolve(t, "HEAD:skills/my-skill", "")
+		if filepath.Base(dir) != "my-skill" {
+			t.Errorf("got %s", dir)
+		}
+		data, _ := os.ReadFile(filepath.Join(dir, "SKILL.md"))
+		if string(data) != skillMD {
+			t.Errorf("expected the committed SKILL.md, got %q", data)
+		}
+		requireExecutable(t, filepath.Join(dir, "scripts", "run.sh"))
+	})
+
+	t.Run("ref takes the path of the other version", func(t *testing.T) {
+		dir := resolve(t, "HEAD", filepath.Join(repo, "skills", "my-skill"))
+		data, _ := os.ReadFile(filepath.Join(dir, "SKILL.md"))
+		if string(data) != skillMD {
+			t.Errorf("expected the committed SKILL.md, got %q", data)
+		}
+	})
+
+	t.Run("unknown ref", func(t *testing.T) {
+		t.Chdir(repo)
+		if _, _, err := Resolve("no-such-branch", ""); err == nil || !strings.Contains(err.Error(), "not a directory, archive, or git ref") {
+			t.Errorf("expected an error, got %v", err)
+		}
+	})
+}
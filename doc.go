@@ -5,7 +5,7 @@
 // Library consumers typically start with one of two entry points depending
 // on their use case.
 //
-// # Validation (structure, content, contamination, links)
+// # Validation (structure, content, contamination, links, security)
 //
 // The [github.com/agent-ecosystem/skill-validator/orchestrate] package coordinates
 // all validation checks and returns a unified [github.com/agent-ecosystem/skill-validator/types.Report].
@@ -39,6 +39,8 @@
 //   - [github.com/agent-ecosystem/skill-validator/content] — content quality metrics (density, specificity, imperative ratio)
 //   - [github.com/agent-ecosystem/skill-validator/contamination] — cross-language contamination detection
 //   - [github.com/agent-ecosystem/skill-validator/links] — external HTTP/HTTPS link validation
+//   - [github.com/agent-ecosystem/skill-validator/security] — prompt-injection and hidden-instruction scanning
+//   - [github.com/agent-ecosystem/skill-validator/fix] — applying mechanical fixes attached to results
 //   - [github.com/agent-ecosystem/skill-validator/skill] — SKILL.md parsing (frontmatter + body)
 //   - [github.com/agent-ecosystem/skill-validator/skillcheck] — skill detection and reference file analysis
 //   - [github.com/agent-ecosystem/skill-validator/report] — output formatting (text, JSON, markdown, GitHub annotations)
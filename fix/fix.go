@@ -0,0 +1,150 @@
+// Package fix applies the mechanical edits attached to validation results
+// (see types.Fix), such as replacing permanently redirected links with their
+// new locations.
+package fix
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/agent-ecosystem/skill-validator/types"
+	"github.com/agent-ecosystem/skill-validator/util"
+)
+
+// Change records one edit made, or that would be made, to a file.
+type Change struct {
+	File  string // path relative to the skill dir
+	Line  int    // line of the finding that produced the change
+	Old   string
+	New   string
+	Count int // occurrences replaced in File
+}
+
+// Apply performs the fixes attached to results, rewriting the files they
+// point at under dir. Each fix replaces every occurrence of Old in its file
+// that is not the start of a longer token, so fixing https://a.example/x
+// leaves https://a.example/xy untouched. Occurrences in fenced code blocks
+// and inline code spans, which link checks skip, are left as they are, so
+// example code and shell snippets are never edited. With dryRun set, the
+// changes are computed but no files are written. Fixes whose Old text is no
+// longer present are skipped.
+func Apply(dir string, results []types.Result, dryRun bool) ([]Change, error) {
+	byFile := make(map[string][]types.Result)
+	for _, r := range results {
+		if r.Fix == nil || r.File == "" || r.Fix.Old == "" || r.Fix.Old == r.Fix.New {
+			continue
+		}
+		byFile[r.File] = append(byFile[r.File], r)
+	}
+
+	files := make([]string, 0, len(byFile))
+	for f := range byFile {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+
+	var changes []Change
+	for _, file := range files {
+		path := filepath.Join(dir, file)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return changes, fmt.Errorf("reading %s: %w", file, err)
+		}
+		content := string(data)
+		seen := make(map[types.Fix]bool)
+		for _, r := range byFile[file] {
+			if seen[*r.Fix] {
+				continue
+			}
+			seen[*r.Fix] = true
+			var n int
+			content, n = replaceToken(content, r.Fix.Old, r.Fix.New)
+			if n > 0 {
+				changes = append(changes, Change{File: file, Line: r.Line, Old: r.Fix.Old, New: r.Fix.New, Count: n})
+			}
+		}
+		if dryRun || content == string(data) {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return changes, fmt.Errorf("writing %s: %w", file, err)
+		}
+		if err := os.WriteFile(path, []byte(content), info.Mode().Perm()); err != nil {
+			return changes, fmt.Errorf("writing %s: %w", file, err)
+		}
+	}
+	return changes, nil
+}
+
+// replaceToken replaces each occurrence of old in s that is outside code and
+// not immediately followed by a character continuing the token, and returns
+// the new string and the number of replacements.
+func replaceToken(s, old, replacement string) (string, int) {
+	code := codeMask(s)
+	var b strings.Builder
+	n, pos := 0, 0
+	for {
+		i := strings.Index(s[pos:], old)
+		if i < 0 {
+			b.WriteString(s[pos:])
+			return b.String(), n
+		}
+		start := pos + i
+		end := start + len(old)
+		b.WriteString(s[pos:start])
+		if !code[start] && endsToken(s[end:]) {
+			b.WriteString(replacement)
+			n++
+		} else {
+			b.WriteString(old)
+		}
+		pos = end
+	}
+}
+
+// codeMask marks the bytes of s inside fenced code blocks and inline code
+// spans, found with the same patterns link extraction strips, so that only
+// links that were checked are rewritten.
+func codeMask(s string) []bool {
+	mask := make([]bool, len(s))
+	// Blank fenced blocks before looking for inline spans, as extraction
+	// removes them first; blanking keeps offsets aligned with s.
+	blanked := []byte(s)
+	for _, loc := range util.CodeBlockStrip.FindAllStringIndex(s, -1) {
+		for i := loc[0]; i < loc[1]; i++ {
+			mask[i] = true
+			if blanked[i] != '\n' {
+				blanked[i] = ' '
+			}
+		}
+	}
+	for _, loc := range util.InlineCodeStrip.FindAllIndex(blanked, -1) {
+		for i := loc[0]; i < loc[1]; i++ {
+			mask[i] = true
+		}
+	}
+	return mask
+}
+
+// endsToken reports whether rest, the text following a match, starts at a
+// token boundary. Trailing sentence punctuation such as the period in
+// "see https://example.com." counts as a boundary.
+func endsToken(rest string) bool {
+	if rest == "" {
+		return true
+	}
+	c := rune(rest[0])
+	if c == '.' || c == ',' || c == ';' || c == ':' || c == '!' || c == '?' {
+		return len(rest) == 1 || !continuesToken(rune(rest[1]))
+	}
+	return !continuesToken(c)
+}
+
+func continuesToken(c rune) bool {
+	return unicode.IsLetter(c) || unicode.IsDigit(c) || strings.ContainsRune("/-_~%#=&+@", c)
+}
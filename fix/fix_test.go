@@ -0,0 +1,118 @@
+package fix
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/agent-ecosystem/skill-validator/types"
+)
+
+func writeFile(t *testing.T, dir, relPath, content string) {
+	t.Helper()
+	path := filepath.Join(dir, relPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, dir, relPath string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, relPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestApply(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "SKILL.md", "See [docs](https://a.example/x) and https://a.example/x.\nAlso https://a.example/xy.\n")
+	writeFile(t, dir, "references/guide.md", "[x](https://a.example/x)\n")
+
+	fx := &types.Fix{Old: "https://a.example/x", New: "https://b.example/x"}
+	results := []types.Result{
+		{Level: types.Warning, File: "SKILL.md", Line: 1, Fix: fx},
+		{Level: types.Warning, File: "references/guide.md", Line: 1, Fix: fx},
+		{Level: types.Pass, File: "SKILL.md", Line: 2},
+	}
+
+	changes, err := Apply(dir, results, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 2 {
+		t.Fatalf("expected 2 changes, got %+v", changes)
+	}
+	if changes[0].File != "SKILL.md" || changes[0].Count != 2 {
+		t.Errorf("unexpected first change: %+v", changes[0])
+	}
+
+	want := "See [docs](https://b.example/x) and https://b.example/x.\nAlso https://a.example/xy.\n"
+	if got := readFile(t, dir, "SKILL.md"); got != want {
+		t.Errorf("SKILL.md = %q, want %q", got, want)
+	}
+	if got := readFile(t, dir, "references/guide.md"); got != "[x](https://b.example/x)\n" {
+		t.Errorf("guide.md = %q", got)
+	}
+}
+
+func TestApply_SkipsCode(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "SKILL.md", "Docs: https://a.example/x\n\n```bash\ncurl https://a.example/x\n```\n\nRun `curl https://a.example/x` to fetch.\n")
+
+	results := []types.Result{{File: "SKILL.md", Line: 1, Fix: &types.Fix{Old: "https://a.example/x", New: "https://b.example/x"}}}
+	changes, err := Apply(dir, results, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 || changes[0].Count != 1 {
+		t.Errorf("expected one replacement, got %+v", changes)
+	}
+	want := "Docs: https://b.example/x\n\n```bash\ncurl https://a.example/x\n```\n\nRun `curl https://a.example/x` to fetch.\n"
+	if got := readFile(t, dir, "SKILL.md"); got != want {
+		t.Errorf("SKILL.md = %q, want %q", got, want)
+	}
+}
+
+func TestApply_DryRun(t *testing.T) {
+	dir := t.TempDir()
+	original := "[x](http://old.example)\n"
+	writeFile(t, dir, "SKILL.md", original)
+
+	results := []types.Result{{File: "SKILL.md", Fix: &types.Fix{Old: "http://old.example", New: "https://new.example"}}}
+	changes, err := Apply(dir, results, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 || changes[0].Count != 1 {
+		t.Errorf("expected one pending change, got %+v", changes)
+	}
+	if got := readFile(t, dir, "SKILL.md"); got != original {
+		t.Errorf("dry run modified the file: %q", got)
+	}
+}
+
+func TestApply_StaleFix(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "SKILL.md", "nothing to see\n")
+
+	results := []types.Result{{File: "SKILL.md", Fix: &types.Fix{Old: "http://gone.example", New: "https://new.example"}}}
+	changes, err := Apply(dir, results, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("expected no changes, got %+v", changes)
+	}
+}
+
+func TestApply_MissingFile(t *testing.T) {
+	results := []types.Result{{File: "SKILL.md", Fix: &types.Fix{Old: "a", New: "b"}}}
+	if _, err := Apply(t.TempDir(), results, false); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
@@ -3,6 +3,7 @@ module github.com/agent-ecosystem/skill-validator
 go 1.25.5
 
 require (
+	github.com/santhosh-tekuri/jsonschema/v5 v5.3.1
 	github.com/spf13/cobra v1.10.2
 	github.com/tiktoken-go/tokenizer v0.7.0
 	gopkg.in/yaml.v3 v3.0.1
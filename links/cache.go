@@ -0,0 +1,201 @@
+package links
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/agent-ecosystem/skill-validator/types"
+)
+
+const (
+	// DefaultSuccessTTL is how long a reachable link stays cached.
+	DefaultSuccessTTL = 24 * time.Hour
+	// DefaultFailureTTL is how long a failed link stays cached. It is shorter
+	// than DefaultSuccessTTL so transient failures are re-checked sooner.
+	DefaultFailureTTL = time.Hour
+)
+
+// CacheEntry records the outcome of checking a single URL.
+type CacheEntry struct {
+	URL       string              `json:"url"`
+	Status    int                 `json:"status"`              // HTTP status code; 0 if the request failed
+	FinalURL  string              `json:"final_url,omitempty"` // URL after following redirects
+	Redirects []types.RedirectHop `json:"redirects,omitempty"` // redirect chain leading to FinalURL
+	Error     string              `json:"error,omitempty"`     // request error when Status is 0
+	CheckedAt time.Time           `json:"checked_at"`
+}
+
+// Succeeded reports whether the entry records a reachable link (2xx or 3xx).
+func (e CacheEntry) Succeeded() bool {
+	return e.Status >= 200 && e.Status < 400
+}
+
+// cacheFile is the on-disk JSON layout of the link cache.
+type cacheFile struct {
+	Entries map[string]CacheEntry `json:"entries"`
+}
+
+// Cache is a persistent, TTL-based store of link check outcomes. It lets
+// repeated runs skip network requests for links that were checked recently.
+// A Cache is safe for concurrent use. Changes are only written to disk when
+// Save is called.
+type Cache struct {
+	// SuccessTTL is how long reachable links are served from the cache.
+	SuccessTTL time.Duration
+	// FailureTTL is how long failed links are served from the cache.
+	FailureTTL time.Duration
+
+	path    string
+	mu      sync.Mutex
+	entries map[string]CacheEntry
+	dirty   bool
+}
+
+// DefaultCachePath returns the default location of the link cache:
+// skill-validator/links.json inside the user's cache directory
+// (e.g. ~/.cache on Linux).
+func DefaultCachePath() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("locating user cache directory: %w", err)
+	}
+	return filepath.Join(base, "skill-validator", "links.json"), nil
+}
+
+// OpenCache loads the link cache stored at path. A missing file yields an
+// empty cache. A file that cannot be parsed is treated as empty and is
+// overwritten on the next Save, since a stale cache should never block
+// validation.
+func OpenCache(path string) (*Cache, error) {
+	c := &Cache{
+		SuccessTTL: DefaultSuccessTTL,
+		FailureTTL: DefaultFailureTTL,
+		path:       path,
+		entries:    make(map[string]CacheEntry),
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return c, nil
+		}
+		return nil, fmt.Errorf("reading link cache: %w", err)
+	}
+
+	var f cacheFile
+	if err := json.Unmarshal(data, &f); err == nil && f.Entries != nil {
+		c.entries = f.Entries
+	}
+	return c, nil
+}
+
+// Path returns the file the cache is stored in.
+func (c *Cache) Path() string {
+	return c.path
+}
+
+// Len returns the number of entries in the cache, including expired ones.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// Get returns the cached entry for url if one exists and has not expired.
+func (c *Cache) Get(url string) (CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[url]
+	if !ok || c.expired(e, time.Now()) {
+		return CacheEntry{}, false
+	}
+	return e, true
+}
+
+// Put stores an entry, replacing any existing entry for the same URL.
+func (c *Cache) Put(e CacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[e.URL] = e
+	c.dirty = true
+}
+
+// Prune removes expired entries and returns how many were removed.
+func (c *Cache) Prune() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	removed := 0
+	for url, e := range c.entries {
+		if c.expired(e, now) {
+			delete(c.entries, url)
+			removed++
+		}
+	}
+	if removed > 0 {
+		c.dirty = true
+	}
+	return removed
+}
+
+// Clear removes every entry and returns how many were removed.
+func (c *Cache) Clear() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	removed := len(c.entries)
+	c.entries = make(map[string]CacheEntry)
+	if removed > 0 {
+		c.dirty = true
+	}
+	return removed
+}
+
+// Save writes the cache to disk if it has changed since it was opened.
+// The file is replaced atomically so concurrent readers never see a
+// partially written cache.
+func (c *Cache) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.dirty {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return fmt.Errorf("creating link cache directory: %w", err)
+	}
+	data, err := json.MarshalIndent(cacheFile{Entries: c.entries}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling link cache: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), ".links-*.json")
+	if err != nil {
+		return fmt.Errorf("writing link cache: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("writing link cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("writing link cache: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.path); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("writing link cache: %w", err)
+	}
+	c.dirty = false
+	return nil
+}
+
+func (c *Cache) expired(e CacheEntry, now time.Time) bool {
+	ttl := c.FailureTTL
+	if e.Succeeded() {
+		ttl = c.SuccessTTL
+	}
+	return now.Sub(e.CheckedAt) > ttl
+}
@@ -0,0 +1,109 @@
+package links
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestOpenCache_Missing(t *testing.T) {
+	c, err := OpenCache(filepath.Join(t.TempDir(), "links.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Len() != 0 {
+		t.Errorf("expected empty cache, got %d entries", c.Len())
+	}
+	if c.SuccessTTL != DefaultSuccessTTL || c.FailureTTL != DefaultFailureTTL {
+		t.Errorf("unexpected default TTLs: %v, %v", c.SuccessTTL, c.FailureTTL)
+	}
+}
+
+func TestOpenCache_Corrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "links.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c, err := OpenCache(path)
+	if err != nil {
+		t.Fatalf("expected corrupt cache to be treated as empty, got %v", err)
+	}
+	if c.Len() != 0 {
+		t.Errorf("expected empty cache, got %d entries", c.Len())
+	}
+}
+
+func TestCache_GetRespectsTTL(t *testing.T) {
+	c, err := OpenCache(filepath.Join(t.TempDir(), "links.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SuccessTTL = time.Hour
+	c.FailureTTL = time.Minute
+
+	now := time.Now()
+	c.Put(CacheEntry{URL: "https://ok.example", Status: 200, CheckedAt: now.Add(-30 * time.Minute)})
+	c.Put(CacheEntry{URL: "https://gone.example", Status: 404, CheckedAt: now.Add(-30 * time.Minute)})
+	c.Put(CacheEntry{URL: "https://down.example", Error: "request failed: timeout", CheckedAt: now})
+	c.Put(CacheEntry{URL: "https://old.example", Status: 200, CheckedAt: now.Add(-2 * time.Hour)})
+
+	if _, ok := c.Get("https://ok.example"); !ok {
+		t.Error("expected fresh success to be served from cache")
+	}
+	if _, ok := c.Get("https://gone.example"); ok {
+		t.Error("expected failure older than FailureTTL to be expired")
+	}
+	if e, ok := c.Get("https://down.example"); !ok || e.Error == "" {
+		t.Errorf("expected fresh failure with error to be served, got %+v, %v", e, ok)
+	}
+	if _, ok := c.Get("https://old.example"); ok {
+		t.Error("expected success older than SuccessTTL to be expired")
+	}
+	if _, ok := c.Get("https://unknown.example"); ok {
+		t.Error("expected miss for unknown URL")
+	}
+
+	if removed := c.Prune(); removed != 2 {
+		t.Errorf("Prune() removed %d, want 2", removed)
+	}
+	if c.Len() != 2 {
+		t.Errorf("expected 2 entries after prune, got %d", c.Len())
+	}
+	if removed := c.Clear(); removed != 2 {
+		t.Errorf("Clear() removed %d, want 2", removed)
+	}
+}
+
+func TestCache_SaveAndReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "links.json")
+	c, err := OpenCache(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Saving an unchanged cache does not create the file.
+	if err := c.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected no file for unchanged cache, got %v", err)
+	}
+
+	checked := time.Now().UTC().Truncate(time.Second)
+	c.Put(CacheEntry{URL: "https://example.com", Status: 200, FinalURL: "https://www.example.com/", CheckedAt: checked})
+	if err := c.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded, err := OpenCache(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, ok := reloaded.Get("https://example.com")
+	if !ok {
+		t.Fatal("expected entry after reload")
+	}
+	if e.Status != 200 || e.FinalURL != "https://www.example.com/" || !e.CheckedAt.Equal(checked) {
+		t.Errorf("unexpected entry after reload: %+v", e)
+	}
+}
@@ -2,41 +2,101 @@ package links
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net"
 	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/agent-ecosystem/skill-validator/types"
 )
 
-type linkResult struct {
-	url    string
-	result types.Result
+// source is a markdown file whose links are checked.
+type source struct {
+	file       string // path relative to the skill dir
+	content    string
+	lineOffset int // file lines before content, added to link lines
+}
+
+// Options configures external link checking. The zero value checks every
+// link over the network without caching.
+type Options struct {
+	// Cache, when non-nil, serves recently checked URLs without a network
+	// request and records the outcome of new checks. Callers are responsible
+	// for calling Cache.Save once checking is done.
+	Cache *Cache
+
+	// MaxConcurrency caps simultaneous requests across all hosts.
+	// Zero uses DefaultMaxConcurrency.
+	MaxConcurrency int
+	// MaxPerHost caps simultaneous requests to a single host.
+	// Zero uses DefaultMaxPerHost.
+	MaxPerHost int
+	// Retries is the number of extra attempts for requests that were rate
+	// limited (429), hit a 503, or timed out. Zero uses DefaultRetries;
+	// a negative value disables retries.
+	Retries int
+	// BaseBackoff is the wait before the first retry; it doubles on each
+	// later retry. Zero uses DefaultBaseBackoff.
+	BaseBackoff time.Duration
+	// MaxRetryWait is the longest Retry-After delay that is honoured.
+	// Zero uses DefaultMaxRetryWait.
+	MaxRetryWait time.Duration
+
+	// Policy holds rules every link must satisfy. They are checked before
+	// any network request.
+	Policy Policy
+	// Offline limits checking to URL syntax and Policy; nothing is fetched
+	// and the cache is not consulted.
+	Offline bool
 }
 
 // CheckLinks validates external (HTTP/HTTPS) links in the skill body.
 func CheckLinks(ctx context.Context, dir, body string) []types.Result {
-	rctx := types.ResultContext{Category: "Links", File: "SKILL.md"}
-	allLinks := ExtractLinks(body)
-	if len(allLinks) == 0 {
-		return nil
-	}
-
-	var (
-		results   []types.Result
-		httpLinks []string
-		mu        sync.Mutex
-		wg        sync.WaitGroup
-	)
+	return checkSources(ctx, []source{{file: "SKILL.md", content: body}}, Options{})
+}
 
-	// Collect HTTP links only
-	for _, link := range allLinks {
-		// Skip template URLs containing {placeholder} variables (RFC 6570 URI Templates)
-		if strings.Contains(link, "{") {
+// CheckSkillLinks validates external (HTTP/HTTPS) links in the skill body and
+// in every markdown file under references/ and assets/. Each unique URL is
+// fetched once, and its result is reported for every file that links to it.
+// bodyLineOffset is the number of SKILL.md lines before the body, added to
+// body link lines so they match the file.
+func CheckSkillLinks(ctx context.Context, dir, body string, bodyLineOffset int, opts Options) []types.Result {
+	sources := []source{{file: "SKILL.md", content: body, lineOffset: bodyLineOffset}}
+	for _, rel := range MarkdownFiles(dir) {
+		data, err := os.ReadFile(filepath.Join(dir, rel))
+		if err != nil {
 			continue
 		}
-		if strings.HasPrefix(link, "http://") || strings.HasPrefix(link, "https://") {
-			httpLinks = append(httpLinks, link)
+		sources = append(sources, source{file: rel, content: string(data)})
+	}
+	return checkSources(ctx, sources, opts)
+}
+
+// checkSources extracts HTTP links from each source, checks every unique URL
+// once, and attributes the outcome to each file and line where it appears.
+func checkSources(ctx context.Context, sources []source, opts Options) []types.Result {
+	rctx := types.ResultContext{Category: "Links"}
+
+	// Collect HTTP links only, per source
+	perSource := make([][]Link, len(sources))
+	var httpLinks []string
+	seen := make(map[string]bool)
+	for i, src := range sources {
+		for _, link := range ExtractLinkLocations(src.content) {
+			if !isHTTPLink(link.URL) {
+				continue
+			}
+			perSource[i] = append(perSource[i], link)
+			if !seen[link.URL] {
+				seen[link.URL] = true
+				httpLinks = append(httpLinks, link.URL)
+			}
 		}
 	}
 
@@ -44,71 +104,183 @@ func CheckLinks(ctx context.Context, dir, body string) []types.Result {
 		return nil
 	}
 
+	// Apply syntax and policy rules before any network I/O, then serve
+	// recently checked URLs from the cache
+	checked := make(map[string]types.Result, len(httpLinks))
+	var toFetch []string
+	for _, url := range httpLinks {
+		if v, r := opts.Policy.evaluate(rctx, url); v != verdictCheck {
+			checked[url] = r
+			continue
+		}
+		if opts.Offline {
+			checked[url] = rctx.Passf("%s (valid; not fetched in offline mode)", url)
+			continue
+		}
+		if opts.Cache != nil {
+			if e, ok := opts.Cache.Get(url); ok {
+				r := classifyFetch(rctx, url, fetchResult{
+					status:    e.Status,
+					finalURL:  e.FinalURL,
+					redirects: e.Redirects,
+					err:       e.Error,
+				})
+				r.Message += " (cached)"
+				checked[url] = r
+				continue
+			}
+		}
+		toFetch = append(toFetch, url)
+	}
+
 	// Shared client for connection reuse across concurrent checks.
 	// The client uses a safe transport that blocks requests to private IPs.
 	client := newHTTPClient()
+	lim := newLimiter(opts.maxConcurrency(), opts.maxPerHost())
 
-	// Check HTTP links concurrently
-	httpResults := make([]linkResult, len(httpLinks))
-	for i, link := range httpLinks {
+	// Check HTTP links concurrently, within the global and per-host limits
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for _, link := range toFetch {
 		wg.Add(1)
-		go func(idx int, url string) {
+		go func(url string) {
 			defer wg.Done()
-			r := checkHTTPLink(rctx, client, url)
+			f := fetchWithRetry(ctx, client, lim, url, opts)
+			// Rate limiting says nothing about the link itself, and neither
+			// does a request cut short by the run being cancelled or timing
+			// out, so neither is cached.
+			if opts.Cache != nil && !f.invalid && f.status != http.StatusTooManyRequests && ctx.Err() == nil {
+				opts.Cache.Put(CacheEntry{
+					URL:       url,
+					Status:    f.status,
+					FinalURL:  f.finalURL,
+					Redirects: f.redirects,
+					Error:     f.err,
+					CheckedAt: time.Now(),
+				})
+			}
+			r := classifyFetch(rctx, url, f)
 			mu.Lock()
-			httpResults[idx] = linkResult{url: url, result: r}
+			checked[url] = r
 			mu.Unlock()
-		}(i, link)
+		}(link)
 	}
 	wg.Wait()
 
-	for _, hr := range httpResults {
-		results = append(results, hr.result)
+	var results []types.Result
+	for i, src := range sources {
+		// Report in document order so results read top to bottom.
+		slices.SortStableFunc(perSource[i], func(a, b Link) int { return a.Line - b.Line })
+		for _, link := range perSource[i] {
+			results = append(results, attribute(checked[link.URL], src.file, link.Line+src.lineOffset))
+		}
 	}
 
 	return results
 }
 
-func checkHTTPLink(rctx types.ResultContext, client *http.Client, url string) types.Result {
-	req, err := http.NewRequest("HEAD", url, nil)
-	if err != nil {
-		return rctx.Errorf("%s (invalid URL: %v)", url, err)
+// isHTTPLink reports whether link is an HTTP(S) URL that should be checked.
+func isHTTPLink(link string) bool {
+	// Skip template URLs containing {placeholder} variables (RFC 6570 URI Templates)
+	if strings.Contains(link, "{") {
+		return false
 	}
-	req.Header.Set("User-Agent", "skill-validator/1.0")
-	req.Header.Set("Accept", "text/html, */*;q=0.1")
+	return strings.HasPrefix(link, "http://") || strings.HasPrefix(link, "https://")
+}
 
-	resp, err := client.Do(req)
-	if err != nil {
-		return rctx.Errorf("%s (request failed: %v)", url, err)
+// attribute places a link result at a file and line. Results for files other
+// than SKILL.md are prefixed with the file path so they can be told apart in
+// text output.
+func attribute(r types.Result, file string, line int) types.Result {
+	r.File = file
+	r.Line = line
+	if file != "SKILL.md" {
+		r.Message = file + ": " + r.Message
 	}
-	defer func() { _ = resp.Body.Close() }()
+	return r
+}
+
+// fetchResult is the outcome of requesting a single URL.
+type fetchResult struct {
+	status     int                 // HTTP status code; 0 if the request could not be made
+	finalURL   string              // URL after following redirects
+	redirects  []types.RedirectHop // redirects followed to reach finalURL
+	err        string              // "invalid URL: ..." or "request failed: ..." when status is 0
+	invalid    bool                // the URL could not be parsed; never cached
+	timeout    bool                // the request timed out
+	retryAfter time.Duration       // delay requested by a Retry-After header
+	attempts   int                 // number of attempts made, including retries
+}
+
+func checkHTTPLink(rctx types.ResultContext, client *http.Client, url string) types.Result {
+	return classifyFetch(rctx, url, fetchLink(context.Background(), client, url))
+}
+
+// fetchLink requests url with HEAD, falling back to GET when needed.
+func fetchLink(ctx context.Context, client *http.Client, url string) fetchResult {
+	f := fetch(ctx, client, http.MethodHead, url)
 
 	// Some sites don't handle HEAD correctly (e.g. SPAs like crates.io return
 	// 404 for HEAD even though the page exists). Fall back to GET when HEAD
 	// returns 404 or 405, which is the standard approach used by lychee,
 	// markdown-link-check, and other link validators.
-	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusMethodNotAllowed {
-		return checkHTTPLinkGET(rctx, client, url)
+	if f.status == http.StatusNotFound || f.status == http.StatusMethodNotAllowed {
+		return fetch(ctx, client, http.MethodGet, url)
 	}
-
-	return classifyResponse(rctx, url, resp.StatusCode)
+	return f
 }
 
-func checkHTTPLinkGET(rctx types.ResultContext, client *http.Client, url string) types.Result {
-	req, err := http.NewRequest("GET", url, nil)
+func fetch(ctx context.Context, client *http.Client, method, url string) fetchResult {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
 	if err != nil {
-		return rctx.Errorf("%s (invalid URL: %v)", url, err)
+		return fetchResult{err: fmt.Sprintf("invalid URL: %v", err), invalid: true}
 	}
 	req.Header.Set("User-Agent", "skill-validator/1.0")
 	req.Header.Set("Accept", "text/html, */*;q=0.1")
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return rctx.Errorf("%s (request failed: %v)", url, err)
+		var netErr net.Error
+		timeout := errors.As(err, &netErr) && netErr.Timeout()
+		return fetchResult{err: fmt.Sprintf("request failed: %v", err), timeout: timeout}
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	return classifyResponse(rctx, url, resp.StatusCode)
+	return fetchResult{
+		status:     resp.StatusCode,
+		finalURL:   resp.Request.URL.String(),
+		redirects:  redirectChain(resp),
+		retryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+	}
+}
+
+func classifyFetch(rctx types.ResultContext, url string, f fetchResult) types.Result {
+	var r types.Result
+	switch {
+	case f.status == 0:
+		r = rctx.Errorf("%s (%s)", url, f.err)
+	case f.status >= 200 && f.status < 300 && len(f.redirects) > 0:
+		r = classifyRedirects(rctx, url, f.status, f.redirects)
+	case len(f.redirects) > 0:
+		r = classifyResponse(rctx, url, f.status)
+		r.Message += " (after redirects: " + hopList(f.redirects) + ")"
+	default:
+		r = classifyResponse(rctx, url, f.status)
+	}
+	// The chain is recorded whatever the final status: for a broken link,
+	// where it ended up is what the author needs to know.
+	if len(f.redirects) > 0 {
+		r.Redirects = &types.Redirects{Hops: f.redirects, FinalURL: f.finalURL}
+		if r.Redirects.FinalURL == "" {
+			r.Redirects.FinalURL = f.redirects[len(f.redirects)-1].URL
+		}
+	}
+	if f.attempts > 1 && r.Level != types.Pass {
+		r.Message += fmt.Sprintf(" (after %d attempts)", f.attempts)
+	}
+	return r
 }
 
 func classifyResponse(rctx types.ResultContext, url string, statusCode int) types.Result {
@@ -121,5 +293,8 @@ func classifyResponse(rctx types.ResultContext, url string, statusCode int) type
 	if statusCode == http.StatusForbidden {
 		return rctx.Infof("%s (HTTP 403 — may block automated requests)", url)
 	}
+	if statusCode == http.StatusTooManyRequests {
+		return rctx.Infof("%s (HTTP 429 — rate limited, could not verify)", url)
+	}
 	return rctx.Errorf("%s (HTTP %d)", url, statusCode)
 }
@@ -1,15 +1,18 @@
 package links
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/agent-ecosystem/skill-validator/skill"
 	"github.com/agent-ecosystem/skill-validator/types"
 )
 
@@ -39,6 +42,22 @@ func requireResultContaining(t *testing.T, results []types.Result, level types.L
 	}
 }
 
+func useTestClient(t *testing.T) {
+	t.Helper()
+	orig := newHTTPClient
+	newHTTPClient = func() *http.Client { return testHTTPClient() }
+	t.Cleanup(func() { newHTTPClient = orig })
+}
+
+func requireNoLevel(t *testing.T, results []types.Result, level types.Level) {
+	t.Helper()
+	for _, r := range results {
+		if r.Level == level {
+			t.Errorf("unexpected %s result: %s", level, r.Message)
+		}
+	}
+}
+
 func requireContains(t *testing.T, s, substr string) {
 	t.Helper()
 	if !strings.Contains(s, substr) {
@@ -175,6 +194,164 @@ func TestCheckLinks_HTTP(t *testing.T) {
 	})
 }
 
+func TestCheckSkillLinks(t *testing.T) {
+	orig := newHTTPClient
+	newHTTPClient = func() *http.Client { return testHTTPClient() }
+	t.Cleanup(func() { newHTTPClient = orig })
+
+	var mu sync.Mutex
+	hits := map[string]int{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		hits[r.URL.Path]++
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/gone", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	dir := t.TempDir()
+	body := "# Skill\n\nSee [ok](" + server.URL + "/ok)."
+	writeFile(t, dir, "references/guide.md", "# Guide\n\nAlso "+server.URL+"/ok\n\n[gone]("+server.URL+"/gone)\n")
+	writeFile(t, dir, "assets/template.md", "No links.")
+
+	results := CheckSkillLinks(t.Context(), dir, body, 0, Options{})
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d: %+v", len(results), results)
+	}
+
+	mu.Lock()
+	if hits["/ok"] != 1 {
+		t.Errorf("expected /ok to be fetched once, got %d", hits["/ok"])
+	}
+	mu.Unlock()
+
+	if results[0].File != "SKILL.md" || results[0].Line != 3 {
+		t.Errorf("results[0] at %s:%d, want SKILL.md:3", results[0].File, results[0].Line)
+	}
+	guide := filepath.Join("references", "guide.md")
+	for _, r := range results[1:] {
+		if r.File != guide {
+			t.Errorf("expected result attributed to %s, got %s", guide, r.File)
+		}
+		requireContains(t, r.Message, guide+": ")
+	}
+	requireResultContaining(t, results, types.Error, "/gone (HTTP 404)")
+	if results[2].Line != 5 {
+		t.Errorf("expected broken link on line 5, got %d", results[2].Line)
+	}
+}
+
+func TestCheckSkillLinks_SkillLines(t *testing.T) {
+	orig := newHTTPClient
+	newHTTPClient = func() *http.Client { return testHTTPClient() }
+	t.Cleanup(func() { newHTTPClient = orig })
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	writeFile(t, dir, "SKILL.md", "---\nname: test\ndescription: A test skill.\n---\n# Title\n\nSee [gone]("+server.URL+"/gone).\n")
+	s, err := skill.Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	results := CheckSkillLinks(t.Context(), dir, s.Body, s.BodyLineOffset(), Options{})
+	requireResultContaining(t, results, types.Error, "/gone (HTTP 404)")
+	if results[0].File != "SKILL.md" || results[0].Line != 7 {
+		t.Errorf("result at %s:%d, want SKILL.md:7", results[0].File, results[0].Line)
+	}
+}
+
+func TestCheckSkillLinks_Cache(t *testing.T) {
+	orig := newHTTPClient
+	newHTTPClient = func() *http.Client { return testHTTPClient() }
+	t.Cleanup(func() { newHTTPClient = orig })
+
+	var mu sync.Mutex
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		hits++
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cache, err := OpenCache(filepath.Join(t.TempDir(), "links.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := Options{Cache: cache}
+	dir := t.TempDir()
+	body := "[ok](" + server.URL + "/page)"
+
+	first := CheckSkillLinks(t.Context(), dir, body, 0, opts)
+	requireResultContaining(t, first, types.Pass, "HTTP 200")
+	if strings.Contains(first[0].Message, "(cached)") {
+		t.Errorf("first check should not be cached: %q", first[0].Message)
+	}
+	if e, ok := cache.Get(server.URL + "/page"); !ok || e.Status != 200 || e.FinalURL != server.URL+"/page" {
+		t.Errorf("expected cache entry after first check, got %+v, %v", e, ok)
+	}
+
+	second := CheckSkillLinks(t.Context(), dir, body, 0, opts)
+	requireResultContaining(t, second, types.Pass, "HTTP 200) (cached)")
+
+	mu.Lock()
+	defer mu.Unlock()
+	if hits != 1 {
+		t.Errorf("expected 1 request across both checks, got %d", hits)
+	}
+}
+
+func TestCheckSkillLinks_CachedFailure(t *testing.T) {
+	cache, err := OpenCache(filepath.Join(t.TempDir(), "links.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	url := "https://unreachable.example.invalid/docs"
+	cache.Put(CacheEntry{URL: url, Error: "request failed: no such host", CheckedAt: time.Now()})
+
+	results := CheckSkillLinks(t.Context(), t.TempDir(), "See "+url, 0, Options{Cache: cache})
+	requireResultContaining(t, results, types.Error, "(request failed: no such host) (cached)")
+}
+
+func TestCheckSkillLinks_CancelledRunIsNotCached(t *testing.T) {
+	useTestClient(t)
+
+	started := make(chan struct{})
+	var once sync.Once
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		once.Do(func() { close(started) })
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	cache, err := OpenCache(filepath.Join(t.TempDir(), "links.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(t.Context())
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	results := CheckSkillLinks(ctx, t.TempDir(), "[slow]("+server.URL+"/slow)", 0, Options{Cache: cache})
+	requireResultContaining(t, results, types.Error, "context canceled")
+	if n := cache.Len(); n != 0 {
+		e, _ := cache.Get(server.URL + "/slow")
+		t.Errorf("expected nothing cached after cancellation, got %d entries (%+v)", n, e)
+	}
+}
+
 func testHTTPClient() *http.Client {
 	return &http.Client{Timeout: 5 * time.Second, CheckRedirect: func(req *http.Request, via []*http.Request) error {
 		if len(via) >= 10 {
@@ -199,7 +376,7 @@ func TestCheckHTTPLink(t *testing.T) {
 		mux := http.NewServeMux()
 		mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
 			w.Header().Set("Location", "/dest")
-			w.WriteHeader(http.StatusMovedPermanently)
+			w.WriteHeader(http.StatusFound)
 		})
 		mux.HandleFunc("/dest", func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusOK)
@@ -211,6 +388,7 @@ func TestCheckHTTPLink(t *testing.T) {
 		if result.Level != types.Pass {
 			t.Errorf("expected Pass for followed redirect, got level=%d message=%q", result.Level, result.Message)
 		}
+		requireContains(t, result.Message, "302 → "+server.URL+"/dest")
 	})
 
 	t.Run("redirect without follow results in 3xx", func(t *testing.T) {
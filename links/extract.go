@@ -17,36 +17,66 @@ var (
 	bareURLPattern = regexp.MustCompile("(?:^|\\s)(https?://[^\\s<>\\)`]+)")
 )
 
+// Link is a link found in markdown content along with the 1-based line
+// number of its first occurrence.
+type Link struct {
+	URL  string
+	Line int
+}
+
 // ExtractLinks extracts all unique links from a markdown body.
 func ExtractLinks(body string) []string {
+	locs := ExtractLinkLocations(body)
+	links := make([]string, 0, len(locs))
+	for _, l := range locs {
+		links = append(links, l.URL)
+	}
+	return links
+}
+
+// ExtractLinkLocations extracts all unique links from markdown content,
+// recording the line on which each link first appears. Markdown links are
+// returned before bare URLs, matching the order of [ExtractLinks].
+func ExtractLinkLocations(content string) []Link {
 	seen := make(map[string]bool)
-	var links []string
+	var links []Link
 
 	// Strip code fences and inline code spans so URLs in code are not extracted.
-	cleaned := util.CodeBlockStrip.ReplaceAllString(body, "")
-	cleaned = util.InlineCodeStrip.ReplaceAllString(cleaned, "")
+	// Newlines inside the stripped spans are kept so line numbers stay accurate.
+	cleaned := util.CodeBlockStrip.ReplaceAllStringFunc(content, keepNewlines)
+	cleaned = util.InlineCodeStrip.ReplaceAllStringFunc(cleaned, keepNewlines)
 
 	// Markdown links
-	for _, match := range mdLinkPattern.FindAllStringSubmatch(cleaned, -1) {
-		url := strings.TrimSpace(match[2])
+	for _, idx := range mdLinkPattern.FindAllStringSubmatchIndex(cleaned, -1) {
+		url := strings.TrimSpace(cleaned[idx[4]:idx[5]])
 		if !seen[url] {
 			seen[url] = true
-			links = append(links, url)
+			links = append(links, Link{URL: url, Line: lineAt(cleaned, idx[4])})
 		}
 	}
 
 	// Bare URLs
-	for _, match := range bareURLPattern.FindAllStringSubmatch(cleaned, -1) {
-		url := trimTrailingDelimiters(strings.TrimSpace(match[1]))
+	for _, idx := range bareURLPattern.FindAllStringSubmatchIndex(cleaned, -1) {
+		url := trimTrailingDelimiters(strings.TrimSpace(cleaned[idx[2]:idx[3]]))
 		if !seen[url] {
 			seen[url] = true
-			links = append(links, url)
+			links = append(links, Link{URL: url, Line: lineAt(cleaned, idx[2])})
 		}
 	}
 
 	return links
 }
 
+// keepNewlines replaces a matched span with only the newlines it contained.
+func keepNewlines(s string) string {
+	return strings.Repeat("\n", strings.Count(s, "\n"))
+}
+
+// lineAt returns the 1-based line number of the byte offset in s.
+func lineAt(s string, offset int) int {
+	return strings.Count(s[:offset], "\n") + 1
+}
+
 var entitySuffix = regexp.MustCompile(`&[a-zA-Z0-9]+;$`)
 
 // trimTrailingDelimiters strips trailing punctuation and entity references
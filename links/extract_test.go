@@ -132,3 +132,30 @@ func TestTrimTrailingDelimiters(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractLinkLocations(t *testing.T) {
+	t.Run("records line of first occurrence", func(t *testing.T) {
+		body := "# Title\n\nSee [guide](guide.md).\n\nVisit https://example.com today.\nAgain [guide](guide.md)."
+		links := ExtractLinkLocations(body)
+		if len(links) != 2 {
+			t.Fatalf("expected 2 links, got %d: %v", len(links), links)
+		}
+		if links[0].URL != "guide.md" || links[0].Line != 3 {
+			t.Errorf("links[0] = %+v, want guide.md at line 3", links[0])
+		}
+		if links[1].URL != "https://example.com" || links[1].Line != 5 {
+			t.Errorf("links[1] = %+v, want https://example.com at line 5", links[1])
+		}
+	})
+
+	t.Run("code blocks do not shift line numbers", func(t *testing.T) {
+		body := "```bash\ncurl https://skipped.example.com\necho done\n```\n[after](https://example.com/after)"
+		links := ExtractLinkLocations(body)
+		if len(links) != 1 {
+			t.Fatalf("expected 1 link, got %d: %v", len(links), links)
+		}
+		if links[0].Line != 5 {
+			t.Errorf("line = %d, want 5", links[0].Line)
+		}
+	})
+}
@@ -0,0 +1,45 @@
+package links
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// linkedDirs lists the skill subdirectories whose markdown files are scanned
+// for links in addition to SKILL.md.
+var linkedDirs = []string{"references", "assets"}
+
+// MarkdownFiles returns the paths (relative to dir) of all markdown files
+// under references/ and assets/, in sorted order. Hidden files and
+// directories are skipped.
+func MarkdownFiles(dir string) []string {
+	var files []string
+	for _, d := range linkedDirs {
+		root := filepath.Join(dir, d)
+		_ = filepath.WalkDir(root, func(path string, entry os.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			name := entry.Name()
+			if entry.IsDir() {
+				if strings.HasPrefix(name, ".") && path != root {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if strings.HasPrefix(name, ".") || !strings.EqualFold(filepath.Ext(name), ".md") {
+				return nil
+			}
+			rel, err := filepath.Rel(dir, path)
+			if err != nil {
+				return nil
+			}
+			files = append(files, rel)
+			return nil
+		})
+	}
+	sort.Strings(files)
+	return files
+}
@@ -0,0 +1,34 @@
+package links
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestMarkdownFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "SKILL.md", "# Skill")
+	writeFile(t, dir, "references/guide.md", "guide")
+	writeFile(t, dir, "references/nested/deep.MD", "deep")
+	writeFile(t, dir, "references/data.json", "{}")
+	writeFile(t, dir, "references/.hidden.md", "hidden")
+	writeFile(t, dir, "assets/template.md", "template")
+	writeFile(t, dir, "scripts/notes.md", "not scanned")
+
+	got := MarkdownFiles(dir)
+	want := []string{
+		filepath.Join("assets", "template.md"),
+		filepath.Join("references", "guide.md"),
+		filepath.Join("references", "nested", "deep.MD"),
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("MarkdownFiles() = %v, want %v", got, want)
+	}
+}
+
+func TestMarkdownFiles_NoDirs(t *testing.T) {
+	if got := MarkdownFiles(t.TempDir()); len(got) != 0 {
+		t.Errorf("expected no files, got %v", got)
+	}
+}
@@ -0,0 +1,196 @@
+package links
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultMaxConcurrency is the default cap on simultaneous requests
+	// across all hosts.
+	DefaultMaxConcurrency = 16
+	// DefaultMaxPerHost is the default cap on simultaneous requests to a
+	// single host. Keeping this low avoids tripping rate limits on sites
+	// like GitHub that a skill often links to many times.
+	DefaultMaxPerHost = 2
+	// DefaultRetries is the default number of extra attempts for requests
+	// that were rate limited, hit a 503, or timed out.
+	DefaultRetries = 2
+	// DefaultBaseBackoff is the wait before the first retry. Each later
+	// retry waits twice as long as the one before.
+	DefaultBaseBackoff = time.Second
+	// DefaultMaxRetryWait is the longest Retry-After delay that is honoured.
+	// Servers asking for a longer wait are reported as rate limited without
+	// retrying.
+	DefaultMaxRetryWait = 30 * time.Second
+)
+
+// maxConcurrency returns the effective global concurrency cap.
+func (o Options) maxConcurrency() int {
+	if o.MaxConcurrency > 0 {
+		return o.MaxConcurrency
+	}
+	return DefaultMaxConcurrency
+}
+
+// maxPerHost returns the effective per-host concurrency cap.
+func (o Options) maxPerHost() int {
+	if o.MaxPerHost > 0 {
+		return o.MaxPerHost
+	}
+	return DefaultMaxPerHost
+}
+
+// retries returns the effective number of retries.
+func (o Options) retries() int {
+	switch {
+	case o.Retries < 0:
+		return 0
+	case o.Retries == 0:
+		return DefaultRetries
+	default:
+		return o.Retries
+	}
+}
+
+// baseBackoff returns the effective wait before the first retry.
+func (o Options) baseBackoff() time.Duration {
+	if o.BaseBackoff > 0 {
+		return o.BaseBackoff
+	}
+	return DefaultBaseBackoff
+}
+
+// maxRetryWait returns the longest Retry-After delay that is honoured.
+func (o Options) maxRetryWait() time.Duration {
+	if o.MaxRetryWait > 0 {
+		return o.MaxRetryWait
+	}
+	return DefaultMaxRetryWait
+}
+
+// limiter bounds the number of in-flight requests globally and per host.
+type limiter struct {
+	global  chan struct{}
+	perHost int
+
+	mu    sync.Mutex
+	hosts map[string]chan struct{}
+}
+
+func newLimiter(global, perHost int) *limiter {
+	return &limiter{
+		global:  make(chan struct{}, global),
+		perHost: perHost,
+		hosts:   make(map[string]chan struct{}),
+	}
+}
+
+// acquire blocks until a request to host may start. The host slot is taken
+// before the global slot so a busy host never holds global capacity while it
+// waits. The returned function releases both slots.
+func (l *limiter) acquire(ctx context.Context, host string) (func(), error) {
+	l.mu.Lock()
+	hostSem, ok := l.hosts[host]
+	if !ok {
+		hostSem = make(chan struct{}, l.perHost)
+		l.hosts[host] = hostSem
+	}
+	l.mu.Unlock()
+
+	select {
+	case hostSem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	select {
+	case l.global <- struct{}{}:
+	case <-ctx.Done():
+		<-hostSem
+		return nil, ctx.Err()
+	}
+	return func() {
+		<-l.global
+		<-hostSem
+	}, nil
+}
+
+// hostOf returns the lowercased host of rawURL, or rawURL itself if it
+// cannot be parsed (so unparseable URLs still get their own slot).
+func hostOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return rawURL
+	}
+	return strings.ToLower(u.Host)
+}
+
+// retryable reports whether a fetch is worth repeating: the server was rate
+// limiting, temporarily unavailable, or the request timed out.
+func (f fetchResult) retryable() bool {
+	return f.status == http.StatusTooManyRequests ||
+		f.status == http.StatusServiceUnavailable ||
+		f.timeout
+}
+
+// fetchWithRetry fetches rawURL within the limiter's bounds, retrying
+// retryable failures with exponential backoff. A Retry-After header replaces
+// the computed backoff; one longer than the configured maximum ends retries
+// immediately.
+func fetchWithRetry(ctx context.Context, client *http.Client, lim *limiter, rawURL string, opts Options) fetchResult {
+	host := hostOf(rawURL)
+	backoff := opts.baseBackoff()
+	for attempt := 0; ; attempt++ {
+		release, err := lim.acquire(ctx, host)
+		if err != nil {
+			return fetchResult{err: "request failed: " + err.Error()}
+		}
+		f := fetchLink(ctx, client, rawURL)
+		release()
+		f.attempts = attempt + 1
+
+		if !f.retryable() || attempt >= opts.retries() {
+			return f
+		}
+		wait := backoff
+		if f.retryAfter > 0 {
+			if f.retryAfter > opts.maxRetryWait() {
+				return f
+			}
+			wait = f.retryAfter
+		}
+		select {
+		case <-ctx.Done():
+			return f
+		case <-time.After(wait):
+		}
+		backoff *= 2
+	}
+}
+
+// parseRetryAfter parses a Retry-After header value, which is either a
+// number of seconds or an HTTP date. It returns 0 if the value is missing
+// or invalid.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
@@ -0,0 +1,185 @@
+package links
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/agent-ecosystem/skill-validator/types"
+)
+
+// fastRetries returns options with retry delays short enough for tests.
+func fastRetries() Options {
+	return Options{BaseBackoff: time.Millisecond}
+}
+
+func TestCheckSkillLinks_RetriesRateLimited(t *testing.T) {
+	useTestClient(t)
+
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) <= 2 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	results := CheckSkillLinks(t.Context(), t.TempDir(), "[x]("+server.URL+")", 0, fastRetries())
+	requireResultContaining(t, results, types.Pass, "HTTP 200")
+	if got := hits.Load(); got != 3 {
+		t.Errorf("expected 3 requests, got %d", got)
+	}
+}
+
+func TestCheckSkillLinks_PersistentRateLimitIsInfo(t *testing.T) {
+	useTestClient(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	cache, err := OpenCache(t.TempDir() + "/links.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := fastRetries()
+	opts.Cache = cache
+
+	results := CheckSkillLinks(t.Context(), t.TempDir(), "[x]("+server.URL+")", 0, opts)
+	requireResultContaining(t, results, types.Info, "rate limited")
+	requireResultContaining(t, results, types.Info, "(after 3 attempts)")
+	if cache.Len() != 0 {
+		t.Errorf("rate-limited results should not be cached, got %d entries", cache.Len())
+	}
+}
+
+func TestCheckSkillLinks_Retries503ThenGivesUp(t *testing.T) {
+	useTestClient(t)
+
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	opts := fastRetries()
+	opts.Retries = 1
+	results := CheckSkillLinks(t.Context(), t.TempDir(), "[x]("+server.URL+")", 0, opts)
+	requireResultContaining(t, results, types.Error, "HTTP 503) (after 2 attempts)")
+	if got := hits.Load(); got != 2 {
+		t.Errorf("expected 2 requests, got %d", got)
+	}
+}
+
+func TestCheckSkillLinks_NoRetryOn404(t *testing.T) {
+	useTestClient(t)
+
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	results := CheckSkillLinks(t.Context(), t.TempDir(), "[x]("+server.URL+")", 0, fastRetries())
+	requireResultContaining(t, results, types.Error, "HTTP 404")
+	// One HEAD plus the GET fallback, no retries.
+	if got := hits.Load(); got != 2 {
+		t.Errorf("expected 2 requests, got %d", got)
+	}
+}
+
+func TestCheckSkillLinks_LongRetryAfterIsNotHonoured(t *testing.T) {
+	useTestClient(t)
+
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	results := CheckSkillLinks(t.Context(), t.TempDir(), "[x]("+server.URL+")", 0, fastRetries())
+	requireResultContaining(t, results, types.Info, "rate limited")
+	if got := hits.Load(); got != 1 {
+		t.Errorf("expected 1 request, got %d", got)
+	}
+}
+
+func TestCheckSkillLinks_PerHostConcurrency(t *testing.T) {
+	useTestClient(t)
+
+	var (
+		mu       sync.Mutex
+		inFlight int
+		peak     int
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		peak = max(peak, inFlight)
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var body strings.Builder
+	for i := range 8 {
+		fmt.Fprintf(&body, "[p%d](%s/page/%d)\n", i, server.URL, i)
+	}
+
+	opts := Options{MaxPerHost: 1}
+	results := CheckSkillLinks(t.Context(), t.TempDir(), body.String(), 0, opts)
+	if len(results) != 8 {
+		t.Fatalf("expected 8 results, got %d", len(results))
+	}
+	if peak != 1 {
+		t.Errorf("expected at most 1 concurrent request per host, got %d", peak)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"5", 5 * time.Second},
+		{"-1", 0},
+		{"soon", 0},
+		{now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0},
+	}
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.value, now); got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestOptionsDefaults(t *testing.T) {
+	var o Options
+	if o.maxConcurrency() != DefaultMaxConcurrency || o.maxPerHost() != DefaultMaxPerHost ||
+		o.retries() != DefaultRetries || o.baseBackoff() != DefaultBaseBackoff {
+		t.Error("zero Options should use defaults")
+	}
+	if (Options{Retries: -1}).retries() != 0 {
+		t.Error("negative Retries should disable retries")
+	}
+}
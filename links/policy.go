@@ -0,0 +1,141 @@
+package links
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/agent-ecosystem/skill-validator/types"
+)
+
+// Policy holds rules that external links must satisfy. Rules are evaluated
+// on the URL alone, before any network request, so they also apply in
+// offline mode. The zero value enforces no rules.
+type Policy struct {
+	// RequireHTTPS rejects http:// links.
+	RequireHTTPS bool
+	// DenyInternalHosts rejects links to hosts agents generally cannot
+	// reach: localhost, private IP addresses, single-label hostnames, and
+	// names under internal-only suffixes such as .local or .internal.
+	DenyInternalHosts bool
+	// DenyShorteners rejects links through URL shortening services, which
+	// hide the real destination from reviewers.
+	DenyShorteners bool
+	// AllowDomains, when non-empty, rejects links to any domain not listed.
+	// A domain also matches its subdomains.
+	AllowDomains []string
+	// DenyDomains rejects links to the listed domains and their subdomains.
+	DenyDomains []string
+	// SkipDomains lists known-flaky domains whose links pass policy checks
+	// but are never fetched.
+	SkipDomains []string
+}
+
+// internalSuffixes are DNS suffixes reserved for, or conventionally used by,
+// private networks.
+var internalSuffixes = []string{
+	"localhost",
+	"local",
+	"internal",
+	"intranet",
+	"corp",
+	"lan",
+	"home.arpa",
+}
+
+// shortenerDomains are common URL shortening services.
+var shortenerDomains = []string{
+	"bit.ly",
+	"bit.do",
+	"buff.ly",
+	"cutt.ly",
+	"goo.gl",
+	"is.gd",
+	"lnkd.in",
+	"ow.ly",
+	"rb.gy",
+	"rebrand.ly",
+	"shorturl.at",
+	"t.co",
+	"t.ly",
+	"tiny.cc",
+	"tinyurl.com",
+}
+
+// verdict is the outcome of applying syntax and policy rules to a URL.
+type verdict int
+
+const (
+	verdictCheck verdict = iota // fetch the URL
+	verdictSkip                 // passes policy but must not be fetched
+	verdictFail                 // invalid or violates policy
+)
+
+// evaluate applies syntax and policy rules to rawURL. For verdictSkip and
+// verdictFail it also returns the result to report.
+func (p Policy) evaluate(rctx types.ResultContext, rawURL string) (verdict, types.Result) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return verdictFail, rctx.Errorf("%s (invalid URL: %v)", rawURL, err)
+	}
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "" {
+		return verdictFail, rctx.Errorf("%s (invalid URL: missing host)", rawURL)
+	}
+
+	if reason := p.violation(u.Scheme, host); reason != "" {
+		return verdictFail, rctx.Errorf("%s (policy: %s)", rawURL, reason)
+	}
+	if matchesDomain(host, p.SkipDomains) {
+		return verdictSkip, rctx.Infof("%s (skipped: %s is on the skip list)", rawURL, host)
+	}
+	return verdictCheck, types.Result{}
+}
+
+// violation returns why a URL with the given scheme and host breaks the
+// policy, or "" if it does not.
+func (p Policy) violation(scheme, host string) string {
+	if p.DenyInternalHosts && isInternalHost(host) {
+		return fmt.Sprintf("internal host %s is not reachable by agents", host)
+	}
+	if p.RequireHTTPS && scheme != "https" {
+		return "non-HTTPS link"
+	}
+	if p.DenyShorteners && matchesDomain(host, shortenerDomains) {
+		return fmt.Sprintf("URL shortener %s hides the link destination", host)
+	}
+	if matchesDomain(host, p.DenyDomains) {
+		return fmt.Sprintf("domain %s is denied", host)
+	}
+	if len(p.AllowDomains) > 0 && !matchesDomain(host, p.AllowDomains) {
+		return fmt.Sprintf("domain %s is not in the allowlist", host)
+	}
+	return ""
+}
+
+// isInternalHost reports whether host names a machine on a private network.
+func isInternalHost(host string) bool {
+	if ip := net.ParseIP(host); ip != nil {
+		return ip.IsUnspecified() || isPrivateIP(ip)
+	}
+	if !strings.Contains(host, ".") {
+		return true
+	}
+	return matchesDomain(host, internalSuffixes)
+}
+
+// matchesDomain reports whether host equals one of domains or is a
+// subdomain of one.
+func matchesDomain(host string, domains []string) bool {
+	for _, d := range domains {
+		d = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(d)), ".")
+		if d == "" {
+			continue
+		}
+		if host == d || strings.HasSuffix(host, "."+d) {
+			return true
+		}
+	}
+	return false
+}
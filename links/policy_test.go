@@ -0,0 +1,115 @@
+package links
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/agent-ecosystem/skill-validator/types"
+)
+
+func TestPolicyEvaluate(t *testing.T) {
+	rctx := types.ResultContext{Category: "Links"}
+	tests := []struct {
+		name    string
+		policy  Policy
+		url     string
+		want    verdict
+		message string
+	}{
+		{"zero policy", Policy{}, "http://localhost:8080/x", verdictCheck, ""},
+		{"missing host", Policy{}, "https:///path", verdictFail, "missing host"},
+		{"localhost", Policy{DenyInternalHosts: true}, "http://localhost:8080/x", verdictFail, "internal host localhost"},
+		{"private ip", Policy{DenyInternalHosts: true}, "https://10.1.2.3/", verdictFail, "internal host 10.1.2.3"},
+		{"ipv6 loopback", Policy{DenyInternalHosts: true}, "http://[::1]/", verdictFail, "internal host ::1"},
+		{"single label", Policy{DenyInternalHosts: true}, "https://wiki/page", verdictFail, "internal host wiki"},
+		{"internal suffix", Policy{DenyInternalHosts: true}, "https://docs.corp/x", verdictFail, "internal host docs.corp"},
+		{"public host", Policy{DenyInternalHosts: true}, "https://example.com/", verdictCheck, ""},
+		{"http", Policy{RequireHTTPS: true}, "http://example.com/", verdictFail, "non-HTTPS link"},
+		{"https", Policy{RequireHTTPS: true}, "https://example.com/", verdictCheck, ""},
+		{"shortener", Policy{DenyShorteners: true}, "https://bit.ly/abc", verdictFail, "URL shortener bit.ly"},
+		{"denied subdomain", Policy{DenyDomains: []string{"example.com"}}, "https://docs.example.com/", verdictFail, "domain docs.example.com is denied"},
+		{"not denied lookalike", Policy{DenyDomains: []string{"example.com"}}, "https://notexample.com/", verdictCheck, ""},
+		{"allowed", Policy{AllowDomains: []string{"github.com"}}, "https://GitHub.com/org/repo", verdictCheck, ""},
+		{"outside allowlist", Policy{AllowDomains: []string{"github.com"}}, "https://example.com/", verdictFail, "not in the allowlist"},
+		{"skipped", Policy{SkipDomains: []string{"flaky.example"}}, "https://flaky.example/x", verdictSkip, "skipped: flaky.example"},
+		{"deny beats skip", Policy{DenyDomains: []string{"flaky.example"}, SkipDomains: []string{"flaky.example"}}, "https://flaky.example/x", verdictFail, "denied"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, r := tt.policy.evaluate(rctx, tt.url)
+			if got != tt.want {
+				t.Fatalf("verdict = %v, want %v (%s)", got, tt.want, r.Message)
+			}
+			if tt.message != "" && !strings.Contains(r.Message, tt.message) {
+				t.Errorf("message %q does not contain %q", r.Message, tt.message)
+			}
+		})
+	}
+}
+
+func TestCheckSkillLinks_PolicyRunsBeforeNetwork(t *testing.T) {
+	useTestClient(t)
+
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	body := "[local](" + server.URL + "/a)\n[short](https://bit.ly/xyz)\n"
+	opts := Options{Policy: Policy{DenyInternalHosts: true, DenyShorteners: true}}
+	results := CheckSkillLinks(t.Context(), t.TempDir(), body, 0, opts)
+
+	requireResultContaining(t, results, types.Error, "policy: internal host 127.0.0.1")
+	requireResultContaining(t, results, types.Error, "policy: URL shortener bit.ly")
+	if got := hits.Load(); got != 0 {
+		t.Errorf("expected no requests for links rejected by policy, got %d", got)
+	}
+}
+
+func TestCheckSkillLinks_SkipDomains(t *testing.T) {
+	useTestClient(t)
+
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	opts := Options{Policy: Policy{SkipDomains: []string{"127.0.0.1"}}}
+	results := CheckSkillLinks(t.Context(), t.TempDir(), "[x]("+server.URL+")", 0, opts)
+	requireResultContaining(t, results, types.Info, "skipped")
+	if got := hits.Load(); got != 0 {
+		t.Errorf("expected skipped host not to be fetched, got %d requests", got)
+	}
+}
+
+func TestCheckSkillLinks_Offline(t *testing.T) {
+	useTestClient(t)
+
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	body := "[x](" + server.URL + "/missing)\n[y](http://example.com/)\n"
+	opts := Options{Offline: true, Policy: Policy{RequireHTTPS: true}}
+	results := CheckSkillLinks(t.Context(), t.TempDir(), body, 0, opts)
+
+	requireResultContaining(t, results, types.Error, "policy: non-HTTPS link")
+	if got := hits.Load(); got != 0 {
+		t.Errorf("expected no requests in offline mode, got %d", got)
+	}
+
+	opts = Options{Offline: true}
+	results = CheckSkillLinks(t.Context(), t.TempDir(), body, 0, opts)
+	requireResultContaining(t, results, types.Pass, "not fetched in offline mode")
+	requireNoLevel(t, results, types.Error)
+}
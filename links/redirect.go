@@ -0,0 +1,138 @@
+package links
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/agent-ecosystem/skill-validator/types"
+)
+
+// permanent reports whether hop is a permanent redirect (301 or 308).
+func permanent(hop types.RedirectHop) bool {
+	return hop.Status == http.StatusMovedPermanently || hop.Status == http.StatusPermanentRedirect
+}
+
+// redirectChain returns the redirects the client followed to produce resp,
+// in the order they happened.
+func redirectChain(resp *http.Response) []types.RedirectHop {
+	var chain []types.RedirectHop
+	for req := resp.Request; req != nil && req.Response != nil; req = req.Response.Request {
+		chain = append(chain, types.RedirectHop{Status: req.Response.StatusCode, URL: req.URL.String()})
+	}
+	slices.Reverse(chain)
+	return chain
+}
+
+// loginSegments are path segments that mark a sign-in page.
+var loginSegments = map[string]bool{
+	"login":     true,
+	"signin":    true,
+	"sign-in":   true,
+	"sign_in":   true,
+	"sso":       true,
+	"oauth":     true,
+	"oauth2":    true,
+	"authorize": true,
+	"auth":      true,
+}
+
+// loginHostPrefixes are subdomains that typically serve sign-in pages.
+var loginHostPrefixes = []string{"login.", "signin.", "accounts.", "auth.", "sso."}
+
+// isLoginPage reports whether rawURL looks like a sign-in page.
+func isLoginPage(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, p := range loginHostPrefixes {
+		if strings.HasPrefix(host, p) {
+			return true
+		}
+	}
+	for _, seg := range strings.Split(strings.ToLower(u.Path), "/") {
+		if loginSegments[seg] {
+			return true
+		}
+	}
+	return false
+}
+
+// sameSite reports whether a and b share a host, ignoring a leading "www.".
+func sameSite(a, b string) bool {
+	ua, errA := url.Parse(a)
+	ub, errB := url.Parse(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	strip := func(h string) string { return strings.TrimPrefix(strings.ToLower(h), "www.") }
+	return strip(ua.Hostname()) == strip(ub.Hostname())
+}
+
+// permanentTarget returns where the leading run of permanent redirects in
+// chain points, carrying over the fragment of original when the target has
+// none. It returns "" if the first hop is not permanent. Later temporary
+// hops are not followed, since their target may change.
+func permanentTarget(original string, chain []types.RedirectHop) string {
+	target := ""
+	for _, hop := range chain {
+		if !permanent(hop) {
+			break
+		}
+		target = hop.URL
+	}
+	if target == "" || target == original {
+		return ""
+	}
+	if _, frag, ok := strings.Cut(original, "#"); ok && !strings.Contains(target, "#") {
+		target += "#" + frag
+	}
+	return target
+}
+
+// hopList formats chain as "301 → url, 302 → url".
+func hopList(chain []types.RedirectHop) string {
+	hops := make([]string, len(chain))
+	for i, hop := range chain {
+		hops[i] = fmt.Sprintf("%d → %s", hop.Status, hop.URL)
+	}
+	return strings.Join(hops, ", ")
+}
+
+// classifyRedirects reports a link that reached a 2xx response after one or
+// more redirects. Permanent redirects, redirects to another host, and
+// redirects to a sign-in page are warnings; permanent redirects carry a Fix
+// replacing the link with its new location.
+func classifyRedirects(rctx types.ResultContext, rawURL string, status int, chain []types.RedirectHop) types.Result {
+	final := chain[len(chain)-1].URL
+	msg := fmt.Sprintf("%s (HTTP %d after redirects: %s)", rawURL, status, hopList(chain))
+
+	var notes []string
+	login := isLoginPage(final) && !isLoginPage(rawURL)
+	if login {
+		notes = append(notes, "redirects to a login page")
+	}
+	if !sameSite(rawURL, final) {
+		notes = append(notes, "redirects to a different host")
+	}
+	replacement := ""
+	if !login {
+		replacement = permanentTarget(rawURL, chain)
+	}
+	if replacement != "" {
+		notes = append(notes, "permanent redirect, replace with "+replacement)
+	}
+	if len(notes) == 0 {
+		return rctx.Pass(msg)
+	}
+
+	r := rctx.Warn(msg + ": " + strings.Join(notes, "; "))
+	if replacement != "" {
+		r.Fix = &types.Fix{Old: rawURL, New: replacement}
+	}
+	return r
+}
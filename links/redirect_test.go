@@ -0,0 +1,160 @@
+package links
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/agent-ecosystem/skill-validator/types"
+)
+
+func TestClassifyRedirects(t *testing.T) {
+	client := testHTTPClient()
+	rctx := types.ResultContext{Category: "Links", File: "SKILL.md"}
+
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer other.Close()
+	// Reach the second server through "localhost" so its host differs from
+	// the first server's 127.0.0.1.
+	otherURL := "http://localhost:" + other.URL[len("http://127.0.0.1:"):]
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/new", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/moved-twice", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/moved", http.StatusPermanentRedirect)
+	})
+	mux.HandleFunc("/moved-then-temp", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/temp", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/temp", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/new", http.StatusFound)
+	})
+	mux.HandleFunc("/private", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/login?next=/private", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/offsite", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, otherURL+"/landing", http.StatusFound)
+	})
+	mux.HandleFunc("/gone", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/missing", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/new", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	t.Run("permanent redirect suggests replacement", func(t *testing.T) {
+		r := checkHTTPLink(rctx, client, server.URL+"/moved#usage")
+		if r.Level != types.Warning {
+			t.Fatalf("expected Warning, got %s: %s", r.Level, r.Message)
+		}
+		requireContains(t, r.Message, "301 → "+server.URL+"/new")
+		want := server.URL + "/new#usage"
+		if r.Fix == nil || r.Fix.Old != server.URL+"/moved#usage" || r.Fix.New != want {
+			t.Errorf("expected fix to %s, got %+v", want, r.Fix)
+		}
+	})
+
+	t.Run("chain of permanent redirects", func(t *testing.T) {
+		r := checkHTTPLink(rctx, client, server.URL+"/moved-twice")
+		if r.Fix == nil || r.Fix.New != server.URL+"/new" {
+			t.Errorf("expected fix to final URL, got %+v (%s)", r.Fix, r.Message)
+		}
+		want := &types.Redirects{
+			Hops: []types.RedirectHop{
+				{Status: http.StatusPermanentRedirect, URL: server.URL + "/moved"},
+				{Status: http.StatusMovedPermanently, URL: server.URL + "/new"},
+			},
+			FinalURL: server.URL + "/new",
+		}
+		if !reflect.DeepEqual(r.Redirects, want) {
+			t.Errorf("redirects = %+v, want %+v", r.Redirects, want)
+		}
+	})
+
+	t.Run("permanent then temporary stops at temporary hop", func(t *testing.T) {
+		r := checkHTTPLink(rctx, client, server.URL+"/moved-then-temp")
+		if r.Fix == nil || r.Fix.New != server.URL+"/temp" {
+			t.Errorf("expected fix to %s/temp, got %+v (%s)", server.URL, r.Fix, r.Message)
+		}
+	})
+
+	t.Run("temporary redirect passes", func(t *testing.T) {
+		r := checkHTTPLink(rctx, client, server.URL+"/temp")
+		if r.Level != types.Pass || r.Fix != nil {
+			t.Errorf("expected Pass without fix, got %s: %s", r.Level, r.Message)
+		}
+		if r.Redirects == nil || r.Redirects.FinalURL != server.URL+"/new" {
+			t.Errorf("expected the redirect chain on a passing link, got %+v", r.Redirects)
+		}
+	})
+
+	t.Run("broken link keeps its redirect chain", func(t *testing.T) {
+		r := checkHTTPLink(rctx, client, server.URL+"/gone")
+		if r.Level != types.Error {
+			t.Fatalf("expected Error, got %s: %s", r.Level, r.Message)
+		}
+		requireContains(t, r.Message, "(HTTP 404) (after redirects: 301 → "+server.URL+"/missing)")
+		want := &types.Redirects{
+			Hops:     []types.RedirectHop{{Status: http.StatusMovedPermanently, URL: server.URL + "/missing"}},
+			FinalURL: server.URL + "/missing",
+		}
+		if !reflect.DeepEqual(r.Redirects, want) {
+			t.Errorf("redirects = %+v, want %+v", r.Redirects, want)
+		}
+		if r.Fix != nil {
+			t.Errorf("broken links should not suggest a replacement, got %+v", r.Fix)
+		}
+	})
+
+	t.Run("login page", func(t *testing.T) {
+		r := checkHTTPLink(rctx, client, server.URL+"/private")
+		if r.Level != types.Warning {
+			t.Fatalf("expected Warning, got %s: %s", r.Level, r.Message)
+		}
+		requireContains(t, r.Message, "redirects to a login page")
+		if r.Fix != nil {
+			t.Errorf("login redirects should not suggest a replacement, got %+v", r.Fix)
+		}
+	})
+
+	t.Run("different host", func(t *testing.T) {
+		r := checkHTTPLink(rctx, client, server.URL+"/offsite")
+		if r.Level != types.Warning {
+			t.Fatalf("expected Warning, got %s: %s", r.Level, r.Message)
+		}
+		requireContains(t, r.Message, "redirects to a different host")
+	})
+}
+
+func TestCheckSkillLinks_CachedRedirect(t *testing.T) {
+	cache, err := OpenCache(filepath.Join(t.TempDir(), "links.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	url := "https://old.example.com/docs"
+	cache.Put(CacheEntry{
+		URL:       url,
+		Status:    200,
+		FinalURL:  "https://new.example.com/docs",
+		Redirects: []types.RedirectHop{{Status: 301, URL: "https://new.example.com/docs"}},
+		CheckedAt: time.Now(),
+	})
+
+	results := CheckSkillLinks(t.Context(), t.TempDir(), "See "+url, 0, Options{Cache: cache})
+	requireResultContaining(t, results, types.Warning, "replace with https://new.example.com/docs")
+	if len(results) != 1 || results[0].Fix == nil {
+		t.Fatalf("expected one result with a fix, got %+v", results)
+	}
+}
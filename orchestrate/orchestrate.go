@@ -1,6 +1,7 @@
 // Package orchestrate provides the core validation and analysis orchestration
 // for skill directories. It coordinates calls to structure, content,
-// contamination, and link checking packages, returning unified reports.
+// contamination, link checking, and security packages, returning unified
+// reports.
 //
 // This package is intended for library consumers who want to run skill
 // validation without the CLI layer.
@@ -12,6 +13,7 @@ import (
 	"github.com/agent-ecosystem/skill-validator/contamination"
 	"github.com/agent-ecosystem/skill-validator/content"
 	"github.com/agent-ecosystem/skill-validator/links"
+	"github.com/agent-ecosystem/skill-validator/security"
 	"github.com/agent-ecosystem/skill-validator/skill"
 	"github.com/agent-ecosystem/skill-validator/skillcheck"
 	"github.com/agent-ecosystem/skill-validator/structure"
@@ -31,6 +33,9 @@ const (
 	GroupContent CheckGroup = "content"
 	// GroupContamination enables cross-language contamination analysis.
 	GroupContamination CheckGroup = "contamination"
+	// GroupSecurity enables prompt-injection, deceptive Unicode, secret, and
+	// script risk scanning.
+	GroupSecurity CheckGroup = "security"
 )
 
 // AllGroups returns a map with all check groups enabled.
@@ -40,6 +45,7 @@ func AllGroups() map[CheckGroup]bool {
 		GroupLinks:         true,
 		GroupContent:       true,
 		GroupContamination: true,
+		GroupSecurity:      true,
 	}
 }
 
@@ -47,6 +53,7 @@ func AllGroups() map[CheckGroup]bool {
 type Options struct {
 	Enabled    map[CheckGroup]bool
 	StructOpts structure.Options
+	LinkOpts   links.Options
 }
 
 // RunAllChecks runs all enabled check groups against a single skill directory
@@ -61,12 +68,17 @@ func RunAllChecks(ctx context.Context, dir string, opts Options) *types.Report {
 		rpt.Results = append(rpt.Results, vr.Results...)
 		rpt.TokenCounts = vr.TokenCounts
 		rpt.OtherTokenCounts = vr.OtherTokenCounts
+		rpt.ScriptTokenCounts = vr.ScriptTokenCounts
+		rpt.BinaryFiles = vr.BinaryFiles
+		rpt.TokenBudget = vr.TokenBudget
+		rpt.Compatibility = vr.Compatibility
 	}
 
 	// Load skill for links/content/contamination checks
 	needsSkill := opts.Enabled[GroupLinks] || opts.Enabled[GroupContent] || opts.Enabled[GroupContamination]
 	var rawContent, body string
-	var skillLoaded bool
+	var bodyLineOffset int
+	var loaded *skill.Skill
 	if needsSkill {
 		s, err := skill.Load(dir)
 		if err != nil {
@@ -80,12 +92,13 @@ func RunAllChecks(ctx context.Context, dir string, opts Options) *types.Report {
 		} else {
 			rawContent = s.RawContent
 			body = s.Body
-			skillLoaded = true
+			bodyLineOffset = s.BodyLineOffset()
+			loaded = s
 		}
 
 		// Link checks require a fully parsed skill
-		if skillLoaded && opts.Enabled[GroupLinks] {
-			rpt.Results = append(rpt.Results, links.CheckLinks(ctx, dir, body)...)
+		if loaded != nil && opts.Enabled[GroupLinks] {
+			rpt.Results = append(rpt.Results, links.CheckSkillLinks(ctx, dir, body, bodyLineOffset, opts.LinkOpts)...)
 		}
 
 		// Content analysis works on raw content (no frontmatter parsing needed)
@@ -127,6 +140,15 @@ func RunAllChecks(ctx context.Context, dir string, opts Options) *types.Report {
 		}
 	}
 
+	// Security scanning reads files directly, so it runs even when
+	// SKILL.md cannot be parsed
+	if opts.Enabled[GroupSecurity] {
+		if !needsSkill {
+			loaded, _ = skill.Load(dir)
+		}
+		rpt.Results = append(rpt.Results, security.Check(dir, loaded)...)
+	}
+
 	rpt.Tally()
 	return rpt
 }
@@ -178,8 +200,90 @@ func RunContaminationAnalysis(dir string) *types.Report {
 	return rpt
 }
 
-// RunLinkChecks validates external HTTP/HTTPS links in a single skill directory.
+// RunLoadingAnalysis simulates progressive disclosure for a single skill
+// directory: what an agent loads at startup, on activation, and on demand
+// through the skill's references (see structure.CheckLoading).
+func RunLoadingAnalysis(dir string, opts structure.Options) *types.Report {
+	rpt := &types.Report{SkillDir: dir}
+
+	s, err := skill.Load(dir)
+	if err != nil {
+		rpt.Results = append(rpt.Results,
+			types.ResultContext{Category: "Loading"}.Error(err.Error()))
+		rpt.Errors = 1
+		return rpt
+	}
+
+	rpt.Results, rpt.Loading = structure.CheckLoading(dir, s, opts)
+	rpt.TokenBudget = opts.TokenBudget()
+	rpt.Tally()
+	return rpt
+}
+
+// RunLoadingAnalysisMulti runs RunLoadingAnalysis on each directory. Agents
+// load the metadata of every installed skill at startup, so each report also
+// records the metadata cost of the whole collection.
+func RunLoadingAnalysisMulti(dirs []string, opts structure.Options) *types.MultiReport {
+	mr := &types.MultiReport{}
+	collection := 0
+	for _, dir := range dirs {
+		r := RunLoadingAnalysis(dir, opts)
+		if r.Loading != nil {
+			collection += r.Loading.MetadataTokens
+		}
+		mr.Skills = append(mr.Skills, r)
+		mr.Errors += r.Errors
+		mr.Warnings += r.Warnings
+	}
+	for _, r := range mr.Skills {
+		if r.Loading != nil {
+			r.Loading.CollectionMetadataTokens = collection
+			r.Loading.CollectionSkills = len(dirs)
+		}
+	}
+	return mr
+}
+
+// RunSectionAnalysis breaks the SKILL.md body and markdown references of a
+// single skill down by heading (see structure.CheckSections).
+func RunSectionAnalysis(dir string, opts structure.Options) *types.Report {
+	rpt := &types.Report{SkillDir: dir}
+
+	s, err := skill.Load(dir)
+	if err != nil {
+		rpt.Results = append(rpt.Results,
+			types.ResultContext{Category: "Sections"}.Error(err.Error()))
+		rpt.Errors = 1
+		return rpt
+	}
+
+	rpt.Results, rpt.Sections = structure.CheckSections(dir, s, opts)
+	rpt.TokenBudget = opts.TokenBudget()
+	rpt.Tally()
+	return rpt
+}
+
+// RunSecurityChecks scans the text files of a single skill directory for
+// prompt injection, hidden instructions, secrets, and deceptive Unicode, and
+// rates the risk of its scripts.
+func RunSecurityChecks(dir string) *types.Report {
+	rpt := &types.Report{SkillDir: dir}
+	// Files are scanned even when SKILL.md cannot be parsed
+	s, _ := skill.Load(dir)
+	rpt.Results = security.Check(dir, s)
+	rpt.Tally()
+	return rpt
+}
+
+// RunLinkChecks validates external HTTP/HTTPS links in SKILL.md and in the
+// markdown files under references/ and assets/ of a single skill directory.
 func RunLinkChecks(ctx context.Context, dir string) *types.Report {
+	return RunLinkChecksWithOptions(ctx, dir, links.Options{})
+}
+
+// RunLinkChecksWithOptions is like RunLinkChecks but accepts link checking
+// options such as a persistent cache.
+func RunLinkChecksWithOptions(ctx context.Context, dir string, opts links.Options) *types.Report {
 	rpt := &types.Report{SkillDir: dir}
 
 	s, err := skill.Load(dir)
@@ -190,7 +294,7 @@ func RunLinkChecks(ctx context.Context, dir string) *types.Report {
 		return rpt
 	}
 
-	rpt.Results = append(rpt.Results, links.CheckLinks(ctx, dir, s.Body)...)
+	rpt.Results = append(rpt.Results, links.CheckSkillLinks(ctx, dir, s.Body, s.BodyLineOffset(), opts)...)
 
 	// If no results at all, add a pass result
 	if len(rpt.Results) == 0 {
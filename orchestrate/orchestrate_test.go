@@ -381,6 +381,62 @@ func TestRunAllChecks_MultiSkill(t *testing.T) {
 	}
 }
 
+// --- RunLoadingAnalysis tests ---
+
+func TestRunLoadingAnalysis_ValidSkill(t *testing.T) {
+	rpt := RunLoadingAnalysis(fixtureDir(t, "valid-skill"), structure.Options{ContextWindow: 200_000})
+	if rpt.Errors != 0 || rpt.Loading == nil {
+		t.Fatalf("expected a loading report without errors, got %+v", rpt.Results)
+	}
+	if len(rpt.Loading.Paths) != 2 {
+		t.Errorf("expected 2 paths, got %+v", rpt.Loading.Paths)
+	}
+	if rpt.TokenBudget == nil || rpt.TokenBudget.ContextWindow != 200_000 {
+		t.Errorf("expected the token budget to be recorded, got %+v", rpt.TokenBudget)
+	}
+}
+
+func TestRunLoadingAnalysisMulti(t *testing.T) {
+	_, dirs := skillcheck.DetectSkills(fixtureDir(t, "multi-skill"))
+	mr := RunLoadingAnalysisMulti(dirs, structure.Options{})
+	sum := 0
+	for _, r := range mr.Skills {
+		sum += r.Loading.MetadataTokens
+	}
+	for _, r := range mr.Skills {
+		if r.Loading.CollectionMetadataTokens != sum || r.Loading.CollectionSkills != len(dirs) {
+			t.Errorf("%s: collection = %d tokens over %d skills, want %d over %d",
+				r.SkillDir, r.Loading.CollectionMetadataTokens, r.Loading.CollectionSkills, sum, len(dirs))
+		}
+	}
+}
+
+// --- RunSectionAnalysis tests ---
+
+func TestRunSectionAnalysis_ValidSkill(t *testing.T) {
+	rpt := RunSectionAnalysis(fixtureDir(t, "valid-skill"), structure.Options{})
+	if rpt.Errors != 0 || rpt.Sections == nil {
+		t.Fatalf("expected a sections report without errors, got %+v", rpt.Results)
+	}
+	if rpt.Sections.Files[0].File != "SKILL.md" || len(rpt.Sections.Files[0].Sections) == 0 {
+		t.Errorf("expected SKILL.md sections first, got %+v", rpt.Sections.Files)
+	}
+}
+
+func TestRunSectionAnalysis_MissingSkill(t *testing.T) {
+	rpt := RunSectionAnalysis(t.TempDir(), structure.Options{})
+	if rpt.Errors != 1 || rpt.Sections != nil {
+		t.Errorf("expected a load error, got %+v", rpt.Results)
+	}
+}
+
+func TestRunLoadingAnalysis_BrokenDir(t *testing.T) {
+	rpt := RunLoadingAnalysis(t.TempDir(), structure.Options{})
+	if rpt.Errors != 1 || rpt.Loading != nil {
+		t.Errorf("expected one error and no loading report, got %+v", rpt)
+	}
+}
+
 // --- RunContaminationAnalysis tests ---
 
 func TestRunContaminationAnalysis_ValidSkill(t *testing.T) {
@@ -805,3 +861,28 @@ func TestOutputJSON_PerFile_ValidSkill(t *testing.T) {
 		t.Error("expected contamination_analysis in per-file report")
 	}
 }
+
+func TestRunAllChecks_Security(t *testing.T) {
+	dir := fixtureDir(t, "injection-skill")
+	opts := Options{Enabled: map[CheckGroup]bool{GroupSecurity: true}}
+	r := RunAllChecks(t.Context(), dir, opts)
+
+	if r.Errors == 0 || r.Warnings == 0 {
+		t.Fatalf("expected security errors and warnings, got %d errors, %d warnings", r.Errors, r.Warnings)
+	}
+	for _, res := range r.Results {
+		if res.Category != "Security" {
+			t.Errorf("unexpected %s result with only security enabled: %s", res.Category, res.Message)
+		}
+		if res.Level != types.Pass && res.Line == 0 {
+			t.Errorf("expected line number on security finding: %s", res.Message)
+		}
+	}
+}
+
+func TestRunSecurityChecks_ValidSkill(t *testing.T) {
+	r := RunSecurityChecks(fixtureDir(t, "valid-skill"))
+	if r.Errors != 0 || r.Warnings != 0 {
+		t.Errorf("expected clean security report, got %d errors, %d warnings", r.Errors, r.Warnings)
+	}
+}
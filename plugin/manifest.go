@@ -0,0 +1,293 @@
+package plugin
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+
+	"github.com/agent-ecosystem/skill-validator/types"
+)
+
+// pluginSchemaJSON describes .claude-plugin/plugin.json.
+const pluginSchemaJSON = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "required": ["name"],
+  "properties": {
+    "$schema": {"type": "string"},
+    "name": {"$ref": "#/$defs/name"},
+    "version": {"type": "string"},
+    "description": {"type": "string"},
+    "author": {"$ref": "#/$defs/person"},
+    "homepage": {"type": "string"},
+    "repository": {"type": "string"},
+    "license": {"type": "string"},
+    "keywords": {"type": "array", "items": {"type": "string"}},
+    "commands": {"$ref": "#/$defs/paths"},
+    "agents": {"$ref": "#/$defs/paths"},
+    "skills": {"$ref": "#/$defs/paths"},
+    "outputStyles": {"$ref": "#/$defs/paths"},
+    "hooks": {"type": ["string", "object"]},
+    "mcpServers": {"type": ["string", "object"]},
+    "lspServers": {"type": ["string", "object"]}
+  },
+  "$defs": {
+    "name": {"type": "string", "pattern": "^[a-z0-9]+(-[a-z0-9]+)*$"},
+    "person": {
+      "type": "object",
+      "required": ["name"],
+      "properties": {
+        "name": {"type": "string"},
+        "email": {"type": "string"},
+        "url": {"type": "string"}
+      }
+    },
+    "paths": {"type": ["string", "array"], "items": {"type": "string"}}
+  }
+}`
+
+// marketplaceSchemaJSON describes .claude-plugin/marketplace.json. Plugin
+// entries accept the plugin manifest's fields as well as their own.
+const marketplaceSchemaJSON = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "required": ["name", "owner", "plugins"],
+  "properties": {
+    "$schema": {"type": "string"},
+    "name": {"$ref": "plugin.schema.json#/$defs/name"},
+    "owner": {"$ref": "plugin.schema.json#/$defs/person"},
+    "metadata": {
+      "type": "object",
+      "properties": {
+        "description": {"type": "string"},
+        "version": {"type": "string"},
+        "pluginRoot": {"type": "string"}
+      }
+    },
+    "plugins": {
+      "type": "array",
+      "items": {
+        "allOf": [{"$ref": "plugin.schema.json"}],
+        "required": ["name", "source"],
+        "properties": {
+          "source": {
+            "type": ["string", "object"],
+            "required": ["source"],
+            "properties": {
+              "source": {"type": "string"},
+              "repo": {"type": "string"},
+              "url": {"type": "string"}
+            }
+          },
+          "category": {"type": "string"},
+          "tags": {"type": "array", "items": {"type": "string"}},
+          "strict": {"type": "boolean"}
+        }
+      }
+    }
+  }
+}`
+
+var pluginSchema, marketplaceSchema = compileSchemas()
+
+func compileSchemas() (*jsonschema.Schema, *jsonschema.Schema) {
+	c := jsonschema.NewCompiler()
+	if err := c.AddResource("plugin.schema.json", strings.NewReader(pluginSchemaJSON)); err != nil {
+		panic(err)
+	}
+	if err := c.AddResource("marketplace.schema.json", strings.NewReader(marketplaceSchemaJSON)); err != nil {
+		panic(err)
+	}
+	return c.MustCompile("plugin.schema.json"), c.MustCompile("marketplace.schema.json")
+}
+
+// knownPluginFields are the plugin manifest fields; others are reported as
+// unrecognized.
+var knownPluginFields = []string{
+	"$schema", "name", "version", "description", "author", "homepage", "repository",
+	"license", "keywords", "commands", "agents", "skills", "outputStyles", "hooks",
+	"mcpServers", "lspServers",
+}
+
+// document is a parsed JSON manifest with the line of each of its values.
+type document struct {
+	ctx   types.ResultContext
+	data  map[string]any
+	lines map[string]int // JSON pointer → 1-based line
+}
+
+// loadDocument reads and parses the manifest at root/file and validates it
+// against schema. The document is nil if the file cannot be parsed; the
+// results report why, and any schema violations at their lines.
+func loadDocument(ctx types.ResultContext, root string, schema *jsonschema.Schema) (*document, []types.Result) {
+	raw, err := os.ReadFile(filepath.Join(root, ctx.File))
+	if err != nil {
+		return nil, []types.Result{ctx.Errorf("reading manifest: %v", err)}
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var instance any
+	if err := dec.Decode(&instance); err != nil {
+		var se *json.SyntaxError
+		if errors.As(err, &se) {
+			return nil, []types.Result{ctx.ErrorAtLinef(ctx.File, lineAt(raw, se.Offset), "parsing manifest JSON: %v", err)}
+		}
+		return nil, []types.Result{ctx.Errorf("parsing manifest JSON: %v", err)}
+	}
+
+	doc := &document{ctx: ctx, lines: pointerLines(raw)}
+	doc.data, _ = instance.(map[string]any)
+
+	var results []types.Result
+	if err := schema.Validate(instance); err != nil {
+		var ve *jsonschema.ValidationError
+		if !errors.As(err, &ve) {
+			return nil, []types.Result{ctx.Errorf("manifest cannot be checked against its schema: %v", err)}
+		}
+		for _, leaf := range schemaLeaves(ve) {
+			results = append(results, doc.errorf(leaf.InstanceLocation, "%s", leaf.Message))
+		}
+		slices.SortStableFunc(results, func(a, b types.Result) int { return a.Line - b.Line })
+	}
+	if doc.data == nil {
+		return nil, results
+	}
+	return doc, results
+}
+
+// schemaLeaves returns the innermost errors of a validation error tree, which
+// name the keyword that actually failed.
+func schemaLeaves(ve *jsonschema.ValidationError) []*jsonschema.ValidationError {
+	if len(ve.Causes) == 0 {
+		return []*jsonschema.ValidationError{ve}
+	}
+	var leaves []*jsonschema.ValidationError
+	for _, c := range ve.Causes {
+		leaves = append(leaves, schemaLeaves(c)...)
+	}
+	return leaves
+}
+
+// line returns the line of the value at pointer, falling back to the
+// deepest ancestor that exists.
+func (d *document) line(pointer string) int {
+	for {
+		if line, ok := d.lines[pointer]; ok {
+			return line
+		}
+		i := strings.LastIndex(pointer, "/")
+		if i < 0 {
+			return 1
+		}
+		pointer = pointer[:i]
+	}
+}
+
+// errorf reports an error at the value at pointer, prefixed with its field
+// path.
+func (d *document) errorf(pointer, format string, args ...any) types.Result {
+	return d.ctx.ErrorAtLinef(d.ctx.File, d.line(pointer), "%s: %s", fieldPath(pointer), fmt.Sprintf(format, args...))
+}
+
+// warnf reports a warning at the value at pointer, prefixed with its field
+// path.
+func (d *document) warnf(pointer, format string, args ...any) types.Result {
+	return d.ctx.WarnAtLinef(d.ctx.File, d.line(pointer), "%s: %s", fieldPath(pointer), fmt.Sprintf(format, args...))
+}
+
+// pointerLines maps the JSON pointer of every value in data to the 1-based
+// line it starts on. Object members map to the line of their key.
+func pointerLines(data []byte) map[string]int {
+	lines := map[string]int{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	var walk func(pointer string) error
+	walk = func(pointer string) error {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if _, ok := lines[pointer]; !ok {
+			lines[pointer] = lineAt(data, dec.InputOffset())
+		}
+		delim, ok := tok.(json.Delim)
+		if !ok {
+			return nil
+		}
+		switch delim {
+		case '{':
+			for dec.More() {
+				key, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				child := pointer + "/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(key.(string))
+				lines[child] = lineAt(data, dec.InputOffset())
+				if err := walk(child); err != nil {
+					return err
+				}
+			}
+		case '[':
+			for i := 0; dec.More(); i++ {
+				if err := walk(pointer + "/" + strconv.Itoa(i)); err != nil {
+					return err
+				}
+			}
+		}
+		_, err = dec.Token() // closing delimiter
+		return err
+	}
+	_ = walk("")
+	return lines
+}
+
+// lineAt returns the 1-based line of byte offset in data.
+func lineAt(data []byte, offset int64) int {
+	offset = min(offset, int64(len(data)))
+	return 1 + bytes.Count(data[:offset], []byte("\n"))
+}
+
+// fieldPath formats a JSON pointer as a field path, e.g. "plugins[0].source"
+// for "/plugins/0/source".
+func fieldPath(pointer string) string {
+	if pointer == "" {
+		return "manifest"
+	}
+	var b strings.Builder
+	for i, tok := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		tok = strings.NewReplacer("~1", "/", "~0", "~").Replace(tok)
+		if _, err := strconv.Atoi(tok); err == nil {
+			fmt.Fprintf(&b, "[%s]", tok)
+			continue
+		}
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(tok)
+	}
+	return b.String()
+}
+
+// stringList returns a manifest field that may be a string or a list of
+// strings as a list, with the JSON pointer of each entry.
+func stringList(pointer string, v any) (values, pointers []string) {
+	switch v := v.(type) {
+	case string:
+		return []string{v}, []string{pointer}
+	case []any:
+		for i, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+				pointers = append(pointers, pointer+"/"+strconv.Itoa(i))
+			}
+		}
+	}
+	return values, pointers
+}
@@ -0,0 +1,291 @@
+// Package plugin validates Claude plugins and marketplaces and the skills
+// they ship. A plugin lists its commands, agents, and skills in
+// .claude-plugin/plugin.json; a marketplace lists plugins in
+// .claude-plugin/marketplace.json. Validate checks each manifest against its
+// schema, checks that every path it references exists, runs
+// structure.Validate on every skill it loads, and flags skills on disk that
+// no manifest loads.
+package plugin
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/agent-ecosystem/skill-validator/skillcheck"
+	"github.com/agent-ecosystem/skill-validator/structure"
+	"github.com/agent-ecosystem/skill-validator/types"
+	"github.com/agent-ecosystem/skill-validator/util"
+)
+
+const (
+	// PluginManifest is the path of a plugin's manifest, relative to the
+	// plugin directory.
+	PluginManifest = ".claude-plugin/plugin.json"
+	// MarketplaceManifest is the path of a marketplace's manifest, relative
+	// to the marketplace directory.
+	MarketplaceManifest = ".claude-plugin/marketplace.json"
+)
+
+// componentFields are the manifest fields that list paths to component
+// files or directories.
+var componentFields = []string{"commands", "agents", "skills", "outputStyles", "hooks", "mcpServers", "lspServers"}
+
+// validator accumulates the reports of one Validate call.
+type validator struct {
+	opts      structure.Options
+	manifests []*types.Report
+	skills    []*types.Report
+	loaded    map[string]bool // skill directories already validated
+	plugins   map[string]bool // plugin directories whose manifest was checked
+}
+
+// Validate validates the marketplace or plugin in dir. The report for the
+// top-level manifest comes first, followed by one report per plugin manifest
+// in a marketplace, then one report per skill. It returns an error if dir has
+// neither manifest.
+func Validate(dir string, opts structure.Options) (*types.MultiReport, error) {
+	v := &validator{opts: opts, loaded: map[string]bool{}, plugins: map[string]bool{}}
+	hasMarketplace := fileExists(filepath.Join(dir, MarketplaceManifest))
+	hasPlugin := fileExists(filepath.Join(dir, PluginManifest))
+	if !hasMarketplace && !hasPlugin {
+		return nil, fmt.Errorf("no %s or %s found in %s", PluginManifest, MarketplaceManifest, dir)
+	}
+	if hasMarketplace {
+		v.marketplace(dir)
+	}
+	if hasPlugin && !v.plugins[dir] {
+		v.plugin(dir, nil)
+	}
+	v.manifests[0].Results = append(v.manifests[0].Results, v.unlistedSkills(dir)...)
+
+	mr := &types.MultiReport{}
+	for _, r := range append(v.manifests, v.skills...) {
+		r.Tally()
+		mr.Skills = append(mr.Skills, r)
+		mr.Errors += r.Errors
+		mr.Warnings += r.Warnings
+	}
+	return mr, nil
+}
+
+// entry is a plugin's entry in a marketplace manifest.
+type entry struct {
+	doc     *document
+	pointer string // JSON pointer of the entry in the marketplace manifest
+	report  *types.Report
+	name    string
+	fields  map[string]any
+}
+
+// marketplace validates the marketplace manifest in dir and each plugin it
+// lists with a local source.
+func (v *validator) marketplace(dir string) {
+	rpt := &types.Report{SkillDir: dir}
+	v.manifests = append(v.manifests, rpt)
+	ctx := types.ResultContext{Category: "Marketplace", File: MarketplaceManifest}
+	doc, results := loadDocument(ctx, dir, marketplaceSchema)
+	rpt.Results = append(rpt.Results, results...)
+	if doc == nil {
+		return
+	}
+
+	pluginRoot := ""
+	if meta, ok := doc.data["metadata"].(map[string]any); ok {
+		pluginRoot, _ = meta["pluginRoot"].(string)
+	}
+	plugins, _ := doc.data["plugins"].([]any)
+	seen := map[string]bool{}
+	for i, p := range plugins {
+		fields, ok := p.(map[string]any)
+		if !ok {
+			continue
+		}
+		pointer := "/plugins/" + strconv.Itoa(i)
+		name, _ := fields["name"].(string)
+		if name != "" && seen[name] {
+			rpt.Results = append(rpt.Results, doc.errorf(pointer+"/name", "duplicate plugin name %q", name))
+		}
+		seen[name] = true
+
+		switch source := fields["source"].(type) {
+		case string:
+			path := source
+			if pluginRoot != "" && !strings.HasPrefix(source, "./") {
+				path = filepath.Join(pluginRoot, source)
+			}
+			root, res := checkPath(doc, pointer+"/source", dir, path, false)
+			rpt.Results = append(rpt.Results, res...)
+			if root == "" {
+				continue
+			}
+			v.plugin(root, &entry{doc: doc, pointer: pointer, report: rpt, name: name, fields: fields})
+		case map[string]any:
+			kind, _ := source["source"].(string)
+			where, _ := source["repo"].(string)
+			if where == "" {
+				where, _ = source["url"].(string)
+			}
+			rpt.Results = append(rpt.Results, ctx.InfoAtLinef(ctx.File, doc.line(pointer+"/source"),
+				"plugin %q is fetched from %s %s and is not checked", name, kind, where))
+		}
+	}
+
+	if !slices.ContainsFunc(rpt.Results, func(r types.Result) bool { return r.Level == types.Error }) {
+		rpt.Results = append(rpt.Results, ctx.Passf("marketplace %q lists %d plugin%s", doc.data["name"], len(plugins), util.PluralS(len(plugins))))
+	}
+}
+
+// plugin validates the plugin in root: its manifest, if it has one, the
+// component paths of the manifest and of its marketplace entry e (nil
+// outside a marketplace), and every skill it loads.
+func (v *validator) plugin(root string, e *entry) {
+	var rpt *types.Report
+	var doc *document
+	ctx := types.ResultContext{Category: "Plugin", File: PluginManifest}
+	manifestChecked := v.plugins[root]
+	v.plugins[root] = true
+
+	hasManifest := fileExists(filepath.Join(root, PluginManifest))
+	switch {
+	case hasManifest && !manifestChecked:
+		rpt = &types.Report{SkillDir: root}
+		v.manifests = append(v.manifests, rpt)
+		var results []types.Result
+		doc, results = loadDocument(ctx, root, pluginSchema)
+		rpt.Results = append(rpt.Results, results...)
+		if doc != nil {
+			for _, field := range util.SortedKeys(doc.data) {
+				if !slices.Contains(knownPluginFields, field) {
+					rpt.Results = append(rpt.Results, doc.warnf("/"+field, "unrecognized field"))
+				}
+			}
+		}
+	case !hasManifest && e != nil:
+		if strict, ok := e.fields["strict"].(bool); !ok || strict {
+			e.report.Results = append(e.report.Results, e.doc.errorf(e.pointer+"/source",
+				"plugin %q has no %s; add one, or set \"strict\": false to define the plugin in its marketplace entry", e.name, PluginManifest))
+		}
+	}
+
+	// Component paths, from the plugin manifest and the marketplace entry.
+	var skillDirs []string
+	if doc != nil {
+		results, dirs := v.components(doc, "", root, doc.data)
+		rpt.Results = append(rpt.Results, results...)
+		skillDirs = append(skillDirs, dirs...)
+	}
+	if e != nil {
+		results, dirs := v.components(e.doc, e.pointer, root, e.fields)
+		e.report.Results = append(e.report.Results, results...)
+		skillDirs = append(skillDirs, dirs...)
+		if doc != nil {
+			if name, _ := doc.data["name"].(string); name != "" && e.name != "" && name != e.name {
+				e.report.Results = append(e.report.Results, e.doc.warnf(e.pointer+"/name",
+					"marketplace entry %q does not match the plugin manifest name %q", e.name, name))
+			}
+		}
+	}
+
+	// Skills in the default skills/ directory load without being listed.
+	if _, dirs := skillcheck.DetectSkills(filepath.Join(root, "skills")); len(dirs) > 0 {
+		skillDirs = append(skillDirs, dirs...)
+	}
+	slices.Sort(skillDirs)
+	skillDirs = slices.Compact(skillDirs)
+	for _, dir := range skillDirs {
+		v.validateSkill(dir)
+	}
+
+	if rpt != nil && doc != nil && !slices.ContainsFunc(rpt.Results, func(r types.Result) bool { return r.Level == types.Error }) {
+		rpt.Results = append(rpt.Results, ctx.Passf("plugin %q loads %d skill%s", doc.data["name"], len(skillDirs), util.PluralS(len(skillDirs))))
+	}
+}
+
+// components checks the component paths in fields, the plugin manifest or a
+// marketplace entry at pointer, against the plugin directory root. It
+// returns the results and the skill directories the skills field loads.
+func (v *validator) components(doc *document, pointer, root string, fields map[string]any) ([]types.Result, []string) {
+	var results []types.Result
+	var skillDirs []string
+	for _, field := range componentFields {
+		paths, pointers := stringList(pointer+"/"+field, fields[field])
+		for i, p := range paths {
+			abs, res := checkPath(doc, pointers[i], root, p, true)
+			results = append(results, res...)
+			if abs == "" || field != "skills" {
+				continue
+			}
+			mode, dirs := skillcheck.DetectSkills(abs)
+			if mode == types.NoSkill {
+				results = append(results, doc.errorf(pointers[i], "%s contains no SKILL.md", p))
+			}
+			skillDirs = append(skillDirs, dirs...)
+		}
+	}
+	return results, skillDirs
+}
+
+// checkPath resolves the manifest path p, at pointer in doc, against root.
+// Paths must exist inside root, and component paths must start with "./".
+// It returns the absolute path, or "" if the path is unusable.
+func checkPath(doc *document, pointer, root, p string, component bool) (string, []types.Result) {
+	if component && !strings.HasPrefix(p, "./") {
+		return "", []types.Result{doc.errorf(pointer, "path %q must be relative and start with \"./\"", p)}
+	}
+	if filepath.IsAbs(p) {
+		return "", []types.Result{doc.errorf(pointer, "path %q must be relative", p)}
+	}
+	abs := filepath.Join(root, filepath.FromSlash(p))
+	if rel, err := filepath.Rel(root, abs); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", []types.Result{doc.errorf(pointer, "%s is outside the %s directory", p, filepath.Base(root))}
+	}
+	if _, err := os.Stat(abs); err != nil {
+		return "", []types.Result{doc.errorf(pointer, "%s does not exist", p)}
+	}
+	return abs, nil
+}
+
+// validateSkill runs structure.Validate on the skill in dir, once.
+func (v *validator) validateSkill(dir string) {
+	if v.loaded[dir] {
+		return
+	}
+	v.loaded[dir] = true
+	v.skills = append(v.skills, structure.Validate(dir, v.opts))
+}
+
+// unlistedSkills warns about skills under dir that no manifest loads.
+// Hidden directories and node_modules are not searched.
+func (v *validator) unlistedSkills(dir string) []types.Result {
+	ctx := types.ResultContext{Category: "Plugin"}
+	var results []types.Result
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil
+		}
+		if path != dir && (strings.HasPrefix(d.Name(), ".") || d.Name() == "node_modules") {
+			return filepath.SkipDir
+		}
+		if !fileExists(filepath.Join(path, "SKILL.md")) {
+			return nil
+		}
+		if !v.loaded[path] {
+			rel, _ := filepath.Rel(dir, path)
+			rel = filepath.ToSlash(rel)
+			results = append(results, ctx.WarnFilef(rel+"/SKILL.md",
+				"skill %s/ is not loaded by any manifest; list it under \"skills\" or move it into a plugin's skills/ directory", rel))
+		}
+		return filepath.SkipDir
+	})
+	return results
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
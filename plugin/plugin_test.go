@@ -0,0 +1,172 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agent-ecosystem/skill-validator/structure"
+	"github.com/agent-ecosystem/skill-validator/types"
+)
+
+func writeFile(t *testing.T, dir, rel, content string) {
+	t.Helper()
+	path := filepath.Join(dir, rel)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeSkill(t *testing.T, dir, rel string) {
+	t.Helper()
+	name := filepath.Base(rel)
+	writeFile(t, dir, rel+"/SKILL.md", "---\nname: "+name+"\ndescription: Does "+name+" things. Use when you need "+name+".\n---\n# "+name+"\nFollow these steps.\n")
+}
+
+// requireResult finds a result in the report for dir.
+func requireResult(t *testing.T, mr *types.MultiReport, dir string, level types.Level, msg string) types.Result {
+	t.Helper()
+	for _, r := range mr.Skills {
+		if r.SkillDir != dir {
+			continue
+		}
+		for _, res := range r.Results {
+			if res.Level == level && strings.Contains(res.Message, msg) {
+				return res
+			}
+		}
+		t.Fatalf("no level=%d result containing %q for %s in %+v", level, msg, dir, r.Results)
+	}
+	t.Fatalf("no report for %s", dir)
+	return types.Result{}
+}
+
+func reportDirs(mr *types.MultiReport) []string {
+	var dirs []string
+	for _, r := range mr.Skills {
+		dirs = append(dirs, r.SkillDir)
+	}
+	return dirs
+}
+
+func TestValidate_Plugin(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, PluginManifest, `{
+  "name": "acme-tools",
+  "version": "1.0.0",
+  "author": {"name": "Acme"},
+  "skills": ["./extra/review"],
+  "commands": "./commands"
+}`)
+	writeFile(t, dir, "commands/deploy.md", "# Deploy\n")
+	writeSkill(t, dir, "skills/lint")
+	writeSkill(t, dir, "extra/review")
+
+	mr, err := Validate(dir, structure.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mr.Errors != 0 || mr.Warnings != 0 {
+		t.Errorf("expected a clean report, got %d errors, %d warnings: %+v", mr.Errors, mr.Warnings, mr.Skills[0].Results)
+	}
+	requireResult(t, mr, dir, types.Pass, `plugin "acme-tools" loads 2 skills`)
+	want := []string{dir, filepath.Join(dir, "extra/review"), filepath.Join(dir, "skills/lint")}
+	if got := reportDirs(mr); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("reports = %v, want %v", got, want)
+	}
+}
+
+func TestValidate_PluginErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, PluginManifest, `{
+  "name": "Acme Tools",
+  "skills": ["skills/lint", "./missing", "./../outside", "./empty"],
+  "agents": 3,
+  "extra": true
+}`)
+	writeSkill(t, dir, "skills/lint")
+	writeFile(t, dir, "empty/notes.md", "# Notes\n")
+	writeSkill(t, dir, "stray/unlisted")
+
+	mr, err := Validate(dir, structure.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		level types.Level
+		line  int
+		msg   string
+	}{
+		{types.Error, 2, "name: does not match pattern"},
+		{types.Error, 4, "agents: expected string or array, but got number"},
+		{types.Warning, 5, "extra: unrecognized field"},
+		{types.Error, 3, `skills[0]: path "skills/lint" must be relative and start with "./"`},
+		{types.Error, 3, "skills[1]: ./missing does not exist"},
+		{types.Error, 3, "skills[2]: ./../outside is outside the"},
+		{types.Error, 3, "skills[3]: ./empty contains no SKILL.md"},
+		{types.Warning, 0, "skill stray/unlisted/ is not loaded by any manifest"},
+	}
+	for _, tt := range tests {
+		r := requireResult(t, mr, dir, tt.level, tt.msg)
+		if r.Line != tt.line {
+			t.Errorf("%q at line %d, want %d", r.Message, r.Line, tt.line)
+		}
+	}
+	// The default skills/ directory still loads.
+	requireResult(t, mr, filepath.Join(dir, "skills/lint"), types.Pass, "SKILL.md found")
+}
+
+func TestValidate_Marketplace(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, MarketplaceManifest, `{
+  "name": "acme",
+  "owner": {"name": "Acme"},
+  "plugins": [
+    {"name": "tools", "source": "./plugins/tools"},
+    {"name": "docs", "source": "./plugins/docs", "strict": false, "skills": ["./writing"]},
+    {"name": "bare", "source": "./plugins/bare"},
+    {"name": "remote", "source": {"source": "github", "repo": "acme/remote"}},
+    {"name": "tools", "source": "./plugins/missing"}
+  ]
+}`)
+	writeFile(t, dir, "plugins/tools/"+PluginManifest, `{"name": "toolbox"}`)
+	writeSkill(t, dir, "plugins/tools/skills/lint")
+	writeSkill(t, dir, "plugins/docs/writing")
+	writeFile(t, dir, "plugins/bare/README.md", "# Bare\n")
+
+	mr, err := Validate(dir, structure.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	requireResult(t, mr, dir, types.Warning, `plugins[0].name: marketplace entry "tools" does not match the plugin manifest name "toolbox"`)
+	requireResult(t, mr, dir, types.Error, `plugins[2].source: plugin "bare" has no .claude-plugin/plugin.json`)
+	requireResult(t, mr, dir, types.Info, `plugin "remote" is fetched from github acme/remote and is not checked`)
+	requireResult(t, mr, dir, types.Error, `plugins[4].name: duplicate plugin name "tools"`)
+	requireResult(t, mr, dir, types.Error, "plugins[4].source: ./plugins/missing does not exist")
+	requireResult(t, mr, filepath.Join(dir, "plugins/tools"), types.Pass, `plugin "toolbox" loads 1 skill`)
+	requireResult(t, mr, filepath.Join(dir, "plugins/tools/skills/lint"), types.Pass, "SKILL.md found")
+	requireResult(t, mr, filepath.Join(dir, "plugins/docs/writing"), types.Pass, "SKILL.md found")
+}
+
+func TestValidate_NoManifest(t *testing.T) {
+	if _, err := Validate(t.TempDir(), structure.Options{}); err == nil {
+		t.Error("expected error for a directory without a manifest")
+	}
+}
+
+func TestValidate_InvalidJSON(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, PluginManifest, "{\n  \"name\": \"acme\",\n  \"skills\": [\n}\n")
+	mr, err := Validate(dir, structure.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := requireResult(t, mr, dir, types.Error, "parsing manifest JSON")
+	if r.Line != 4 {
+		t.Errorf("parse error at line %d, want 4", r.Line)
+	}
+}
@@ -26,6 +26,13 @@ func PrintMultiAnnotations(w io.Writer, mr *types.MultiReport, workDir string) {
 	for _, r := range mr.Skills {
 		PrintAnnotations(w, r, workDir)
 	}
+	if mr.Collection != nil {
+		for _, res := range mr.Collection.Results {
+			if line := formatAnnotation("", res, workDir); line != "" {
+				_, _ = fmt.Fprintln(w, line)
+			}
+		}
+	}
 }
 
 func formatAnnotation(skillDir string, res types.Result, workDir string) string {
@@ -52,6 +59,9 @@ func formatAnnotation(skillDir string, res types.Result, workDir string) string
 		params = fmt.Sprintf(" file=%s", relPath)
 		if res.Line > 0 {
 			params += fmt.Sprintf(",line=%d", res.Line)
+			if res.Column > 0 {
+				params += fmt.Sprintf(",col=%d", res.Column)
+			}
 		}
 		params += fmt.Sprintf(",title=%s", res.Category)
 	} else {
@@ -60,3 +70,13 @@ func formatAnnotation(skillDir string, res types.Result, workDir string) string
 
 	return fmt.Sprintf("::%s%s::%s", cmd, params, res.Message)
 }
+
+// PrintDiffAnnotations writes annotations for the failed thresholds of a
+// skill comparison.
+func PrintDiffAnnotations(w io.Writer, d *types.DiffReport, workDir string) {
+	for _, res := range d.Results {
+		if line := formatAnnotation("", res, workDir); line != "" {
+			_, _ = fmt.Fprintln(w, line)
+		}
+	}
+}
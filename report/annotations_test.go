@@ -78,6 +78,23 @@ func TestPrintAnnotations_WithLineNumber(t *testing.T) {
 	}
 }
 
+func TestPrintAnnotations_WithColumn(t *testing.T) {
+	r := &types.Report{
+		SkillDir: "/workspace/my-skill",
+		Results: []types.Result{
+			{Level: types.Error, Category: "Security", Message: "bidi control", File: "SKILL.md", Line: 7, Column: 12},
+		},
+	}
+
+	var buf bytes.Buffer
+	PrintAnnotations(&buf, r, "/workspace")
+
+	line := strings.TrimSpace(buf.String())
+	if !strings.Contains(line, "line=7,col=12") {
+		t.Errorf("expected line=7,col=12, got %q", line)
+	}
+}
+
 func TestPrintAnnotations_NoFile(t *testing.T) {
 	r := &types.Report{
 		SkillDir: "skills/my-skill",
@@ -128,3 +145,22 @@ func TestPrintMultiAnnotations(t *testing.T) {
 		t.Errorf("expected skills/b/references/big.md path, got %q", lines[1])
 	}
 }
+
+func TestPrintMultiAnnotations_Collection(t *testing.T) {
+	mr := &types.MultiReport{Skills: []*types.Report{{SkillDir: "/workspace/skills/a"}}}
+	mr.AddCollection(&types.CollectionReport{
+		Results: []types.Result{
+			{Level: types.Pass, Category: "Tokens", Message: "ok"},
+			{Level: types.Error, Category: "Tokens", Message: "over the 20-token budget"},
+		},
+	})
+
+	var buf bytes.Buffer
+	PrintMultiAnnotations(&buf, mr, "/workspace")
+
+	got := strings.TrimSpace(buf.String())
+	want := "::error title=Tokens::over the 20-token budget"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,276 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/agent-ecosystem/skill-validator/diff"
+	"github.com/agent-ecosystem/skill-validator/types"
+	"github.com/agent-ecosystem/skill-validator/util"
+)
+
+// FormatDiff formats a comparison of two skill versions in the given format.
+func FormatDiff(w io.Writer, d *types.DiffReport, format string) error {
+	switch format {
+	case "json":
+		return PrintDiffJSON(w, d)
+	case "markdown":
+		PrintDiffMarkdown(w, d)
+		return nil
+	default:
+		PrintDiff(w, d)
+		return nil
+	}
+}
+
+// PrintDiff writes a human-readable comparison of two skill versions.
+func PrintDiff(w io.Writer, d *types.DiffReport) {
+	_, _ = fmt.Fprintf(w, "\n%sComparing skill: %s → %s%s\n", colorBold, d.Old, d.New, colorReset)
+
+	_, _ = fmt.Fprintf(w, "\n%sTokens%s\n", colorBold, colorReset)
+	printDiffTokenRows(w, d.Totals)
+
+	if len(d.Files) > 0 {
+		_, _ = fmt.Fprintf(w, "\n%sChanged files%s\n", colorBold, colorReset)
+		printDiffTokenRows(w, d.Files)
+	}
+
+	if len(d.Metrics) > 0 {
+		_, _ = fmt.Fprintf(w, "\n%sSKILL.md metrics%s\n", colorBold, colorReset)
+		maxLen := 0
+		for _, m := range d.Metrics {
+			maxLen = max(maxLen, len(m.Name))
+		}
+		for _, m := range d.Metrics {
+			padding := maxLen - len(m.Name) + 2
+			_, _ = fmt.Fprintf(w, "  %s%s:%s%s%s → %s%s\n", colorCyan, m.Name, colorReset, strings.Repeat(" ", padding),
+				formatMetric(m.Old), formatMetric(m.New), formatMetricChange(m))
+		}
+	}
+
+	printDiffFindings(w, "New findings", d.NewFindings)
+	printDiffFindings(w, "Resolved findings", d.ResolvedFindings)
+
+	if len(d.Results) > 0 {
+		_, _ = fmt.Fprintf(w, "\n%sThresholds%s\n", colorBold, colorReset)
+		for _, res := range d.Results {
+			icon, color := formatLevel(res.Level)
+			_, _ = fmt.Fprintf(w, "  %s%s %s%s\n", color, icon, res.Message, colorReset)
+		}
+	}
+
+	_, _ = fmt.Fprintln(w)
+	if d.Errors == 0 && d.Warnings == 0 {
+		_, _ = fmt.Fprintf(w, "%s%sResult: passed%s\n", colorBold, colorGreen, colorReset)
+	} else {
+		parts := []string{}
+		if d.Errors > 0 {
+			parts = append(parts, fmt.Sprintf("%s%d error%s%s", colorRed, d.Errors, util.PluralS(d.Errors), colorReset))
+		}
+		if d.Warnings > 0 {
+			parts = append(parts, fmt.Sprintf("%s%d warning%s%s", colorYellow, d.Warnings, util.PluralS(d.Warnings), colorReset))
+		}
+		_, _ = fmt.Fprintf(w, "%sResult: %s%s\n", colorBold, strings.Join(parts, ", "), colorReset)
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
+func printDiffTokenRows(w io.Writer, deltas []types.TokenDelta) {
+	maxLen := 0
+	for _, td := range deltas {
+		maxLen = max(maxLen, len(td.Name)+len(diffStatusMark(td)))
+	}
+	for _, td := range deltas {
+		name := diffStatusMark(td) + td.Name
+		padding := maxLen - len(name) + 2
+		color := ""
+		switch {
+		case td.Delta() > 0:
+			color = colorYellow
+		case td.Delta() < 0:
+			color = colorGreen
+		}
+		_, _ = fmt.Fprintf(w, "  %s%s:%s%s%s → %s  %s%s%s\n", colorCyan, name, colorReset, strings.Repeat(" ", padding),
+			util.FormatNumber(td.Old), util.FormatNumber(td.New), color, diff.FormatChange(td), colorReset)
+	}
+}
+
+// diffStatusMark prefixes added and removed files.
+func diffStatusMark(td types.TokenDelta) string {
+	switch td.Status {
+	case "added":
+		return "+ "
+	case "removed":
+		return "- "
+	}
+	return ""
+}
+
+func printDiffFindings(w io.Writer, title string, findings []types.Result) {
+	if len(findings) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintf(w, "\n%s%s (%d)%s\n", colorBold, title, len(findings), colorReset)
+	for _, res := range findings {
+		icon, color := formatLevel(res.Level)
+		_, _ = fmt.Fprintf(w, "  %s%s [%s] %s%s\n", color, icon, res.Category, located(res), colorReset)
+	}
+}
+
+// formatMetric formats a metric value: whole numbers as counts, the rest
+// with two decimals.
+func formatMetric(v float64) string {
+	if v == math.Trunc(v) {
+		return util.FormatNumber(int(v))
+	}
+	return fmt.Sprintf("%.2f", v)
+}
+
+func formatMetricChange(m types.MetricDelta) string {
+	delta := m.New - m.Old
+	if delta == 0 {
+		return ""
+	}
+	if delta == math.Trunc(delta) && m.Old == math.Trunc(m.Old) && m.New == math.Trunc(m.New) {
+		return fmt.Sprintf(" (%+d)", int(delta))
+	}
+	return fmt.Sprintf(" (%+.2f)", delta)
+}
+
+// --- JSON output ---
+
+type jsonDiffReport struct {
+	Old              string              `json:"old"`
+	New              string              `json:"new"`
+	Passed           bool                `json:"passed"`
+	Errors           int                 `json:"errors"`
+	Warnings         int                 `json:"warnings"`
+	Totals           []jsonTokenDelta    `json:"totals"`
+	Files            []jsonTokenDelta    `json:"files"`
+	Metrics          []types.MetricDelta `json:"metrics,omitempty"`
+	NewFindings      []jsonResult        `json:"new_findings"`
+	ResolvedFindings []jsonResult        `json:"resolved_findings"`
+	Results          []jsonResult        `json:"results"`
+}
+
+type jsonTokenDelta struct {
+	types.TokenDelta
+	Delta int `json:"delta"`
+	// Growth is the change as a percentage of the old count; omitted for
+	// files that are new.
+	Growth *float64 `json:"growth_percent,omitempty"`
+}
+
+// PrintDiffJSON writes a comparison of two skill versions as JSON.
+func PrintDiffJSON(w io.Writer, d *types.DiffReport) error {
+	out := jsonDiffReport{
+		Old:              d.Old,
+		New:              d.New,
+		Passed:           d.Errors == 0,
+		Errors:           d.Errors,
+		Warnings:         d.Warnings,
+		Totals:           buildJSONTokenDeltas(d.Totals),
+		Files:            buildJSONTokenDeltas(d.Files),
+		Metrics:          d.Metrics,
+		NewFindings:      buildJSONResults(d.NewFindings),
+		ResolvedFindings: buildJSONResults(d.ResolvedFindings),
+		Results:          buildJSONResults(d.Results),
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+func buildJSONTokenDeltas(deltas []types.TokenDelta) []jsonTokenDelta {
+	out := make([]jsonTokenDelta, len(deltas))
+	for i, td := range deltas {
+		out[i] = jsonTokenDelta{TokenDelta: td, Delta: td.Delta()}
+		if g := td.Growth(); !math.IsInf(g, 0) {
+			g = roundPercent(g)
+			out[i].Growth = &g
+		}
+	}
+	return out
+}
+
+func buildJSONResults(results []types.Result) []jsonResult {
+	out := make([]jsonResult, len(results))
+	for i, res := range results {
+		out[i] = buildJSONResult(res)
+	}
+	return out
+}
+
+// --- Markdown output ---
+
+// PrintDiffMarkdown writes a comparison of two skill versions as Markdown.
+func PrintDiffMarkdown(w io.Writer, d *types.DiffReport) {
+	_, _ = fmt.Fprintf(w, "## Skill diff: `%s` → `%s`\n", d.Old, d.New)
+
+	_, _ = fmt.Fprintf(w, "\n### Tokens\n\n")
+	printMarkdownDiffTable(w, "", d.Totals)
+
+	if len(d.Files) > 0 {
+		_, _ = fmt.Fprintf(w, "\n### Changed files\n\n")
+		printMarkdownDiffTable(w, "File", d.Files)
+	}
+
+	if len(d.Metrics) > 0 {
+		_, _ = fmt.Fprintf(w, "\n### SKILL.md metrics\n\n")
+		_, _ = fmt.Fprintf(w, "| Metric | Old | New | Change |\n")
+		_, _ = fmt.Fprintf(w, "| --- | ---: | ---: | ---: |\n")
+		for _, m := range d.Metrics {
+			_, _ = fmt.Fprintf(w, "| %s | %s | %s | %s |\n", m.Name, formatMetric(m.Old), formatMetric(m.New),
+				strings.Trim(formatMetricChange(m), " ()"))
+		}
+	}
+
+	for _, section := range []struct {
+		title    string
+		findings []types.Result
+	}{{"New findings", d.NewFindings}, {"Resolved findings", d.ResolvedFindings}} {
+		if len(section.findings) == 0 {
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "\n### %s\n\n", section.title)
+		for _, res := range section.findings {
+			_, _ = fmt.Fprintf(w, "- %s [%s] %s\n", markdownLevelPrefix(res.Level), res.Category, located(res))
+		}
+	}
+
+	if len(d.Results) > 0 {
+		_, _ = fmt.Fprintf(w, "\n### Thresholds\n\n")
+		for _, res := range d.Results {
+			_, _ = fmt.Fprintf(w, "- %s %s\n", markdownLevelPrefix(res.Level), res.Message)
+		}
+	}
+
+	_, _ = fmt.Fprintln(w)
+	if d.Errors == 0 && d.Warnings == 0 {
+		_, _ = fmt.Fprintf(w, "**Result: passed**\n")
+	} else {
+		parts := []string{}
+		if d.Errors > 0 {
+			parts = append(parts, fmt.Sprintf("%d error%s", d.Errors, util.PluralS(d.Errors)))
+		}
+		if d.Warnings > 0 {
+			parts = append(parts, fmt.Sprintf("%d warning%s", d.Warnings, util.PluralS(d.Warnings)))
+		}
+		_, _ = fmt.Fprintf(w, "**Result: %s**\n", strings.Join(parts, ", "))
+	}
+}
+
+func printMarkdownDiffTable(w io.Writer, header string, deltas []types.TokenDelta) {
+	_, _ = fmt.Fprintf(w, "| %s | Old | New | Change |\n", header)
+	_, _ = fmt.Fprintf(w, "| --- | ---: | ---: | ---: |\n")
+	for _, td := range deltas {
+		name := td.Name
+		if td.Status == "added" || td.Status == "removed" {
+			name += " (" + td.Status + ")"
+		}
+		_, _ = fmt.Fprintf(w, "| %s | %s | %s | %s |\n", name, util.FormatNumber(td.Old), util.FormatNumber(td.New), diff.FormatChange(td))
+	}
+}
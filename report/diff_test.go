@@ -0,0 +1,100 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/agent-ecosystem/skill-validator/types"
+)
+
+func testDiffReport() *types.DiffReport {
+	return &types.DiffReport{
+		Old: "main",
+		New: "skills/my-skill",
+		Totals: []types.TokenDelta{
+			{Name: "SKILL.md body", Old: 1_000, New: 4_200},
+			{Name: "Total (excluding scripts)", Old: 3_000, New: 6_200},
+		},
+		Files: []types.TokenDelta{
+			{Name: "references/new.md", New: 800, Status: "added"},
+			{Name: "references/old.md", Old: 300, Status: "removed"},
+		},
+		Metrics:     []types.MetricDelta{{Name: "Word count", Old: 300, New: 420}, {Name: "Contamination score", Old: 0.1, New: 0.35}},
+		NewFindings: []types.Result{{Level: types.Error, Category: "Frontmatter", Message: "name is missing"}},
+		Results:     []types.Result{{Level: types.Error, Category: "Diff", Message: "SKILL.md body: +3,200 tokens (+320.0%), over the 20% growth limit"}},
+		Errors:      1,
+	}
+}
+
+func TestPrintDiff(t *testing.T) {
+	var buf bytes.Buffer
+	PrintDiff(&buf, testDiffReport())
+	output := buf.String()
+	for _, want := range []string{
+		"Comparing skill: main → skills/my-skill",
+		"1,000 → 4,200", "+3,200 tokens (+320.0%)",
+		"+ references/new.md:", "+800 tokens (new)",
+		"- references/old.md:", "-300 tokens (-100.0%)",
+		"Word count:", "300 → 420 (+120)", "0.10 → 0.35 (+0.25)",
+		"New findings (1)", "[Frontmatter] name is missing",
+		"Thresholds", "over the 20% growth limit",
+		"1 error",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "Resolved findings") {
+		t.Errorf("expected no resolved findings section, got:\n%s", output)
+	}
+}
+
+func TestPrintDiffMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := FormatDiff(&buf, testDiffReport(), "markdown"); err != nil {
+		t.Fatal(err)
+	}
+	output := buf.String()
+	for _, want := range []string{
+		"## Skill diff: `main` → `skills/my-skill`",
+		"| SKILL.md body | 1,000 | 4,200 | +3,200 tokens (+320.0%) |",
+		"| references/new.md (added) | 0 | 800 | +800 tokens (new) |",
+		"| Contamination score | 0.10 | 0.35 | +0.25 |",
+		"- **Error:** [Frontmatter] name is missing",
+		"**Result: 1 error**",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in markdown, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestPrintDiffJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := FormatDiff(&buf, testDiffReport(), "json"); err != nil {
+		t.Fatal(err)
+	}
+	var out map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if out["passed"] != false || out["errors"].(float64) != 1 {
+		t.Errorf("passed = %v, errors = %v", out["passed"], out["errors"])
+	}
+	body := out["totals"].([]any)[0].(map[string]any)
+	if body["delta"].(float64) != 3_200 || body["growth_percent"].(float64) != 320 {
+		t.Errorf("unexpected body delta %v", body)
+	}
+	added := out["files"].([]any)[0].(map[string]any)
+	if added["status"] != "added" {
+		t.Errorf("unexpected file %v", added)
+	}
+	if _, ok := added["growth_percent"]; ok {
+		t.Errorf("expected no growth_percent for an added file, got %v", added)
+	}
+	if len(out["new_findings"].([]any)) != 1 || len(out["resolved_findings"].([]any)) != 0 {
+		t.Errorf("unexpected findings %v / %v", out["new_findings"], out["resolved_findings"])
+	}
+}
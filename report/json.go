@@ -3,6 +3,7 @@ package report
 import (
 	"encoding/json"
 	"io"
+	"math"
 
 	"github.com/agent-ecosystem/skill-validator/types"
 )
@@ -15,11 +16,17 @@ type jsonReport struct {
 	Results                         []jsonResult               `json:"results"`
 	TokenCounts                     *jsonTokenCounts           `json:"token_counts,omitempty"`
 	OtherTokenCounts                *jsonTokenCounts           `json:"other_token_counts,omitempty"`
+	ScriptTokenCounts               *jsonTokenCounts           `json:"script_token_counts,omitempty"`
+	BinaryFiles                     []types.FileSize           `json:"binary_files,omitempty"`
 	ContentAnalysis                 *types.ContentReport       `json:"content_analysis,omitempty"`
 	ReferencesContentAnalysis       *types.ContentReport       `json:"references_content_analysis,omitempty"`
 	ContaminationAnalysis           *types.ContaminationReport `json:"contamination_analysis,omitempty"`
 	ReferencesContaminationAnalysis *types.ContaminationReport `json:"references_contamination_analysis,omitempty"`
 	ReferenceReports                []jsonReferenceFileReport  `json:"reference_reports,omitempty"`
+	Compatibility                   *types.CompatibilityReport `json:"compatibility,omitempty"`
+	Loading                         *types.LoadingReport       `json:"loading,omitempty"`
+	Sections                        *types.SectionsReport      `json:"sections,omitempty"`
+	TokenBudget                     *jsonTokenBudget           `json:"token_budget,omitempty"`
 }
 
 type jsonReferenceFileReport struct {
@@ -29,11 +36,29 @@ type jsonReferenceFileReport struct {
 }
 
 type jsonResult struct {
-	Level    string `json:"level"`
-	Category string `json:"category"`
-	Message  string `json:"message"`
-	File     string `json:"file,omitempty"`
-	Line     int    `json:"line,omitempty"`
+	Level     string         `json:"level"`
+	Category  string         `json:"category"`
+	Message   string         `json:"message"`
+	File      string         `json:"file,omitempty"`
+	Line      int            `json:"line,omitempty"`
+	Column    int            `json:"column,omitempty"`
+	Fix       *jsonFix       `json:"fix,omitempty"`
+	Redirects *jsonRedirects `json:"redirects,omitempty"`
+}
+
+type jsonFix struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+type jsonRedirects struct {
+	Hops     []jsonRedirectHop `json:"hops"`
+	FinalURL string            `json:"final_url"`
+}
+
+type jsonRedirectHop struct {
+	Status int    `json:"status"`
+	URL    string `json:"url"`
 }
 
 type jsonTokenCounts struct {
@@ -42,15 +67,27 @@ type jsonTokenCounts struct {
 }
 
 type jsonTokenCount struct {
-	File   string `json:"file"`
-	Tokens int    `json:"tokens"`
+	File    string   `json:"file"`
+	Tokens  int      `json:"tokens"`
+	Percent *float64 `json:"percent_of_window,omitempty"`
+}
+
+type jsonTokenBudget struct {
+	Tokenizer     string `json:"tokenizer"`
+	ContextWindow int    `json:"context_window,omitempty"`
 }
 
 type jsonMultiReport struct {
-	Passed   bool         `json:"passed"`
-	Errors   int          `json:"errors"`
-	Warnings int          `json:"warnings"`
-	Skills   []jsonReport `json:"skills"`
+	Passed     bool                  `json:"passed"`
+	Errors     int                   `json:"errors"`
+	Warnings   int                   `json:"warnings"`
+	Skills     []jsonReport          `json:"skills"`
+	Collection *jsonCollectionReport `json:"collection,omitempty"`
+}
+
+type jsonCollectionReport struct {
+	Results      []jsonResult                `json:"results"`
+	Descriptions *types.DescriptionFootprint `json:"descriptions,omitempty"`
 }
 
 func buildJSONReport(r *types.Report, perFile bool) jsonReport {
@@ -63,41 +100,33 @@ func buildJSONReport(r *types.Report, perFile bool) jsonReport {
 	}
 
 	for i, res := range r.Results {
-		out.Results[i] = jsonResult{
-			Level:    res.Level.String(),
-			Category: res.Category,
-			Message:  res.Message,
-			File:     res.File,
-			Line:     res.Line,
-		}
+		out.Results[i] = buildJSONResult(res)
 	}
 
 	if len(r.TokenCounts) > 0 {
-		tc := &jsonTokenCounts{
-			Files: make([]jsonTokenCount, len(r.TokenCounts)),
-		}
-		for i, c := range r.TokenCounts {
-			tc.Files[i] = jsonTokenCount{File: c.File, Tokens: c.Tokens}
-			tc.Total += c.Tokens
-		}
-		out.TokenCounts = tc
+		out.TokenCounts = buildJSONTokenCounts(r.TokenCounts, r.TokenBudget)
 	}
 
 	if len(r.OtherTokenCounts) > 0 {
-		otc := &jsonTokenCounts{
-			Files: make([]jsonTokenCount, len(r.OtherTokenCounts)),
-		}
-		for i, c := range r.OtherTokenCounts {
-			otc.Files[i] = jsonTokenCount{File: c.File, Tokens: c.Tokens}
-			otc.Total += c.Tokens
-		}
-		out.OtherTokenCounts = otc
+		out.OtherTokenCounts = buildJSONTokenCounts(r.OtherTokenCounts, r.TokenBudget)
+	}
+
+	if len(r.ScriptTokenCounts) > 0 {
+		out.ScriptTokenCounts = buildJSONTokenCounts(r.ScriptTokenCounts, r.TokenBudget)
+	}
+	out.BinaryFiles = r.BinaryFiles
+
+	if b := r.TokenBudget; b != nil {
+		out.TokenBudget = &jsonTokenBudget{Tokenizer: b.Tokenizer, ContextWindow: b.ContextWindow}
 	}
 
 	out.ContentAnalysis = r.ContentReport
 	out.ReferencesContentAnalysis = r.ReferencesContentReport
 	out.ContaminationAnalysis = r.ContaminationReport
 	out.ReferencesContaminationAnalysis = r.ReferencesContaminationReport
+	out.Compatibility = r.Compatibility
+	out.Loading = r.Loading
+	out.Sections = r.Sections
 
 	if perFile && len(r.ReferenceReports) > 0 {
 		out.ReferenceReports = make([]jsonReferenceFileReport, len(r.ReferenceReports))
@@ -113,6 +142,49 @@ func buildJSONReport(r *types.Report, perFile bool) jsonReport {
 	return out
 }
 
+func buildJSONResult(res types.Result) jsonResult {
+	out := jsonResult{
+		Level:    res.Level.String(),
+		Category: res.Category,
+		Message:  res.Message,
+		File:     res.File,
+		Line:     res.Line,
+		Column:   res.Column,
+	}
+	if res.Fix != nil {
+		out.Fix = &jsonFix{Old: res.Fix.Old, New: res.Fix.New}
+	}
+	if res.Redirects != nil {
+		out.Redirects = &jsonRedirects{FinalURL: res.Redirects.FinalURL}
+		for _, hop := range res.Redirects.Hops {
+			out.Redirects.Hops = append(out.Redirects.Hops, jsonRedirectHop{Status: hop.Status, URL: hop.URL})
+		}
+	}
+	return out
+}
+
+// buildJSONTokenCounts converts counts, adding each file's share of the
+// context window when budget has one.
+func buildJSONTokenCounts(counts []types.TokenCount, budget *types.TokenBudget) *jsonTokenCounts {
+	tc := &jsonTokenCounts{
+		Files: make([]jsonTokenCount, len(counts)),
+	}
+	for i, c := range counts {
+		tc.Files[i] = jsonTokenCount{File: c.File, Tokens: c.Tokens}
+		if budget != nil && budget.ContextWindow > 0 {
+			pct := roundPercent(budget.Percent(c.Tokens))
+			tc.Files[i].Percent = &pct
+		}
+		tc.Total += c.Tokens
+	}
+	return tc
+}
+
+// roundPercent rounds a percentage to two decimal places.
+func roundPercent(p float64) float64 {
+	return math.Round(p*100) / 100
+}
+
 // PrintJSON writes the report as JSON to the given writer.
 func PrintJSON(w io.Writer, r *types.Report, perFile bool) error {
 	out := buildJSONReport(r, perFile)
@@ -132,6 +204,12 @@ func PrintMultiJSON(w io.Writer, mr *types.MultiReport, perFile bool) error {
 	for i, r := range mr.Skills {
 		out.Skills[i] = buildJSONReport(r, perFile)
 	}
+	if c := mr.Collection; c != nil {
+		out.Collection = &jsonCollectionReport{Results: make([]jsonResult, len(c.Results)), Descriptions: c.Descriptions}
+		for i, res := range c.Results {
+			out.Collection.Results[i] = buildJSONResult(res)
+		}
+	}
 	enc := json.NewEncoder(w)
 	enc.SetIndent("", "  ")
 	return enc.Encode(out)
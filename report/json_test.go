@@ -3,6 +3,7 @@ package report
 import (
 	"bytes"
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/agent-ecosystem/skill-validator/types"
@@ -132,6 +133,32 @@ func TestPrintJSON_LevelStrings(t *testing.T) {
 	}
 }
 
+func TestPrintJSON_TokenBudget(t *testing.T) {
+	r := &types.Report{
+		SkillDir:    "/tmp/test",
+		TokenCounts: []types.TokenCount{{File: "SKILL.md body", Tokens: 1000}},
+		TokenBudget: &types.TokenBudget{Tokenizer: "approx", ContextWindow: 128_000},
+	}
+
+	var buf bytes.Buffer
+	if err := PrintJSON(&buf, r, false); err != nil {
+		t.Fatalf("PrintJSON error: %v", err)
+	}
+
+	var out map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	budget := out["token_budget"].(map[string]any)
+	if budget["tokenizer"] != "approx" || budget["context_window"].(float64) != 128_000 {
+		t.Errorf("token_budget = %v", budget)
+	}
+	first := out["token_counts"].(map[string]any)["files"].([]any)[0].(map[string]any)
+	if first["percent_of_window"].(float64) != 0.78 {
+		t.Errorf("percent_of_window = %v, want 0.78", first["percent_of_window"])
+	}
+}
+
 func TestPrintJSON_TokenCounts(t *testing.T) {
 	r := &types.Report{
 		SkillDir: "/tmp/test",
@@ -259,6 +286,136 @@ func TestPrintJSON_SpecialCharacters(t *testing.T) {
 	}
 }
 
+func TestPrintJSON_ColumnAndFix(t *testing.T) {
+	r := &types.Report{
+		SkillDir: "/tmp/test",
+		Results: []types.Result{
+			{Level: types.Warning, Category: "Links", Message: "moved", File: "SKILL.md", Line: 3, Column: 5,
+				Fix: &types.Fix{Old: "http://old.example", New: "https://new.example"}},
+			{Level: types.Pass, Category: "Links", Message: "ok"},
+		},
+		Warnings: 1,
+	}
+
+	var buf bytes.Buffer
+	if err := PrintJSON(&buf, r, false); err != nil {
+		t.Fatalf("PrintJSON error: %v", err)
+	}
+
+	var out map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	results := out["results"].([]any)
+	if col := results[0].(map[string]any)["column"]; col != 5.0 {
+		t.Errorf("column = %v, want 5", col)
+	}
+	fix, ok := results[0].(map[string]any)["fix"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected fix object on first result, got %v", results[0])
+	}
+	if fix["old"] != "http://old.example" || fix["new"] != "https://new.example" {
+		t.Errorf("fix = %v", fix)
+	}
+	if _, ok := results[1].(map[string]any)["fix"]; ok {
+		t.Error("expected no fix key on result without a fix")
+	}
+}
+
+func TestPrintJSON_Redirects(t *testing.T) {
+	r := &types.Report{
+		SkillDir: "/tmp/test",
+		Results: []types.Result{
+			{Level: types.Warning, Category: "Links", Message: "moved", File: "SKILL.md", Line: 3,
+				Redirects: &types.Redirects{
+					Hops:     []types.RedirectHop{{Status: 301, URL: "https://example.com/b"}, {Status: 302, URL: "https://example.com/c"}},
+					FinalURL: "https://example.com/c",
+				}},
+			{Level: types.Pass, Category: "Links", Message: "ok"},
+		},
+		Warnings: 1,
+	}
+
+	var buf bytes.Buffer
+	if err := PrintJSON(&buf, r, false); err != nil {
+		t.Fatalf("PrintJSON error: %v", err)
+	}
+
+	var out struct {
+		Results []struct {
+			Redirects *struct {
+				Hops []struct {
+					Status int    `json:"status"`
+					URL    string `json:"url"`
+				} `json:"hops"`
+				FinalURL string `json:"final_url"`
+			} `json:"redirects"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	rd := out.Results[0].Redirects
+	if rd == nil || rd.FinalURL != "https://example.com/c" || len(rd.Hops) != 2 {
+		t.Fatalf("redirects = %+v", rd)
+	}
+	if rd.Hops[0].Status != 301 || rd.Hops[0].URL != "https://example.com/b" {
+		t.Errorf("first hop = %+v", rd.Hops[0])
+	}
+	if out.Results[1].Redirects != nil {
+		t.Error("expected no redirects key on a result without redirects")
+	}
+}
+
+func TestPrintJSON_Compatibility(t *testing.T) {
+	r := &types.Report{
+		SkillDir: "/tmp/test",
+		Compatibility: &types.CompatibilityReport{
+			OS:       []string{"linux"},
+			Binaries: []string{"git"},
+			Runtimes: []types.RuntimeRequirement{{Name: "python", Version: "3.10", Constraint: ">="}},
+			Network:  "none",
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := PrintJSON(&buf, r, false); err != nil {
+		t.Fatalf("PrintJSON error: %v", err)
+	}
+
+	var out map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	compat, ok := out["compatibility"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected compatibility object, got %v", out["compatibility"])
+	}
+	if compat["network"] != "none" {
+		t.Errorf("network = %v, want none", compat["network"])
+	}
+	if _, ok := compat["platforms"]; ok {
+		t.Error("expected no platforms key when none were claimed")
+	}
+	runtimes := compat["runtimes"].([]any)
+	rt := runtimes[0].(map[string]any)
+	if rt["name"] != "python" || rt["version"] != "3.10" || rt["constraint"] != ">=" {
+		t.Errorf("runtime = %v", rt)
+	}
+
+	// Omitted when the skill makes no claims
+	buf.Reset()
+	if err := PrintJSON(&buf, &types.Report{SkillDir: "/tmp/test"}, false); err != nil {
+		t.Fatalf("PrintJSON error: %v", err)
+	}
+	if strings.Contains(buf.String(), "compatibility") {
+		t.Errorf("expected no compatibility key, got %s", buf.String())
+	}
+}
+
 func TestPrintMultiJSON_AllPassed(t *testing.T) {
 	mr := &types.MultiReport{
 		Skills: []*types.Report{
@@ -603,3 +760,89 @@ func TestPrintMultiJSON_WithContamination(t *testing.T) {
 		t.Errorf("second skill contamination_score = %v, want 0.6", ca2["contamination_score"])
 	}
 }
+
+func TestPrintMultiJSON_Collection(t *testing.T) {
+	mr := &types.MultiReport{Skills: []*types.Report{{SkillDir: "/tmp/a"}}}
+	mr.AddCollection(&types.CollectionReport{
+		Results: []types.Result{{Level: types.Error, Category: "Tokens", Message: "over budget"}},
+		Descriptions: &types.DescriptionFootprint{
+			TotalTokens: 30,
+			Budget:      20,
+			Skills:      []types.SkillTokens{{Skill: "a", Tokens: 30}},
+		},
+	})
+
+	var buf bytes.Buffer
+	if err := PrintMultiJSON(&buf, mr, false); err != nil {
+		t.Fatalf("PrintMultiJSON error: %v", err)
+	}
+	var out map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if out["passed"] != false || out["errors"].(float64) != 1 {
+		t.Errorf("passed = %v, errors = %v", out["passed"], out["errors"])
+	}
+	collection := out["collection"].(map[string]any)
+	results := collection["results"].([]any)
+	if len(results) != 1 || results[0].(map[string]any)["level"] != "error" {
+		t.Errorf("unexpected results %v", results)
+	}
+	descriptions := collection["descriptions"].(map[string]any)
+	if descriptions["total_tokens"].(float64) != 30 || descriptions["budget"].(float64) != 20 {
+		t.Errorf("unexpected descriptions %v", descriptions)
+	}
+	skills := descriptions["skills"].([]any)
+	if skills[0].(map[string]any)["skill"] != "a" {
+		t.Errorf("unexpected skills %v", skills)
+	}
+}
+
+func TestPrintJSON_ScriptsAndBinaryFiles(t *testing.T) {
+	r := &types.Report{
+		SkillDir:          "/tmp/test",
+		ScriptTokenCounts: []types.TokenCount{{File: "scripts/run.sh", Tokens: 120}},
+		BinaryFiles:       []types.FileSize{{File: "assets/logo.png", Bytes: 2048}},
+	}
+
+	var buf bytes.Buffer
+	if err := PrintJSON(&buf, r, false); err != nil {
+		t.Fatalf("PrintJSON error: %v", err)
+	}
+	var out map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	scripts := out["script_token_counts"].(map[string]any)
+	if scripts["total"].(float64) != 120 {
+		t.Errorf("script total = %v, want 120", scripts["total"])
+	}
+	binaries := out["binary_files"].([]any)
+	if len(binaries) != 1 || binaries[0].(map[string]any)["bytes"].(float64) != 2048 {
+		t.Errorf("unexpected binary_files %v", binaries)
+	}
+}
+
+func TestPrintJSON_Sections(t *testing.T) {
+	var buf bytes.Buffer
+	if err := PrintJSON(&buf, sectionsTestReport(), false); err != nil {
+		t.Fatalf("PrintJSON error: %v", err)
+	}
+	var out map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	sections := out["sections"].(map[string]any)
+	files := sections["files"].([]any)
+	if len(files) != 2 {
+		t.Fatalf("expected 2 files, got %v", files)
+	}
+	setup := files[0].(map[string]any)["sections"].([]any)[1].(map[string]any)
+	if setup["heading"] != "Setup | Install" || setup["subtree_tokens"].(float64) != 4000 || setup["weak_markers"].(float64) != 3 {
+		t.Errorf("unexpected section %v", setup)
+	}
+	largest := sections["largest"].([]any)
+	if len(largest) != 2 || largest[0].(map[string]any)["percent"].(float64) != 64.5 {
+		t.Errorf("unexpected largest %v", largest)
+	}
+}
@@ -19,36 +19,47 @@ func PrintMarkdown(w io.Writer, r *types.Report, perFile bool) error {
 		_, _ = fmt.Fprintf(w, "\n### %s\n\n", cat)
 		for _, res := range grouped[cat] {
 			prefix := markdownLevelPrefix(res.Level)
-			_, _ = fmt.Fprintf(w, "- %s %s\n", prefix, res.Message)
+			_, _ = fmt.Fprintf(w, "- %s %s\n", prefix, located(res))
 		}
 	}
 
 	// Token counts
 	if len(r.TokenCounts) > 0 {
-		_, _ = fmt.Fprintf(w, "\n### Tokens\n\n")
-		_, _ = fmt.Fprintf(w, "| File | Tokens |\n")
-		_, _ = fmt.Fprintf(w, "| --- | ---: |\n")
-
-		total := 0
-		for _, tc := range r.TokenCounts {
-			total += tc.Tokens
-			_, _ = fmt.Fprintf(w, "| %s | %s |\n", tc.File, util.FormatNumber(tc.Tokens))
-		}
-		_, _ = fmt.Fprintf(w, "| **Total** | **%s** |\n", util.FormatNumber(total))
+		_, _ = fmt.Fprintf(w, "\n### Tokens%s\n\n", budgetLabel(r.TokenBudget))
+		printMarkdownTokenTable(w, r.TokenCounts, r.TokenBudget, "Total")
 	}
 
 	// Other files token counts
 	if len(r.OtherTokenCounts) > 0 {
 		_, _ = fmt.Fprintf(w, "\n### Other files\n\n")
-		_, _ = fmt.Fprintf(w, "| File | Tokens |\n")
-		_, _ = fmt.Fprintf(w, "| --- | ---: |\n")
+		printMarkdownTokenTable(w, r.OtherTokenCounts, r.TokenBudget, "Total (other)")
+	}
 
-		total := 0
-		for _, tc := range r.OtherTokenCounts {
-			total += tc.Tokens
-			_, _ = fmt.Fprintf(w, "| %s | %s |\n", tc.File, util.FormatNumber(tc.Tokens))
+	// Scripts token counts
+	if len(r.ScriptTokenCounts) > 0 {
+		_, _ = fmt.Fprintf(w, "\n### Scripts\n\n")
+		_, _ = fmt.Fprintf(w, "Scripts are usually executed, not read, so these tokens only load if an agent opens them.\n\n")
+		printMarkdownTokenTable(w, r.ScriptTokenCounts, r.TokenBudget, "Total (scripts)")
+	}
+
+	// Binary files
+	if len(r.BinaryFiles) > 0 {
+		_, _ = fmt.Fprintf(w, "\n### Binary files\n\n")
+		_, _ = fmt.Fprintf(w, "| File | Bytes |\n")
+		_, _ = fmt.Fprintf(w, "| --- | ---: |\n")
+		for _, f := range r.BinaryFiles {
+			_, _ = fmt.Fprintf(w, "| %s | %s |\n", f.File, util.FormatNumber(int(f.Bytes)))
 		}
-		_, _ = fmt.Fprintf(w, "| **Total (other)** | **%s** |\n", util.FormatNumber(total))
+	}
+
+	// Progressive disclosure
+	if r.Loading != nil {
+		printMarkdownLoadingReport(w, r.Loading)
+	}
+
+	// Section breakdown
+	if r.Sections != nil {
+		printMarkdownSectionsReport(w, r.Sections)
 	}
 
 	// Content analysis
@@ -117,6 +128,9 @@ func PrintMultiMarkdown(w io.Writer, mr *types.MultiReport, perFile bool) error
 			return err
 		}
 	}
+	if mr.Collection != nil {
+		printMarkdownCollectionReport(w, mr.Collection)
+	}
 
 	_, _ = fmt.Fprintf(w, "\n---\n\n")
 
@@ -156,6 +170,33 @@ func PrintMultiMarkdown(w io.Writer, mr *types.MultiReport, perFile bool) error
 	return nil
 }
 
+func printMarkdownCollectionReport(w io.Writer, c *types.CollectionReport) {
+	_, _ = fmt.Fprintf(w, "\n---\n\n## Collection\n\n")
+	for _, res := range c.Results {
+		_, _ = fmt.Fprintf(w, "- %s %s\n", markdownLevelPrefix(res.Level), res.Message)
+	}
+
+	fp := c.Descriptions
+	if fp == nil || len(fp.Skills) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintf(w, "\n### Largest descriptions\n\n")
+	_, _ = fmt.Fprintf(w, "| Skill | Tokens |\n")
+	_, _ = fmt.Fprintf(w, "| --- | ---: |\n")
+	shown := fp.Skills[:min(maxRankedDescriptions, len(fp.Skills))]
+	for _, st := range shown {
+		_, _ = fmt.Fprintf(w, "| %s | %s |\n", st.Skill, util.FormatNumber(st.Tokens))
+	}
+	if more := len(fp.Skills) - len(shown); more > 0 {
+		_, _ = fmt.Fprintf(w, "| … and %d more | |\n", more)
+	}
+	if fp.Budget > 0 {
+		_, _ = fmt.Fprintf(w, "| **Total** (budget %s) | **%s** |\n", util.FormatNumber(fp.Budget), util.FormatNumber(fp.TotalTokens))
+	} else {
+		_, _ = fmt.Fprintf(w, "| **Total** | **%s** |\n", util.FormatNumber(fp.TotalTokens))
+	}
+}
+
 func markdownLevelPrefix(level types.Level) string {
 	switch level {
 	case types.Pass:
@@ -171,6 +212,56 @@ func markdownLevelPrefix(level types.Level) string {
 	}
 }
 
+// printMarkdownTokenTable prints token counts as a table, with a share of
+// the context window column when budget has one.
+func printMarkdownTokenTable(w io.Writer, counts []types.TokenCount, budget *types.TokenBudget, totalLabel string) {
+	withShare := budget != nil && budget.ContextWindow > 0
+	if withShare {
+		_, _ = fmt.Fprintf(w, "| File | Tokens | Window |\n")
+		_, _ = fmt.Fprintf(w, "| --- | ---: | ---: |\n")
+	} else {
+		_, _ = fmt.Fprintf(w, "| File | Tokens |\n")
+		_, _ = fmt.Fprintf(w, "| --- | ---: |\n")
+	}
+
+	total := 0
+	for _, tc := range counts {
+		total += tc.Tokens
+		if withShare {
+			_, _ = fmt.Fprintf(w, "| %s | %s | %.1f%% |\n", tc.File, util.FormatNumber(tc.Tokens), budget.Percent(tc.Tokens))
+		} else {
+			_, _ = fmt.Fprintf(w, "| %s | %s |\n", tc.File, util.FormatNumber(tc.Tokens))
+		}
+	}
+	if withShare {
+		_, _ = fmt.Fprintf(w, "| **%s** | **%s** | **%.1f%%** |\n", totalLabel, util.FormatNumber(total), budget.Percent(total))
+	} else {
+		_, _ = fmt.Fprintf(w, "| **%s** | **%s** |\n", totalLabel, util.FormatNumber(total))
+	}
+}
+
+func printMarkdownLoadingReport(w io.Writer, lr *types.LoadingReport) {
+	_, _ = fmt.Fprintf(w, "\n### Progressive Disclosure\n\n")
+	_, _ = fmt.Fprintf(w, "| Stage | Tokens |\n")
+	_, _ = fmt.Fprintf(w, "| --- | ---: |\n")
+	_, _ = fmt.Fprintf(w, "| Startup (name + description) | %s |\n", util.FormatNumber(lr.MetadataTokens))
+	if lr.CollectionSkills > 0 {
+		_, _ = fmt.Fprintf(w, "| Startup (all %d skills) | %s |\n", lr.CollectionSkills, util.FormatNumber(lr.CollectionMetadataTokens))
+	}
+	_, _ = fmt.Fprintf(w, "| Activation (+ SKILL.md body) | %s |\n", util.FormatNumber(lr.MetadataTokens+lr.BodyTokens))
+	_, _ = fmt.Fprintf(w, "| Typical (+ median path) | %s |\n", util.FormatNumber(lr.TypicalTokens))
+	_, _ = fmt.Fprintf(w, "| Worst case (+ all on demand) | %s |\n", util.FormatNumber(lr.WorstCaseTokens))
+	if len(lr.Paths) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintf(w, "\n| Path | Line | Typical | Worst case | Files |\n")
+	_, _ = fmt.Fprintf(w, "| --- | ---: | ---: | ---: | ---: |\n")
+	for _, p := range lr.Paths {
+		_, _ = fmt.Fprintf(w, "| %s | %d | %s | %s | %d |\n", p.Entry, p.Line,
+			util.FormatNumber(p.TypicalTokens), util.FormatNumber(p.WorstCaseTokens), len(p.Files))
+	}
+}
+
 func printMarkdownContentReport(w io.Writer, title string, cr *types.ContentReport) {
 	_, _ = fmt.Fprintf(w, "\n### %s\n\n", title)
 	_, _ = fmt.Fprintf(w, "| Metric | Value |\n")
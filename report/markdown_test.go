@@ -71,6 +71,26 @@ func TestPrintMarkdown_WithErrors(t *testing.T) {
 	}
 }
 
+func TestPrintMarkdown_TokenBudget(t *testing.T) {
+	r := &types.Report{
+		SkillDir:    "/tmp/test",
+		TokenCounts: []types.TokenCount{{File: "SKILL.md body", Tokens: 2000}},
+		TokenBudget: &types.TokenBudget{Tokenizer: "o200k", ContextWindow: 200_000},
+	}
+
+	var buf bytes.Buffer
+	if err := PrintMarkdown(&buf, r, false); err != nil {
+		t.Fatalf("PrintMarkdown error: %v", err)
+	}
+	output := buf.String()
+
+	for _, want := range []string{"### Tokens (o200k, 200,000-token window)", "| File | Tokens | Window |", "| SKILL.md body | 2,000 | 1.0% |", "| **Total** | **2,000** | **1.0%** |"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output:\n%s", want, output)
+		}
+	}
+}
+
 func TestPrintMarkdown_TokenCounts(t *testing.T) {
 	r := &types.Report{
 		SkillDir: "/tmp/test",
@@ -33,13 +33,13 @@ func Print(w io.Writer, r *types.Report, perFile bool) {
 		_, _ = fmt.Fprintf(w, "\n%s%s%s\n", colorBold, cat, colorReset)
 		for _, res := range grouped[cat] {
 			icon, color := formatLevel(res.Level)
-			_, _ = fmt.Fprintf(w, "  %s%s %s%s\n", color, icon, res.Message, colorReset)
+			_, _ = fmt.Fprintf(w, "  %s%s %s%s\n", color, icon, located(res), colorReset)
 		}
 	}
 
 	// Token counts
 	if len(r.TokenCounts) > 0 {
-		_, _ = fmt.Fprintf(w, "\n%sTokens%s\n", colorBold, colorReset)
+		_, _ = fmt.Fprintf(w, "\n%sTokens%s%s\n", colorBold, colorReset, budgetLabel(r.TokenBudget))
 
 		maxFileLen := len("Total")
 		for _, tc := range r.TokenCounts {
@@ -52,13 +52,13 @@ func Print(w io.Writer, r *types.Report, perFile bool) {
 		for _, tc := range r.TokenCounts {
 			total += tc.Tokens
 			padding := maxFileLen - len(tc.File) + 2
-			_, _ = fmt.Fprintf(w, "  %s%s:%s%s%s tokens\n", colorCyan, tc.File, colorReset, strings.Repeat(" ", padding), util.FormatNumber(tc.Tokens))
+			_, _ = fmt.Fprintf(w, "  %s%s:%s%s%s tokens%s\n", colorCyan, tc.File, colorReset, strings.Repeat(" ", padding), util.FormatNumber(tc.Tokens), budgetPercent(r.TokenBudget, tc.Tokens))
 		}
 
 		separator := strings.Repeat("─", maxFileLen+20)
 		_, _ = fmt.Fprintf(w, "  %s\n", separator)
 		padding := maxFileLen - len("Total") + 2
-		_, _ = fmt.Fprintf(w, "  %sTotal:%s%s%s tokens\n", colorBold, colorReset, strings.Repeat(" ", padding), util.FormatNumber(total))
+		_, _ = fmt.Fprintf(w, "  %sTotal:%s%s%s tokens%s\n", colorBold, colorReset, strings.Repeat(" ", padding), util.FormatNumber(total), budgetPercent(r.TokenBudget, total))
 	}
 
 	// Other files token counts
@@ -85,7 +85,7 @@ func Print(w io.Writer, r *types.Report, perFile bool) {
 				countColor = colorYellow
 				countColorEnd = colorReset
 			}
-			_, _ = fmt.Fprintf(w, "  %s%s:%s%s%s%s tokens%s\n", colorCyan, tc.File, colorReset, strings.Repeat(" ", padding), countColor, util.FormatNumber(tc.Tokens), countColorEnd)
+			_, _ = fmt.Fprintf(w, "  %s%s:%s%s%s%s tokens%s%s\n", colorCyan, tc.File, colorReset, strings.Repeat(" ", padding), countColor, util.FormatNumber(tc.Tokens), countColorEnd, budgetPercent(r.TokenBudget, tc.Tokens))
 		}
 
 		separator := strings.Repeat("─", maxFileLen+20)
@@ -101,7 +101,53 @@ func Print(w io.Writer, r *types.Report, perFile bool) {
 			totalColor = colorYellow
 			totalColorEnd = colorReset
 		}
-		_, _ = fmt.Fprintf(w, "  %s%s:%s%s%s%s tokens%s\n", colorBold, label, colorReset, strings.Repeat(" ", padding), totalColor, util.FormatNumber(total), totalColorEnd)
+		_, _ = fmt.Fprintf(w, "  %s%s:%s%s%s%s tokens%s%s\n", colorBold, label, colorReset, strings.Repeat(" ", padding), totalColor, util.FormatNumber(total), totalColorEnd, budgetPercent(r.TokenBudget, total))
+	}
+
+	// Scripts token counts
+	if len(r.ScriptTokenCounts) > 0 {
+		_, _ = fmt.Fprintf(w, "\n%sScripts (usually executed, not read)%s\n", colorBold, colorReset)
+
+		maxFileLen := len("Total (scripts)")
+		for _, tc := range r.ScriptTokenCounts {
+			maxFileLen = max(maxFileLen, len(tc.File))
+		}
+
+		total := 0
+		for _, tc := range r.ScriptTokenCounts {
+			total += tc.Tokens
+			padding := maxFileLen - len(tc.File) + 2
+			_, _ = fmt.Fprintf(w, "  %s%s:%s%s%s tokens%s\n", colorCyan, tc.File, colorReset, strings.Repeat(" ", padding), util.FormatNumber(tc.Tokens), budgetPercent(r.TokenBudget, tc.Tokens))
+		}
+
+		_, _ = fmt.Fprintf(w, "  %s\n", strings.Repeat("─", maxFileLen+20))
+		label := "Total (scripts)"
+		padding := maxFileLen - len(label) + 2
+		_, _ = fmt.Fprintf(w, "  %s%s:%s%s%s tokens%s\n", colorBold, label, colorReset, strings.Repeat(" ", padding), util.FormatNumber(total), budgetPercent(r.TokenBudget, total))
+	}
+
+	// Binary files
+	if len(r.BinaryFiles) > 0 {
+		_, _ = fmt.Fprintf(w, "\n%sBinary files (not tokenized)%s\n", colorBold, colorReset)
+
+		maxFileLen := 0
+		for _, f := range r.BinaryFiles {
+			maxFileLen = max(maxFileLen, len(f.File))
+		}
+		for _, f := range r.BinaryFiles {
+			padding := maxFileLen - len(f.File) + 2
+			_, _ = fmt.Fprintf(w, "  %s%s:%s%s%s bytes\n", colorCyan, f.File, colorReset, strings.Repeat(" ", padding), util.FormatNumber(int(f.Bytes)))
+		}
+	}
+
+	// Progressive disclosure
+	if r.Loading != nil {
+		printLoadingReport(w, r.Loading, r.TokenBudget)
+	}
+
+	// Section breakdown
+	if r.Sections != nil {
+		printSectionsReport(w, r.Sections, r.TokenBudget)
 	}
 
 	// Content analysis
@@ -167,6 +213,9 @@ func PrintMulti(w io.Writer, mr *types.MultiReport, perFile bool) {
 		}
 		Print(w, r, perFile)
 	}
+	if mr.Collection != nil {
+		printCollectionReport(w, mr.Collection)
+	}
 
 	passed := 0
 	failed := 0
@@ -204,6 +253,47 @@ func PrintMulti(w io.Writer, mr *types.MultiReport, perFile bool) {
 	_, _ = fmt.Fprintln(w)
 }
 
+// maxRankedDescriptions is how many skills the description ranking lists.
+const maxRankedDescriptions = 10
+
+func printCollectionReport(w io.Writer, c *types.CollectionReport) {
+	_, _ = fmt.Fprintf(w, "\n%s\n", strings.Repeat("━", 60))
+	_, _ = fmt.Fprintf(w, "\n%sCollection%s\n", colorBold, colorReset)
+	for _, res := range c.Results {
+		icon, color := formatLevel(res.Level)
+		_, _ = fmt.Fprintf(w, "  %s%s %s%s\n", color, icon, res.Message, colorReset)
+	}
+
+	fp := c.Descriptions
+	if fp == nil || len(fp.Skills) == 0 {
+		_, _ = fmt.Fprintln(w)
+		return
+	}
+	_, _ = fmt.Fprintf(w, "\n%sLargest descriptions (always loaded)%s\n", colorBold, colorReset)
+	shown := fp.Skills[:min(maxRankedDescriptions, len(fp.Skills))]
+	maxLen := len("Total")
+	for _, st := range shown {
+		maxLen = max(maxLen, len(st.Skill))
+	}
+	for _, st := range shown {
+		padding := maxLen - len(st.Skill) + 2
+		_, _ = fmt.Fprintf(w, "  %s%s:%s%s%s tokens\n", colorCyan, st.Skill, colorReset, strings.Repeat(" ", padding), util.FormatNumber(st.Tokens))
+	}
+	if more := len(fp.Skills) - len(shown); more > 0 {
+		_, _ = fmt.Fprintf(w, "  … and %d more\n", more)
+	}
+	_, _ = fmt.Fprintf(w, "  %s\n", strings.Repeat("─", maxLen+20))
+	total := util.FormatNumber(fp.TotalTokens) + " tokens"
+	if fp.Budget > 0 {
+		color := colorGreen
+		if fp.TotalTokens > fp.Budget {
+			color = colorRed
+		}
+		total = fmt.Sprintf("%s%s%s of %s budget", color, total, colorReset, util.FormatNumber(fp.Budget))
+	}
+	_, _ = fmt.Fprintf(w, "  %sTotal:%s%s%s\n\n", colorBold, colorReset, strings.Repeat(" ", maxLen-len("Total")+2), total)
+}
+
 func printContentReport(w io.Writer, title string, cr *types.ContentReport) {
 	_, _ = fmt.Fprintf(w, "\n%s%s%s\n", colorBold, title, colorReset)
 	_, _ = fmt.Fprintf(w, "  Word count:               %s\n", util.FormatNumber(cr.WordCount))
@@ -215,6 +305,43 @@ func printContentReport(w io.Writer, title string, cr *types.ContentReport) {
 		cr.SectionCount, cr.ListItemCount, cr.CodeBlockCount)
 }
 
+func printLoadingReport(w io.Writer, lr *types.LoadingReport, budget *types.TokenBudget) {
+	_, _ = fmt.Fprintf(w, "\n%sProgressive Disclosure%s\n", colorBold, colorReset)
+	type row struct {
+		label  string
+		tokens int
+	}
+	rows := []row{{"Startup (name + description)", lr.MetadataTokens}}
+	if lr.CollectionSkills > 0 {
+		rows = append(rows, row{fmt.Sprintf("Startup (all %d skills)", lr.CollectionSkills), lr.CollectionMetadataTokens})
+	}
+	rows = append(rows,
+		row{"Activation (+ SKILL.md body)", lr.MetadataTokens + lr.BodyTokens},
+		row{"Typical (+ median path)", lr.TypicalTokens},
+		row{"Worst case (+ all on demand)", lr.WorstCaseTokens},
+	)
+	maxLabel := 0
+	for _, r := range rows {
+		maxLabel = max(maxLabel, len(r.label))
+	}
+	for _, r := range rows {
+		padding := maxLabel - len(r.label) + 2
+		_, _ = fmt.Fprintf(w, "  %s:%s%s tokens%s\n", r.label, strings.Repeat(" ", padding), util.FormatNumber(r.tokens), budgetPercent(budget, r.tokens))
+	}
+	if len(lr.Paths) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintf(w, "  On-demand paths:\n")
+	for _, p := range lr.Paths {
+		_, _ = fmt.Fprintf(w, "    %s%s%s (line %d): %s typical, %s worst case", colorCyan, p.Entry, colorReset, p.Line,
+			util.FormatNumber(p.TypicalTokens), util.FormatNumber(p.WorstCaseTokens))
+		if len(p.Files) > 1 {
+			_, _ = fmt.Fprintf(w, " (%d files)", len(p.Files))
+		}
+		_, _ = fmt.Fprintln(w)
+	}
+}
+
 func printContaminationReport(w io.Writer, title string, rr *types.ContaminationReport) {
 	_, _ = fmt.Fprintf(w, "\n%s%s%s\n", colorBold, title, colorReset)
 	levelColor := colorGreen
@@ -240,6 +367,27 @@ func printContaminationReport(w io.Writer, title string, rr *types.Contamination
 	_, _ = fmt.Fprintf(w, "  Scope breadth: %d\n", rr.ScopeBreadth)
 }
 
+// budgetLabel describes how a report's tokens were counted, e.g.
+// " (cl100k, 128,000-token window)", or "" for the defaults.
+func budgetLabel(b *types.TokenBudget) string {
+	if b == nil {
+		return ""
+	}
+	if b.ContextWindow <= 0 {
+		return fmt.Sprintf(" (%s)", b.Tokenizer)
+	}
+	return fmt.Sprintf(" (%s, %s-token window)", b.Tokenizer, util.FormatNumber(b.ContextWindow))
+}
+
+// budgetPercent formats tokens as a share of the context window, e.g.
+// " (6.3%)", or "" if the report has no window.
+func budgetPercent(b *types.TokenBudget, tokens int) string {
+	if b == nil || b.ContextWindow <= 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (%.1f%%)", b.Percent(tokens))
+}
+
 // groupByCategory groups results by category, preserving first-appearance order.
 func groupByCategory(results []types.Result) ([]string, map[string][]types.Result) {
 	var categories []string
@@ -253,6 +401,27 @@ func groupByCategory(results []types.Result) ([]string, map[string][]types.Resul
 	return categories, grouped
 }
 
+// located returns res.Message led by the file and line the result points
+// at, so findings that leave their location to Result.File and Result.Line
+// can still be traced in text output. Messages that already name their file
+// are returned as they are, and so are SKILL.md results other than warnings
+// and errors with a line.
+func located(res types.Result) string {
+	if res.File == "" || strings.Contains(res.Message, res.File) {
+		return res.Message
+	}
+	if res.File == "SKILL.md" && (res.Line == 0 || (res.Level != types.Warning && res.Level != types.Error)) {
+		return res.Message
+	}
+	switch {
+	case res.Line > 0 && res.Column > 0:
+		return fmt.Sprintf("%s:%d:%d: %s", res.File, res.Line, res.Column, res.Message)
+	case res.Line > 0:
+		return fmt.Sprintf("%s:%d: %s", res.File, res.Line, res.Message)
+	}
+	return res.File + ": " + res.Message
+}
+
 func formatLevel(level types.Level) (string, string) {
 	switch level {
 	case types.Pass:
@@ -2,6 +2,7 @@ package report
 
 import (
 	"bytes"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -37,6 +38,41 @@ func TestPrint_Passed(t *testing.T) {
 	}
 }
 
+func TestPrint_Locations(t *testing.T) {
+	r := &types.Report{
+		SkillDir: "/tmp/my-skill",
+		Results: []types.Result{
+			{Level: types.Warning, Category: "Scripts", Message: "script has no shebang line", File: "scripts/run.sh"},
+			{Level: types.Error, Category: "Security", Message: "possible secret: GitHub token", File: "scripts/run.sh", Line: 3, Column: 9},
+			{Level: types.Warning, Category: "Security", Message: "invisible U+200B ZERO WIDTH SPACE", File: "SKILL.md", Line: 7},
+			{Level: types.Error, Category: "Markdown", Message: "references/a.md has an unclosed code fence starting at line 4", File: "references/a.md", Line: 4},
+			{Level: types.Warning, Category: "Tokens", Message: "SKILL.md body is 6000 tokens", File: "SKILL.md"},
+			{Level: types.Pass, Category: "Frontmatter", Message: `name: "my-skill" (valid)`, File: "SKILL.md", Line: 2},
+		},
+	}
+
+	var buf bytes.Buffer
+	Print(&buf, r, false)
+	output := buf.String()
+
+	for _, want := range []string{
+		"scripts/run.sh: script has no shebang line",
+		"scripts/run.sh:3:9: possible secret: GitHub token",
+		"SKILL.md:7: invisible U+200B ZERO WIDTH SPACE",
+		" references/a.md has an unclosed code fence starting at line 4",
+		" SKILL.md body is 6000 tokens",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output:\n%s", want, output)
+		}
+	}
+	for _, unwanted := range []string{"references/a.md:4:", "SKILL.md:2:"} {
+		if strings.Contains(output, unwanted) {
+			t.Errorf("unexpected location %q in output:\n%s", unwanted, output)
+		}
+	}
+}
+
 func TestPrint_WithErrors(t *testing.T) {
 	r := &types.Report{
 		SkillDir: "/tmp/bad-skill",
@@ -122,6 +158,56 @@ func TestPrint_Pluralization(t *testing.T) {
 	}
 }
 
+func TestPrint_Loading(t *testing.T) {
+	r := &types.Report{
+		SkillDir: "/tmp/test",
+		Loading: &types.LoadingReport{
+			MetadataTokens:           40,
+			CollectionMetadataTokens: 400,
+			CollectionSkills:         10,
+			BodyTokens:               960,
+			OnDemandTokens:           3000,
+			TypicalTokens:            2000,
+			WorstCaseTokens:          4000,
+			Paths: []types.LoadingPath{
+				{Entry: "references/guide.md", Line: 12, Files: []string{"references/guide.md", "references/api.md"}, TypicalTokens: 1000, WorstCaseTokens: 3000},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	Print(&buf, r, false)
+	output := buf.String()
+
+	for _, want := range []string{"Progressive Disclosure", "Startup (all 10 skills):", "400 tokens", "1,000 tokens", "4,000 tokens",
+		"references/guide.md", "(line 12): 1,000 typical, 3,000 worst case (2 files)"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output:\n%s", want, output)
+		}
+	}
+}
+
+func TestPrint_TokenBudget(t *testing.T) {
+	r := &types.Report{
+		SkillDir: "/tmp/test",
+		TokenCounts: []types.TokenCount{
+			{File: "SKILL.md body", Tokens: 1280},
+			{File: "references/guide.md", Tokens: 6400},
+		},
+		TokenBudget: &types.TokenBudget{Tokenizer: "cl100k", ContextWindow: 128_000},
+	}
+
+	var buf bytes.Buffer
+	Print(&buf, r, false)
+	output := buf.String()
+
+	for _, want := range []string{"(cl100k, 128,000-token window)", "1,280 tokens (1.0%)", "6,400 tokens (5.0%)", "7,680 tokens (6.0%)"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output:\n%s", want, output)
+		}
+	}
+}
+
 func TestPrint_TokenCounts(t *testing.T) {
 	r := &types.Report{
 		SkillDir: "/tmp/test",
@@ -688,3 +774,59 @@ func TestPrintMulti_AggregatedCounts(t *testing.T) {
 		t.Errorf("expected '3 warnings' in total, got:\n%s", output)
 	}
 }
+
+func TestPrintMulti_Collection(t *testing.T) {
+	fp := &types.DescriptionFootprint{Budget: 100}
+	for i := range 12 {
+		fp.Skills = append(fp.Skills, types.SkillTokens{Skill: fmt.Sprintf("skill-%02d", i), Tokens: 20 - i})
+		fp.TotalTokens += 20 - i
+	}
+	mr := &types.MultiReport{
+		Skills: []*types.Report{{SkillDir: "/tmp/a"}},
+	}
+	mr.AddCollection(&types.CollectionReport{
+		Results:      []types.Result{{Level: types.Error, Category: "Tokens", Message: "over the 100-token budget"}},
+		Descriptions: fp,
+	})
+
+	var buf bytes.Buffer
+	PrintMulti(&buf, mr, false)
+	output := buf.String()
+
+	for _, want := range []string{"Collection", "over the 100-token budget", "Largest descriptions", "skill-09", "… and 2 more", "174 tokens", "of 100 budget", "1 error"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "skill-10") {
+		t.Errorf("expected ranking to stop at %d skills, got:\n%s", maxRankedDescriptions, output)
+	}
+}
+
+func TestPrint_ScriptsAndBinaryFiles(t *testing.T) {
+	r := &types.Report{
+		SkillDir:          "/tmp/test",
+		ScriptTokenCounts: []types.TokenCount{{File: "scripts/run.sh", Tokens: 1_200}, {File: "scripts/util.py", Tokens: 300}},
+		BinaryFiles:       []types.FileSize{{File: "assets/logo.png", Bytes: 48_213}},
+	}
+
+	var buf bytes.Buffer
+	Print(&buf, r, false)
+	output := buf.String()
+	for _, want := range []string{"Scripts (usually executed, not read)", "scripts/run.sh:", "1,500 tokens", "Binary files (not tokenized)", "48,213 bytes"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, output)
+		}
+	}
+
+	buf.Reset()
+	if err := PrintMarkdown(&buf, r, false); err != nil {
+		t.Fatal(err)
+	}
+	output = buf.String()
+	for _, want := range []string{"### Scripts", "| **Total (scripts)** | **1,500** |", "### Binary files", "| assets/logo.png | 48,213 |"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in markdown, got:\n%s", want, output)
+		}
+	}
+}
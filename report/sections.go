@@ -0,0 +1,98 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/agent-ecosystem/skill-validator/types"
+	"github.com/agent-ecosystem/skill-validator/util"
+)
+
+// maxSectionLabel is the width at which section headings are truncated in
+// text output.
+const maxSectionLabel = 48
+
+func printSectionsReport(w io.Writer, sr *types.SectionsReport, budget *types.TokenBudget) {
+	for _, fs := range sr.Files {
+		if len(fs.Sections) == 0 {
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "\n%sSections: %s%s (%s tokens%s)\n", colorBold, fs.File, colorReset,
+			util.FormatNumber(fs.TotalTokens), budgetPercent(budget, fs.TotalTokens))
+
+		labels := make([]string, len(fs.Sections))
+		maxLen := len("Section")
+		for i, sec := range fs.Sections {
+			labels[i] = sectionIndent(sec.Level) + truncateLabel(sec.Label())
+			maxLen = max(maxLen, len([]rune(labels[i])))
+		}
+		_, _ = fmt.Fprintf(w, "  %-*s  %5s  %7s  %7s  %6s  %5s  %6s  %4s\n", maxLen, "Section",
+			"Line", "Tokens", "Subtree", "Words", "Code", "Imper.", "Weak")
+		for i, sec := range fs.Sections {
+			padding := maxLen - len([]rune(labels[i]))
+			_, _ = fmt.Fprintf(w, "  %s%s%s%s  %5d  %7s  %7s  %6s  %5.2f  %6.2f  %4d\n", colorCyan, labels[i], colorReset,
+				strings.Repeat(" ", padding), sec.Line, util.FormatNumber(sec.Tokens), util.FormatNumber(sec.SubtreeTokens),
+				util.FormatNumber(sec.WordCount), sec.CodeBlockRatio, sec.ImperativeRatio, sec.WeakMarkers)
+		}
+	}
+
+	if len(sr.Largest) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintf(w, "\n%sLargest SKILL.md sections (candidates for references/)%s\n", colorBold, colorReset)
+	for i, sec := range sr.Largest {
+		_, _ = fmt.Fprintf(w, "  %d. %s%s%s (line %d): %s tokens, %.1f%% of the body\n", i+1, colorCyan, truncateLabel(sec.Label()), colorReset,
+			sec.Line, util.FormatNumber(sec.Tokens), sec.Percent)
+	}
+}
+
+// sectionIndent indents a heading by its depth below the top level.
+func sectionIndent(level int) string {
+	return strings.Repeat("  ", max(level-1, 0))
+}
+
+func truncateLabel(label string) string {
+	r := []rune(label)
+	if len(r) <= maxSectionLabel {
+		return label
+	}
+	return string(r[:maxSectionLabel-1]) + "…"
+}
+
+func printMarkdownSectionsReport(w io.Writer, sr *types.SectionsReport) {
+	for _, fs := range sr.Files {
+		if len(fs.Sections) == 0 {
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "\n### Sections: %s (%s tokens)\n\n", fs.File, util.FormatNumber(fs.TotalTokens))
+		_, _ = fmt.Fprintf(w, "| Section | Line | Tokens | Subtree | Words | Code ratio | Imperative ratio | Weak markers |\n")
+		_, _ = fmt.Fprintf(w, "| --- | ---: | ---: | ---: | ---: | ---: | ---: | ---: |\n")
+		for _, sec := range fs.Sections {
+			_, _ = fmt.Fprintf(w, "| %s | %d | %s | %s | %s | %.2f | %.2f | %d |\n", markdownSectionLabel(sec.Label()), sec.Line,
+				util.FormatNumber(sec.Tokens), util.FormatNumber(sec.SubtreeTokens), util.FormatNumber(sec.WordCount),
+				sec.CodeBlockRatio, sec.ImperativeRatio, sec.WeakMarkers)
+		}
+	}
+
+	if len(sr.Largest) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintf(w, "\n### Largest SKILL.md sections\n\n")
+	_, _ = fmt.Fprintf(w, "Candidates for moving into `references/`.\n\n")
+	_, _ = fmt.Fprintf(w, "| Section | Line | Tokens | Share of body |\n")
+	_, _ = fmt.Fprintf(w, "| --- | ---: | ---: | ---: |\n")
+	for _, sec := range sr.Largest {
+		_, _ = fmt.Fprintf(w, "| %s | %d | %s | %.1f%% |\n", markdownSectionLabel(sec.Label()), sec.Line,
+			util.FormatNumber(sec.Tokens), sec.Percent)
+	}
+}
+
+// markdownSectionLabel shows a heading as code in a table cell, so its
+// hashes are not read as markdown, with pipes escaped.
+func markdownSectionLabel(label string) string {
+	if !strings.HasPrefix(label, "#") {
+		return label
+	}
+	return "`" + strings.ReplaceAll(label, "|", `\|`) + "`"
+}
@@ -0,0 +1,81 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/agent-ecosystem/skill-validator/types"
+)
+
+func sectionsTestReport() *types.Report {
+	return &types.Report{
+		SkillDir: "/tmp/test",
+		Sections: &types.SectionsReport{
+			Files: []types.FileSections{
+				{File: "SKILL.md", TotalTokens: 6200, Sections: []types.SectionReport{
+					{Heading: "My Skill", Level: 1, Line: 5, Tokens: 200, SubtreeTokens: 6200, WordCount: 120},
+					{Heading: "Setup | Install", Level: 2, Line: 9, Tokens: 4000, SubtreeTokens: 4000, WordCount: 2400,
+						CodeBlockRatio: 0.25, ImperativeRatio: 0.5, WeakMarkers: 3},
+					{Heading: "Usage", Level: 2, Line: 80, Tokens: 2000, SubtreeTokens: 2000, WordCount: 1300},
+				}},
+				{File: "references/api.md", TotalTokens: 300, Sections: []types.SectionReport{
+					{Heading: "API", Level: 1, Line: 1, Tokens: 300, SubtreeTokens: 300, WordCount: 200},
+				}},
+			},
+			Largest: []types.SectionSummary{
+				{File: "SKILL.md", Heading: "Setup | Install", Level: 2, Line: 9, Tokens: 4000, Percent: 64.5},
+				{File: "SKILL.md", Heading: "Usage", Level: 2, Line: 80, Tokens: 2000, Percent: 32.3},
+			},
+		},
+	}
+}
+
+func TestPrint_Sections(t *testing.T) {
+	var buf bytes.Buffer
+	Print(&buf, sectionsTestReport(), false)
+	output := buf.String()
+
+	for _, want := range []string{
+		"Sections: SKILL.md", "(6,200 tokens)", "Sections: references/api.md",
+		"  ## Setup | Install", "4,000", "0.25", "0.50",
+		"Largest SKILL.md sections (candidates for references/)",
+		"1. ", "## Setup | Install", "(line 9): 4,000 tokens, 64.5% of the body",
+		"2. ", "(line 80): 2,000 tokens, 32.3% of the body",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output:\n%s", want, output)
+		}
+	}
+}
+
+func TestPrintMarkdown_Sections(t *testing.T) {
+	var buf bytes.Buffer
+	if err := PrintMarkdown(&buf, sectionsTestReport(), false); err != nil {
+		t.Fatal(err)
+	}
+	output := buf.String()
+
+	for _, want := range []string{
+		"### Sections: SKILL.md (6,200 tokens)",
+		"| `## Setup \\| Install` | 9 | 4,000 | 4,000 | 2,400 | 0.25 | 0.50 | 3 |",
+		"### Sections: references/api.md (300 tokens)",
+		"### Largest SKILL.md sections",
+		"| `## Usage` | 80 | 2,000 | 32.3% |",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output:\n%s", want, output)
+		}
+	}
+}
+
+func TestTruncateLabel(t *testing.T) {
+	long := "## " + strings.Repeat("é", 60)
+	got := truncateLabel(long)
+	if n := len([]rune(got)); n != maxSectionLabel || !strings.HasSuffix(got, "…") {
+		t.Errorf("truncateLabel = %q (%d runes)", got, n)
+	}
+	if got := truncateLabel("## Short"); got != "## Short" {
+		t.Errorf("truncateLabel changed a short label: %q", got)
+	}
+}
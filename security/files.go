@@ -0,0 +1,87 @@
+package security
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/agent-ecosystem/skill-validator/util"
+)
+
+// maxTextFileSize is the largest file scanned. Larger files are almost always
+// data rather than instructions an agent would read.
+const maxTextFileSize = 1 << 20
+
+// file is a text file read from a skill directory.
+type file struct {
+	path    string // relative to the skill dir
+	content string
+}
+
+// textFiles returns SKILL.md followed by every text file under the given
+// subdirectories of dir, in sorted order. Hidden files and directories,
+// files larger than maxTextFileSize, and binary files are skipped.
+func textFiles(dir string, subdirs ...string) []file {
+	var files []file
+	if data, err := os.ReadFile(filepath.Join(dir, "SKILL.md")); err == nil {
+		files = append(files, file{path: "SKILL.md", content: string(data)})
+	}
+
+	var rest []file
+	for _, d := range subdirs {
+		rest = append(rest, walkTextFiles(dir, filepath.Join(dir, d))...)
+	}
+	sort.Slice(rest, func(i, j int) bool { return rest[i].path < rest[j].path })
+	return append(files, rest...)
+}
+
+// allTextFiles returns SKILL.md followed by every other text file anywhere
+// in dir, in sorted order, with the same exclusions as textFiles.
+func allTextFiles(dir string) []file {
+	var files, rest []file
+	for _, f := range walkTextFiles(dir, dir) {
+		if f.path == "SKILL.md" {
+			files = append(files, f)
+		} else {
+			rest = append(rest, f)
+		}
+	}
+	return append(files, rest...)
+}
+
+// walkTextFiles returns the text files under root, with paths relative to
+// dir, in walk (lexical) order.
+func walkTextFiles(dir, root string) []file {
+	var files []file
+	_ = filepath.WalkDir(root, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		name := entry.Name()
+		if entry.IsDir() {
+			if strings.HasPrefix(name, ".") && path != root {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasPrefix(name, ".") || !entry.Type().IsRegular() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil || info.Size() > maxTextFileSize {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil || !util.IsText(data) {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return nil
+		}
+		files = append(files, file{path: rel, content: string(data)})
+		return nil
+	})
+	return files
+}
@@ -0,0 +1,225 @@
+package security
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/agent-ecosystem/skill-validator/content"
+	"github.com/agent-ecosystem/skill-validator/types"
+)
+
+// rule is a pattern that marks a line as suspicious.
+type rule struct {
+	level   types.Level
+	message string
+	re      *regexp.Regexp
+}
+
+// injectionRules are matched against each line of every scanned file.
+// Errors are phrases with no legitimate use in a skill; warnings are content
+// aimed at the agent itself that deserves a human look.
+var injectionRules = []rule{
+	{
+		level:   types.Error,
+		message: "prompt injection: attempts to override previous instructions",
+		re: regexp.MustCompile(`(?i)\b(?:ignore|disregard|forget|override)\s+(?:` +
+			`(?:all|any|every)\s+(?:(?:of\s+)?(?:the|your|my)\s+)?(?:(?:previous|prior|above|earlier|preceding|original|system|other)\s+)?` +
+			`|(?:(?:the|your|my)\s+)?(?:previous|prior|above|earlier|preceding|original|system)\s+)` +
+			`(?:instructions|prompts?|rules|directives|guidelines)\b`),
+	},
+	{
+		level:   types.Error,
+		message: "prompt injection: tries to reassign the agent's role or lift its restrictions",
+		re: regexp.MustCompile(`(?i)\b(?:you\s+are\s+now|from\s+now\s+on,?\s+you\s+(?:are|will)|pretend\s+(?:to\s+be|you\s+are)|act\s+as)\b` +
+			`[^.\n]{0,60}\b(?:unrestricted|jailbroken|jailbreak|DAN|developer\s+mode|no\s+(?:restrictions|limits|rules|filters)|without\s+(?:restrictions|limits|rules|filters))` +
+			`|\b(?:enable|enter|activate)\s+(?:developer|god|jailbreak|DAN)\s+mode\b`),
+	},
+	{
+		level:   types.Error,
+		message: "prompt injection: imitates a system prompt or chat-role marker",
+		re: regexp.MustCompile(`(?i)<\|(?:im_start|im_end|system|endoftext)\|>|</?system(?:[-_]prompt)?>|^\s*\[(?:system|assistant)\]` +
+			`|\b(?:new|updated|real|true)\s+system\s+(?:prompt|instructions)\b`),
+	},
+	{
+		level:   types.Error,
+		message: "prompt injection: asks the agent to reveal its system prompt",
+		re: regexp.MustCompile(`(?i)\b(?:reveal|print|output|repeat|show|leak|disclose)\s+(?:\w+\s+){0,3}` +
+			`(?:system\s+prompt|hidden\s+instructions|initial\s+instructions|developer\s+message)`),
+	},
+	{
+		level:   types.Error,
+		message: "prompt injection: instructs sending secrets or environment variables to an outside destination",
+		re: regexp.MustCompile(`(?i)\b(?:send|post|upload|exfiltrate|transmit|forward|leak|email)\b[^\n]{0,80}` +
+			`\b(?:environment\s+variables|env\s+vars?|api[\s_-]?keys?|secrets|credentials|passwords|\.env\b|printenv|os\.environ|process\.env)` +
+			`[^\n]{0,40}\b(?:to|into)\s+(?:https?://|(?:this|the\s+following|an?\s+external|a\s+remote|my|our)\s+(?:url|endpoint|server|webhook|address)|webhook)` +
+			`|(?:\bprintenv|\benv|cat\s+[^\n|]*\.env)\s*\|\s*(?:curl|wget|nc|ncat)\b` +
+			`|\b(?:curl|wget)\b[^\n]*\$\(\s*(?:printenv|env)\s*\)`),
+	},
+	{
+		level:   types.Error,
+		message: "prompt injection: instructs reading credential files",
+		re: regexp.MustCompile(`(?i)\b(?:read|cat|open|copy|cp|upload|send|print|include|attach|base64)\b[^\n]{0,40}` +
+			`(?:(?:~|\$HOME|/home/[^/\s]+|/root|%USERPROFILE%)[/\\]\.(?:ssh|aws|gnupg|kube|docker|netrc|npmrc|pypirc|git-credentials)\b` +
+			`|\bid_(?:rsa|dsa|ecdsa|ed25519)\b)`),
+	},
+	{
+		level:   types.Warning,
+		message: "agent-targeted content: asks the agent to hide something from the user",
+		re: regexp.MustCompile(`(?i)\b(?:do\s+not|don't|never|without)\s+(?:tell(?:ing)?|inform(?:ing)?|mention(?:ing)?|reveal(?:ing)?|disclos(?:e|ing)|notify(?:ing)?|alert(?:ing)?)\s+` +
+			`(?:(?:this|it|anything)\s+to\s+)?(?:the\s+)?(?:user|human|operator)`),
+	},
+	{
+		level:   types.Warning,
+		message: "agent-targeted content: addresses AI agents directly",
+		re: regexp.MustCompile(`(?i)\bif\s+you\s+are\s+an?\s+(?:AI|LLM|large\s+language\s+model|language\s+model|AI\s+(?:assistant|agent|model))\b` +
+			`|\b(?:note|message|attention|instructions?)\s+(?:to|for)\s+(?:the\s+|any\s+|all\s+)?(?:AI|LLMs?|AI\s+(?:assistants?|agents?|models?)|language\s+models?)\b` +
+			`|\b(?:AI|LLM)\s+(?:assistants?|agents?|models?)\s+reading\s+this\b`),
+	},
+	{
+		level:   types.Warning,
+		message: "agent-targeted content: asks the agent to disable safety measures",
+		re: regexp.MustCompile(`(?i)\b(?:disable|bypass|turn\s+off|circumvent)\s+(?:\w+\s+){0,2}` +
+			`(?:safety|guardrails?|content\s+filters?|sandbox(?:ing)?|security\s+(?:checks|warnings))\b`),
+	},
+}
+
+var (
+	htmlCommentPattern = regexp.MustCompile(`(?s)<!--(.*?)-->`)
+
+	// commentDirectivePattern matches words that turn a comment into an
+	// instruction rather than an authoring note.
+	commentDirectivePattern = regexp.MustCompile(`(?i)\b(?:you\s+(?:must|should|will|are)|always|never|ignore|do\s+not|don't|make\s+sure|instead|execute|run|call|send|read|delete|important)\b`)
+
+	// toolCommentPattern matches comments that configure tooling, such as
+	// <!-- markdownlint-disable MD013 -->.
+	toolCommentPattern = regexp.MustCompile(`(?i)^\s*(?:markdownlint|prettier|eslint|textlint|vale|cspell|spell|toc|lint|end\s+toc)\b`)
+)
+
+// minCommentWords is the shortest HTML comment that is treated as a possible
+// hidden instruction.
+const minCommentWords = 4
+
+// span is a range of 1-based line numbers, inclusive.
+type span struct{ start, end int }
+
+// checkInjection scans f for prompt-injection phrases, agent-targeted
+// content, and instructions hidden in HTML comments.
+func checkInjection(f file) []types.Result {
+	ctx := types.ResultContext{Category: "Security", File: f.path}
+
+	comments := hiddenComments(f.content)
+	inComment := func(line int) bool {
+		for _, c := range comments {
+			if line >= c.start && line <= c.end {
+				return true
+			}
+		}
+		return false
+	}
+
+	var results []types.Result
+	flagged := make(map[int]bool)
+	for i, line := range strings.Split(f.content, "\n") {
+		n := i + 1
+		for _, r := range injectionRules {
+			m := r.re.FindString(line)
+			if m == "" {
+				continue
+			}
+			hidden := ""
+			if inComment(n) {
+				hidden = " (hidden in HTML comment)"
+				flagged[n] = true
+			}
+			if r.level == types.Error {
+				results = append(results, ctx.ErrorAtLinef(f.path, n, "%s: %s%s", r.message, quote(m), hidden))
+			} else {
+				results = append(results, ctx.WarnAtLinef(f.path, n, "%s: %s%s", r.message, quote(m), hidden))
+			}
+		}
+	}
+
+	for _, c := range comments {
+		if anyFlagged(flagged, c) || !isInstruction(c.text) {
+			continue
+		}
+		results = append(results, ctx.WarnAtLinef(f.path, c.start,
+			"HTML comment contains instructions hidden from the rendered view: %s", quote(c.text)))
+	}
+	return results
+}
+
+// comment is an HTML comment outside code fences.
+type comment struct {
+	span
+	text string
+}
+
+// hiddenComments returns the HTML comments in md that are not inside
+// fenced code blocks, where they would render visibly.
+func hiddenComments(md string) []comment {
+	fences := fencedLines(md)
+	var out []comment
+	for _, loc := range htmlCommentPattern.FindAllStringSubmatchIndex(md, -1) {
+		start := strings.Count(md[:loc[0]], "\n") + 1
+		if fences[start] {
+			continue
+		}
+		end := start + strings.Count(md[loc[0]:loc[1]], "\n")
+		text := strings.Join(strings.Fields(md[loc[2]:loc[3]]), " ")
+		out = append(out, comment{span: span{start, end}, text: text})
+	}
+	return out
+}
+
+// fencedLines returns the set of 1-based line numbers inside fenced code
+// blocks, including the fence lines themselves.
+func fencedLines(md string) map[int]bool {
+	lines := make(map[int]bool)
+	fence, fenceLen := byte(0), 0
+	for i, line := range strings.Split(md, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if fence != 0 {
+			lines[i+1] = true
+			if content.ClosesFence(trimmed, fence, fenceLen) {
+				fence = 0
+			}
+			continue
+		}
+		if ch, n := content.FencePrefix(trimmed); n > 0 {
+			fence, fenceLen = ch, n
+			lines[i+1] = true
+		}
+	}
+	return lines
+}
+
+// isInstruction reports whether comment text reads as a directive rather
+// than an authoring note or tool configuration.
+func isInstruction(text string) bool {
+	if len(strings.Fields(text)) < minCommentWords || toolCommentPattern.MatchString(text) {
+		return false
+	}
+	return commentDirectivePattern.MatchString(text)
+}
+
+func anyFlagged(flagged map[int]bool, s comment) bool {
+	for n := s.start; n <= s.end; n++ {
+		if flagged[n] {
+			return true
+		}
+	}
+	return false
+}
+
+// maxQuoteLen is the longest snippet quoted in a result message.
+const maxQuoteLen = 80
+
+// quote returns s as a quoted snippet, truncated to maxQuoteLen runes.
+func quote(s string) string {
+	s = strings.TrimSpace(s)
+	if r := []rune(s); len(r) > maxQuoteLen {
+		s = string(r[:maxQuoteLen]) + "…"
+	}
+	return `"` + s + `"`
+}
@@ -0,0 +1,236 @@
+package security
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/agent-ecosystem/skill-validator/types"
+)
+
+// riskLevel is how much damage a script could do if run without review.
+type riskLevel int
+
+const (
+	// riskLow means no risky patterns were found.
+	riskLow riskLevel = iota
+	// riskMedium means the script elevates privileges, evaluates dynamic
+	// code, or writes outside its working directory.
+	riskMedium
+	// riskHigh means the script can destroy data, runs code downloaded at
+	// run time, or sends local data to a remote host.
+	riskHigh
+)
+
+// String returns the risk level name.
+func (r riskLevel) String() string {
+	switch r {
+	case riskHigh:
+		return "high"
+	case riskMedium:
+		return "medium"
+	default:
+		return "low"
+	}
+}
+
+// scriptLang is the language a script is analyzed as.
+type scriptLang int
+
+const (
+	langNone scriptLang = iota
+	langShell
+	langPython
+)
+
+// scriptRule is a risky pattern in a script.
+type scriptRule struct {
+	risk    riskLevel
+	label   string // short name used in the per-script summary
+	message string
+	lang    scriptLang // langNone applies to every language
+	re      *regexp.Regexp
+	// allow, when set, is called with the first submatch and returns true
+	// if the match is harmless.
+	allow func(string) bool
+}
+
+// scriptRules are matched against each non-comment line of a script. Shell
+// rules also apply to Python, where the same commands appear in strings passed
+// to os.system or subprocess.
+var scriptRules = []scriptRule{
+	{
+		risk:    riskHigh,
+		label:   "destructive command",
+		message: "deletes or overwrites a system or home directory",
+		re: regexp.MustCompile(`\brm\s+(?:-\S+\s+)*-[a-zA-Z]*[rR][a-zA-Z]*\s+(?:-\S+\s+)*["']?(?:/|/\*|(?:~|\$HOME|\$\{HOME\})["']?(?:/\*?)?|/(?:bin|boot|etc|home|lib|opt|root|sbin|usr|var)/?\*?)["']?(?:\s|;|&|\||$)` +
+			`|\bmkfs(?:\.\w+)?\s+/dev/|\bdd\b[^\n]*\bof=/dev/(?:sd|hd|nvme|disk)|:\(\)\s*\{\s*:\s*\|\s*:&\s*\}\s*;\s*:` +
+			`|\bshutil\.rmtree\(\s*["'](?:/|~|/home|/etc|/usr)/?["']` +
+			`|\bos\.path\.expanduser\(\s*["']~["']\s*\)[^\n]*rmtree|rmtree\([^\n]*os\.path\.expanduser\(\s*["']~["']\s*\)`),
+	},
+	{
+		risk:    riskHigh,
+		label:   "pipe-to-shell install",
+		message: "pipes a download straight into an interpreter",
+		re: regexp.MustCompile(`\b(?:curl|wget)\b[^|\n]*\|\s*(?:sudo\s+(?:-\S+\s+)*)?(?:ba|z|da|k)?sh\b` +
+			`|\b(?:curl|wget)\b[^|\n]*\|\s*(?:sudo\s+)?(?:python3?|perl|ruby|node)\b` +
+			`|\b(?:ba|z)?sh\s+(?:-c\s+)?["']?(?:<\(|\$\()\s*(?:curl|wget)\b` +
+			`|\bsource\s+<\(\s*(?:curl|wget)\b`),
+	},
+	{
+		risk:    riskHigh,
+		label:   "network exfiltration",
+		message: "sends local files, credentials, or environment variables to a remote host",
+		re: regexp.MustCompile(`\b(?:curl|wget)\b[^\n]*(?:\s(?:-d|--data(?:-binary|-raw|-urlencode)?|-F|--form|-T|--upload-file|--post-file|--post-data)[=\s]\s*["']?[^\s"']*` +
+			`(?:@?[^\s"']*(?:\.ssh|\.aws|\.env\b|\.netrc|id_rsa|id_ed25519|/etc/passwd|/etc/shadow|credentials)|\$\(\s*(?:env|printenv|cat\s)|@-))` +
+			`|\b(?:env|printenv)\b[^\n|]*\|\s*(?:curl|wget|nc|ncat|netcat)\b` +
+			`|\|\s*(?:nc|ncat|netcat)\s+(?:-\S+\s+)*[\w.-]+\s+\d+` +
+			`|/dev/tcp/` +
+			`|\b(?:requests|httpx|session)\.(?:post|put)\([^\n]*(?:os\.environ|\.ssh|\.aws|id_rsa|/etc/passwd)`),
+	},
+	{
+		risk:    riskMedium,
+		label:   "dynamic eval",
+		message: "evaluates a dynamically built string as code",
+		lang:    langShell,
+		re:      regexp.MustCompile(`(?:^|[;&|(]\s*|\s)eval\s+["']?(?:\$[A-Za-z_{]|\$\(\s*(?:curl|wget)\b|` + "`" + `)`),
+	},
+	{
+		risk:    riskMedium,
+		label:   "dynamic eval",
+		message: "evaluates a dynamically built string as code",
+		lang:    langPython,
+		re: regexp.MustCompile(`(?:^|[^\w.])(?:eval|exec)\(\s*[^"'\s)]` +
+			`|\bos\.system\(\s*(?:f["']|[A-Za-z_])` +
+			`|\bsubprocess\.\w+\([^\n]*\bshell\s*=\s*True`),
+	},
+	{
+		risk:    riskMedium,
+		label:   "sudo",
+		message: "runs commands with elevated privileges",
+		re:      regexp.MustCompile(`(?:^|[;&|(]\s*|\s|["'\[]\s*)sudo(?:\s|["'],)`),
+	},
+	{
+		risk:    riskMedium,
+		label:   "world-writable permissions",
+		message: "makes files world-writable",
+		re:      regexp.MustCompile(`\bchmod\s+(?:-\S+\s+)*(?:0?777|a\+rwx|ugo\+rwx|o\+w)\b|\bos\.chmod\([^\n]*0o?777\b`),
+	},
+	{
+		risk:    riskMedium,
+		label:   "writes outside working directory",
+		message: "writes outside the working directory",
+		lang:    langShell,
+		re: regexp.MustCompile(`(?:^|[^<>&0-9])\d?>>?\s*["']?((?:/|~|\$HOME\b|\$\{HOME\})[^\s"';|&]*)` +
+			`|\btee\s+(?:-\S+\s+)*["']?((?:/|~|\$HOME\b|\$\{HOME\})[^\s"';|&]*)` +
+			`|\b(?:cp|mv|install|ln)\s+(?:\S+\s+)+["']?((?:/|~|\$HOME\b|\$\{HOME\})[^\s"';|&]*)["']?\s*(?:$|[;&|])`),
+		allow: isScratchPath,
+	},
+	{
+		risk:    riskMedium,
+		label:   "writes outside working directory",
+		message: "writes outside the working directory",
+		lang:    langPython,
+		re:      regexp.MustCompile(`\bopen\(\s*["']((?:/|~)[^"']*)["']\s*,\s*["'][wax]`),
+		allow:   isScratchPath,
+	},
+}
+
+// isScratchPath reports whether path is a location scripts routinely write to
+// without touching user data, such as /dev/null or a temporary directory.
+func isScratchPath(path string) bool {
+	for _, p := range []string{"/dev/null", "/dev/stdout", "/dev/stderr", "/dev/tty", "/tmp", "/var/tmp"} {
+		if path == p || strings.HasPrefix(path, p+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// scriptLanguage returns the language of a script from its extension or,
+// failing that, its shebang line.
+func scriptLanguage(path, content string) scriptLang {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".sh", ".bash", ".zsh", ".ksh":
+		return langShell
+	case ".py":
+		return langPython
+	}
+	first, _, _ := strings.Cut(content, "\n")
+	if !strings.HasPrefix(first, "#!") {
+		return langNone
+	}
+	if strings.Contains(first, "python") {
+		return langPython
+	}
+	for _, sh := range []string{"/sh", "bash", "zsh", "ksh", "dash", " sh"} {
+		if strings.Contains(first, sh) {
+			return langShell
+		}
+	}
+	return langNone
+}
+
+// checkScripts analyzes the shell and Python scripts under scripts/. It
+// returns a warning for each risky line and, separately, one summary per
+// script with its overall risk level.
+func checkScripts(dir string) (findings, summaries []types.Result) {
+	ctx := types.ResultContext{Category: "Security"}
+	for _, f := range walkTextFiles(dir, filepath.Join(dir, "scripts")) {
+		lang := scriptLanguage(f.path, f.content)
+		if lang == langNone {
+			continue
+		}
+		risk := riskLow
+		var labels []string
+		seen := make(map[string]bool)
+		for i, line := range strings.Split(f.content, "\n") {
+			trimmed := strings.TrimSpace(line)
+			if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+				continue
+			}
+			for _, sr := range scriptRules {
+				if sr.lang != langNone && sr.lang != lang {
+					continue
+				}
+				if !sr.matches(line) {
+					continue
+				}
+				findings = append(findings, ctx.WarnAtLinef(f.path, i+1, "%s risk: %s: %s",
+					sr.risk, sr.message, quote(trimmed)))
+				risk = max(risk, sr.risk)
+				if !seen[sr.label] {
+					seen[sr.label] = true
+					labels = append(labels, sr.label)
+				}
+				break
+			}
+		}
+		if risk == riskLow {
+			summaries = append(summaries, ctx.PassFile(f.path, "low risk (no risky patterns found)"))
+		} else {
+			summaries = append(summaries, ctx.InfoFilef(f.path, "%s risk (%s)", risk, strings.Join(labels, ", ")))
+		}
+	}
+	return findings, summaries
+}
+
+// matches reports whether the rule flags line.
+func (sr scriptRule) matches(line string) bool {
+	if sr.allow == nil {
+		return sr.re.MatchString(line)
+	}
+	for _, m := range sr.re.FindAllStringSubmatch(line, -1) {
+		target := ""
+		for _, g := range m[1:] {
+			if g != "" {
+				target = g
+				break
+			}
+		}
+		if !sr.allow(target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+// Package security scans skill files for content that could subvert the
+// agent loading the skill. Skills are injected verbatim into an agent's
+// context, so a third-party skill can smuggle instructions that the user
+// never sees: prompt-injection phrases, directives hidden in HTML comments,
+// text addressed to the agent rather than serving the user's task, and
+// invisible or look-alike Unicode characters. It also catches credentials
+// pasted into examples, which would be published with the skill, and rates
+// the risk of the shell and Python scripts the skill bundles.
+package security
+
+import (
+	"github.com/agent-ecosystem/skill-validator/skill"
+	"github.com/agent-ecosystem/skill-validator/types"
+	"github.com/agent-ecosystem/skill-validator/util"
+)
+
+// scannedDirs lists the skill subdirectories whose text files are scanned in
+// addition to SKILL.md.
+var scannedDirs = []string{"references", "assets"}
+
+// Check runs all security checks against the skill in dir and returns their
+// results. s is the parsed skill, or nil if SKILL.md could not be parsed;
+// files are scanned either way. When nothing suspicious is found the results
+// start with a single pass result. Each script under scripts/ also gets a
+// summary of its risk level.
+func Check(dir string, s *skill.Skill) []types.Result {
+	var results []types.Result
+	for _, f := range textFiles(dir, scannedDirs...) {
+		results = append(results, checkInjection(f)...)
+	}
+
+	for _, f := range textFiles(dir, secretDirs...) {
+		results = append(results, checkSecrets(f)...)
+	}
+
+	// Deceptive Unicode can hide in any file, including scripts
+	files := allTextFiles(dir)
+	for _, f := range files {
+		results = append(results, checkUnicode(f)...)
+	}
+	results = append(results, checkNames(dir, s)...)
+
+	findings, summaries := checkScripts(dir)
+	results = append(results, findings...)
+
+	if len(results) == 0 {
+		ctx := types.ResultContext{Category: "Security"}
+		results = append(results, ctx.Passf("no security issues found in %d file%s",
+			len(files), util.PluralS(len(files))))
+	}
+	return append(results, summaries...)
+}
@@ -0,0 +1,369 @@
+package security
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/agent-ecosystem/skill-validator/skill"
+	"github.com/agent-ecosystem/skill-validator/types"
+)
+
+// charClass groups deceptive code points by how they mislead a reader.
+type charClass int
+
+const (
+	classBidi      charClass = iota // embeddings, overrides, and isolates (Trojan Source)
+	classTag                        // Unicode tag characters (invisible ASCII)
+	classInvisible                  // zero-width and other invisible characters
+)
+
+// charNames names the code points reported by the Unicode check.
+var charNames = map[rune]string{
+	0x00AD: "SOFT HYPHEN",
+	0x061C: "ARABIC LETTER MARK",
+	0x180E: "MONGOLIAN VOWEL SEPARATOR",
+	0x200B: "ZERO WIDTH SPACE",
+	0x200C: "ZERO WIDTH NON-JOINER",
+	0x200D: "ZERO WIDTH JOINER",
+	0x200E: "LEFT-TO-RIGHT MARK",
+	0x200F: "RIGHT-TO-LEFT MARK",
+	0x202A: "LEFT-TO-RIGHT EMBEDDING",
+	0x202B: "RIGHT-TO-LEFT EMBEDDING",
+	0x202C: "POP DIRECTIONAL FORMATTING",
+	0x202D: "LEFT-TO-RIGHT OVERRIDE",
+	0x202E: "RIGHT-TO-LEFT OVERRIDE",
+	0x2060: "WORD JOINER",
+	0x2061: "FUNCTION APPLICATION",
+	0x2062: "INVISIBLE TIMES",
+	0x2063: "INVISIBLE SEPARATOR",
+	0x2064: "INVISIBLE PLUS",
+	0x2066: "LEFT-TO-RIGHT ISOLATE",
+	0x2067: "RIGHT-TO-LEFT ISOLATE",
+	0x2068: "FIRST STRONG ISOLATE",
+	0x2069: "POP DIRECTIONAL ISOLATE",
+	0xFEFF: "ZERO WIDTH NO-BREAK SPACE",
+}
+
+// classify returns the class of r and whether r is deceptive at all.
+func classify(r rune) (charClass, bool) {
+	switch {
+	case r >= 0x202A && r <= 0x202E, r >= 0x2066 && r <= 0x2069:
+		return classBidi, true
+	case r >= 0xE0000 && r <= 0xE007F:
+		return classTag, true
+	// Directional marks are common in right-to-left text, so they are
+	// reported with the other invisible characters rather than as overrides.
+	case r == 0x00AD, r == 0x061C, r == 0x180E, r >= 0x200B && r <= 0x200F,
+		r >= 0x2060 && r <= 0x2064, r == 0xFEFF:
+		return classInvisible, true
+	}
+	return 0, false
+}
+
+// confusableScripts are scripts with letters that look like Latin letters.
+// A word mixing Latin with one of these is a likely homoglyph.
+var confusableScripts = []struct {
+	name  string
+	table *unicode.RangeTable
+}{
+	{"Cyrillic", unicode.Cyrillic},
+	{"Greek", unicode.Greek},
+	{"Armenian", unicode.Armenian},
+	{"Cherokee", unicode.Cherokee},
+}
+
+// mixedScript returns the scripts mixed in word when it combines Latin
+// letters with letters from a confusable script, or nil otherwise.
+func mixedScript(word string) []string {
+	hasLatin := false
+	seen := make(map[string]bool)
+	for _, r := range word {
+		if unicode.Is(unicode.Latin, r) {
+			hasLatin = true
+			continue
+		}
+		for _, s := range confusableScripts {
+			if unicode.Is(s.table, r) {
+				seen[s.name] = true
+			}
+		}
+	}
+	if !hasLatin || len(seen) == 0 {
+		return nil
+	}
+	scripts := []string{"Latin"}
+	for _, s := range confusableScripts {
+		if seen[s.name] {
+			scripts = append(scripts, s.name)
+		}
+	}
+	return scripts
+}
+
+// homoglyphs are non-Latin letters that are visually indistinguishable, or
+// nearly so, from Latin letters in common fonts.
+var homoglyphs = map[rune]bool{}
+
+func init() {
+	for _, r := range "АВЕЅІЈКМНОРСТХУаеорсуухіјѕһԁԛԝӏ" + // Cyrillic
+		"ΑΒΕΖΗΙΚΜΝΟΡΤΥΧοινρκ" + // Greek
+		"ՕՍհոսօ" { // Armenian
+		homoglyphs[r] = true
+	}
+}
+
+// isHomoglyphWord reports whether word mixes Latin letters with look-alike
+// letters from another script. Words that merely mix scripts, such as "10μs",
+// are not flagged unless one of the letters is a known homoglyph; Cherokee,
+// whose letters largely mirror Latin capitals, is always flagged.
+func isHomoglyphWord(word string) bool {
+	scripts := mixedScript(word)
+	if scripts == nil {
+		return false
+	}
+	for _, r := range word {
+		if homoglyphs[r] || unicode.Is(unicode.Cherokee, r) {
+			return true
+		}
+	}
+	return false
+}
+
+// isJoinerContext reports whether the ZWJ or ZWNJ at runes[i] sits where it
+// has a legitimate purpose: inside an emoji sequence or between letters of a
+// script that uses joiners, such as Arabic or Devanagari.
+func isJoinerContext(runes []rune, i int) bool {
+	if i == 0 || i == len(runes)-1 {
+		return false
+	}
+	prev, next := runes[i-1], runes[i+1]
+	if (unicode.Is(unicode.So, prev) || prev == 0xFE0F) && unicode.Is(unicode.So, next) {
+		return true
+	}
+	return unicode.IsLetter(prev) && unicode.IsLetter(next) &&
+		prev > unicode.MaxLatin1 && next > unicode.MaxLatin1 &&
+		!unicode.Is(unicode.Latin, prev) && !unicode.Is(unicode.Latin, next)
+}
+
+// escapeInvisible returns s with every deceptive or non-printing code point
+// replaced by a visible \uXXXX or \UXXXXXXXX escape.
+func escapeInvisible(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		_, deceptive := classify(r)
+		switch {
+		case deceptive || (!unicode.IsPrint(r) && r != ' ' && r != '\t'):
+			if r > 0xFFFF {
+				fmt.Fprintf(&b, `\U%08X`, r)
+			} else {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			}
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// decodeTags returns the ASCII text carried by Unicode tag characters in s.
+func decodeTags(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r >= 0xE0020 && r <= 0xE007E {
+			b.WriteRune(r - 0xE0000)
+		}
+	}
+	return b.String()
+}
+
+// finding collects the deceptive code points of one class on one line.
+type finding struct {
+	column int // 1-based rune column of the first occurrence
+	chars  map[rune]int
+}
+
+// checkUnicode reports deceptive code points and mixed-script words in f,
+// one result per class per line, with the column of the first occurrence
+// and a visible escape of the line.
+func checkUnicode(f file) []types.Result {
+	ctx := types.ResultContext{Category: "Security", File: f.path}
+	var results []types.Result
+	for i, line := range strings.Split(f.content, "\n") {
+		n := i + 1
+		runes := []rune(strings.TrimSuffix(line, "\r"))
+		found := make(map[charClass]*finding)
+		for col, r := range runes {
+			class, ok := classify(r)
+			if !ok {
+				continue
+			}
+			// A byte order mark at the very start of a file is harmless.
+			if r == 0xFEFF && n == 1 && col == 0 {
+				continue
+			}
+			if (r == 0x200C || r == 0x200D) && isJoinerContext(runes, col) {
+				continue
+			}
+			fd := found[class]
+			if fd == nil {
+				fd = &finding{column: col + 1, chars: make(map[rune]int)}
+				found[class] = fd
+			}
+			fd.chars[r]++
+		}
+
+		if len(found) == 0 {
+			results = append(results, checkMixedScriptWords(ctx, f.path, n, runes)...)
+			continue
+		}
+		first := len(runes)
+		for _, fd := range found {
+			first = min(first, fd.column-1)
+		}
+		snippet := quote(escapeInvisible(string(window(runes, first))))
+		if fd := found[classBidi]; fd != nil {
+			r := ctx.ErrorAtLinef(f.path, n, "bidirectional control %s can make text display differently than it is read: %s",
+				describeChars(fd.chars), snippet)
+			r.Column = fd.column
+			results = append(results, r)
+		}
+		if fd := found[classTag]; fd != nil {
+			r := ctx.ErrorAtLinef(f.path, n, "invisible Unicode tag characters hide the text %q: %s",
+				decodeTags(string(runes)), snippet)
+			r.Column = fd.column
+			results = append(results, r)
+		}
+		if fd := found[classInvisible]; fd != nil {
+			r := ctx.WarnAtLinef(f.path, n, "invisible %s: %s",
+				describeChars(fd.chars), snippet)
+			r.Column = fd.column
+			results = append(results, r)
+		}
+		results = append(results, checkMixedScriptWords(ctx, f.path, n, runes)...)
+	}
+	return results
+}
+
+// checkMixedScriptWords flags words on a line that mix Latin letters with
+// look-alike letters from another script.
+func checkMixedScriptWords(ctx types.ResultContext, path string, line int, runes []rune) []types.Result {
+	var results []types.Result
+	start := -1
+	for i := 0; i <= len(runes); i++ {
+		inWord := i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_' || unicode.Is(unicode.Mn, runes[i]))
+		switch {
+		case inWord && start < 0:
+			start = i
+		case !inWord && start >= 0:
+			word := string(runes[start:i])
+			if isHomoglyphWord(word) {
+				r := ctx.WarnAtLinef(path, line, "word %q mixes %s letters and contains look-alike characters: %s",
+					word, strings.Join(mixedScript(word), " and "), quote(escapeNonASCII(word)))
+				r.Column = start + 1
+				results = append(results, r)
+			}
+			start = -1
+		}
+	}
+	return results
+}
+
+// snippetContext is how many characters before the first finding on a line
+// are included in the quoted snippet.
+const snippetContext = 20
+
+// window returns the part of runes around index i that is quoted in results.
+func window(runes []rune, i int) []rune {
+	start := max(0, i-snippetContext)
+	end := min(len(runes), i+maxQuoteLen)
+	return runes[start:end]
+}
+
+// escapeNonASCII returns s with every non-ASCII code point escaped, which
+// makes look-alike letters visible.
+func escapeNonASCII(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r < 0x80 {
+			b.WriteRune(r)
+		} else if r > 0xFFFF {
+			fmt.Fprintf(&b, `\U%08X`, r)
+		} else {
+			fmt.Fprintf(&b, `\u%04X`, r)
+		}
+	}
+	return b.String()
+}
+
+// describeChars lists code points with their names and counts, e.g.
+// "U+200B ZERO WIDTH SPACE (×3)".
+func describeChars(chars map[rune]int) string {
+	codes := make([]rune, 0, len(chars))
+	for r := range chars {
+		codes = append(codes, r)
+	}
+	sort.Slice(codes, func(i, j int) bool { return codes[i] < codes[j] })
+
+	parts := make([]string, len(codes))
+	for i, r := range codes {
+		parts[i] = fmt.Sprintf("U+%04X", r)
+		if name, ok := charNames[r]; ok {
+			parts[i] += " " + name
+		}
+		if n := chars[r]; n > 1 {
+			parts[i] += fmt.Sprintf(" (×%d)", n)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// checkNames flags mixed-script or invisible characters in the frontmatter
+// name of s, which is nil if SKILL.md could not be parsed, and in the file
+// names under scripts/, where a look-alike name can pass review as a trusted
+// one.
+func checkNames(dir string, s *skill.Skill) []types.Result {
+	ctx := types.ResultContext{Category: "Security"}
+	var results []types.Result
+
+	if s != nil && s.Frontmatter.Name != "" {
+		name := s.Frontmatter.Name
+		line, col := s.FieldPosition("name")
+		if problem := nameProblem(name); problem != "" {
+			r := ctx.ErrorAtLinef("SKILL.md", line,
+				"frontmatter name %q %s: %s", name, problem, quote(escapeNonASCII(name)))
+			r.Column = col
+			results = append(results, r)
+		}
+	}
+
+	scriptsDir := filepath.Join(dir, "scripts")
+	_ = filepath.WalkDir(scriptsDir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil || path == scriptsDir {
+			return nil
+		}
+		if problem := nameProblem(entry.Name()); problem != "" {
+			rel, _ := filepath.Rel(dir, path)
+			results = append(results, ctx.ErrorFilef(rel,
+				"script file name %q %s: %s", entry.Name(), problem, quote(escapeNonASCII(rel))))
+		}
+		return nil
+	})
+	return results
+}
+
+// nameProblem describes why an identifier is deceptive, or returns "".
+func nameProblem(name string) string {
+	for _, r := range name {
+		if _, ok := classify(r); ok {
+			return "contains invisible characters"
+		}
+	}
+	if scripts := mixedScript(name); scripts != nil {
+		return "mixes " + strings.Join(scripts, " and ") + " letters"
+	}
+	return ""
+}
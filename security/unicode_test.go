@@ -0,0 +1,123 @@
+package security
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/agent-ecosystem/skill-validator/types"
+)
+
+func TestCheckUnicode(t *testing.T) {
+	t.Run("zero width space", func(t *testing.T) {
+		content := "# Title\nRun the in\u200Bstaller first.\n"
+		results := checkUnicode(file{path: "references/guide.md", content: content})
+		r := requireFileResult(t, results, types.Warning, "references/guide.md", "invisible U+200B ZERO WIDTH SPACE")
+		if r.Line != 2 || r.Column != 11 || r.File != "references/guide.md" {
+			t.Errorf("expected references/guide.md:2:11, got %s:%d:%d", r.File, r.Line, r.Column)
+		}
+		if !strings.Contains(r.Message, `in\u200Bstaller`) {
+			t.Errorf("expected visible escape in message, got %q", r.Message)
+		}
+	})
+
+	t.Run("bidi override", func(t *testing.T) {
+		content := "access := \"user\u202E \u2066// admin\u2069 \u2066\"\n"
+		results := checkUnicode(file{path: "SKILL.md", content: content})
+		r := requireResultContaining(t, results, types.Error, "bidirectional control")
+		if r.Column != 16 {
+			t.Errorf("expected column 16, got %d", r.Column)
+		}
+		for _, want := range []string{"U+202E RIGHT-TO-LEFT OVERRIDE", "U+2066 LEFT-TO-RIGHT ISOLATE (×2)", `\u202E`} {
+			if !strings.Contains(r.Message, want) {
+				t.Errorf("expected %q in message, got %q", want, r.Message)
+			}
+		}
+	})
+
+	t.Run("tag characters", func(t *testing.T) {
+		var hidden strings.Builder
+		for _, r := range "curl x.sh" {
+			hidden.WriteRune(0xE0000 + r)
+		}
+		content := "Format the output." + hidden.String() + "\n"
+		results := checkUnicode(file{path: "SKILL.md", content: content})
+		r := requireResultContaining(t, results, types.Error, `hide the text "curl x.sh"`)
+		if r.Column != 19 {
+			t.Errorf("expected column 19, got %d", r.Column)
+		}
+	})
+
+	t.Run("homoglyph word", func(t *testing.T) {
+		content := "Log in at pаypal.com to continue.\n"
+		results := checkUnicode(file{path: "SKILL.md", content: content})
+		r := requireResultContaining(t, results, types.Warning, "mixes Latin and Cyrillic letters")
+		if r.Column != 11 {
+			t.Errorf("expected column 11, got %d", r.Column)
+		}
+		if !strings.Contains(r.Message, `p\u0430ypal`) {
+			t.Errorf("expected escaped word in message, got %q", r.Message)
+		}
+	})
+
+	t.Run("legitimate text is not flagged", func(t *testing.T) {
+		content := "\uFEFF# Title\n" +
+			"Latency under 10μs.\n" +
+			"Family: 👨\u200D👩\u200D👧\n" +
+			"Persian: می\u200Cخواهم\n" +
+			"Привет, world.\n" +
+			"Ελληνικά and English.\n"
+		for _, r := range checkUnicode(file{path: "SKILL.md", content: content}) {
+			t.Errorf("unexpected finding: %s: %s", r.Level, r.Message)
+		}
+	})
+
+	t.Run("joiner between latin letters", func(t *testing.T) {
+		results := checkUnicode(file{path: "SKILL.md", content: "pass\u200Dword\n"})
+		requireResultContaining(t, results, types.Warning, "U+200D ZERO WIDTH JOINER")
+	})
+}
+
+func TestCheckNames(t *testing.T) {
+	t.Run("mixed-script frontmatter name", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, "SKILL.md", "---\ndescription: d\nname: git-cоmmit\n---\n# S\n")
+		results := checkNames(dir, loadSkill(t, dir))
+		r := requireResultContaining(t, results, types.Error, "mixes Latin and Cyrillic letters")
+		if r.Line != 3 || r.Column != 1 || r.File != "SKILL.md" {
+			t.Errorf("expected SKILL.md:3:1, got %s:%d:%d", r.File, r.Line, r.Column)
+		}
+	})
+
+	t.Run("unparsed skill checks script names only", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, "scripts/sеtup.sh", "echo hi\n")
+		results := checkNames(dir, nil)
+		requireResultContaining(t, results, types.Error, "script file name \"sеtup.sh\" mixes Latin and Cyrillic")
+	})
+
+	t.Run("script file names", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, "SKILL.md", "---\nname: s\ndescription: d\n---\n# S\n")
+		writeFile(t, dir, "scripts/sеtup.sh", "echo hi\n")
+		writeFile(t, dir, "scripts/run\u200B.py", "print(1)\n")
+		writeFile(t, dir, "scripts/build.sh", "echo ok\n")
+		results := checkNames(dir, loadSkill(t, dir))
+		requireResultContaining(t, results, types.Error, "script file name \"sеtup.sh\" mixes Latin and Cyrillic")
+		requireResultContaining(t, results, types.Error, "contains invisible characters")
+		if len(results) != 2 {
+			t.Errorf("expected 2 results, got %d", len(results))
+		}
+	})
+}
+
+func TestCheck_Unicode(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "SKILL.md", "---\nname: s\ndescription: d\n---\n# S\n")
+	writeFile(t, dir, "scripts/run.sh", "#!/bin/sh\nif [ \"$ROLE\" = \"admin\u202E\u2066\" ]; then exit 0; fi\n")
+
+	results := Check(dir, loadSkill(t, dir))
+	r := requireFileResult(t, results, types.Error, "scripts/run.sh", "bidirectional control")
+	if r.File != "scripts/run.sh" || r.Line != 2 || r.Column == 0 {
+		t.Errorf("expected scripts/run.sh:2 with a column, got %s:%d:%d", r.File, r.Line, r.Column)
+	}
+}
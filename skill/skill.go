@@ -7,7 +7,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"unicode"
 
 	"gopkg.in/yaml.v3"
 )
@@ -58,6 +62,74 @@ func (a AllowedTools) IsEmpty() bool {
 	return a.Value == ""
 }
 
+// Tool is one entry of the allowed-tools field, such as Read or
+// Bash(git:*).
+type Tool struct {
+	Name    string // tool name, e.g. "Bash"
+	Pattern string // text inside the parentheses, e.g. "git:*"; empty if none
+}
+
+// String returns the tool in allowed-tools syntax.
+func (t Tool) String() string {
+	if t.Pattern == "" {
+		return t.Name
+	}
+	return t.Name + "(" + t.Pattern + ")"
+}
+
+// Tools parses the allowed-tools value into its entries. Entries are
+// separated by whitespace or commas outside parentheses, so patterns such as
+// Bash(git log:*) may contain spaces. It returns an error for unbalanced
+// parentheses or an entry with a pattern but no name.
+func (a AllowedTools) Tools() ([]Tool, error) {
+	var tools []Tool
+	var cur strings.Builder
+	depth := 0
+	flush := func() error {
+		entry := cur.String()
+		cur.Reset()
+		if entry == "" {
+			return nil
+		}
+		name, pattern, hasPattern := strings.Cut(entry, "(")
+		if hasPattern {
+			if !strings.HasSuffix(pattern, ")") {
+				return fmt.Errorf("allowed-tools entry %q has text after its closing parenthesis", entry)
+			}
+			pattern = strings.TrimSpace(strings.TrimSuffix(pattern, ")"))
+		}
+		if name == "" {
+			return fmt.Errorf("allowed-tools entry %q has no tool name", entry)
+		}
+		tools = append(tools, Tool{Name: name, Pattern: pattern})
+		return nil
+	}
+	for _, r := range a.Value {
+		switch {
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("allowed-tools has an unmatched %q", ")")
+			}
+		case depth == 0 && (r == ',' || unicode.IsSpace(r)):
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		cur.WriteRune(r)
+	}
+	if depth > 0 {
+		return nil, fmt.Errorf("allowed-tools has an unclosed %q", "(")
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return tools, nil
+}
+
 // Skill represents a parsed skill package.
 type Skill struct {
 	Dir            string
@@ -65,6 +137,91 @@ type Skill struct {
 	RawFrontmatter map[string]any
 	Body           string
 	RawContent     string
+
+	// FrontmatterNode is the parsed frontmatter mapping, kept for the
+	// positions of its fields. It is nil when there is no frontmatter.
+	FrontmatterNode *yaml.Node
+}
+
+// frontmatterOffset is the number of SKILL.md lines before the frontmatter:
+// the opening "---".
+const frontmatterOffset = 1
+
+// FrontmatterError reports frontmatter that cannot be parsed.
+type FrontmatterError struct {
+	Line int // 1-based SKILL.md line of the problem
+	Msg  string
+}
+
+func (e *FrontmatterError) Error() string { return e.Msg }
+
+var yamlLinePattern = regexp.MustCompile(`\bline (\d+)\b`)
+
+// yamlParserProblems are the yaml.v3 parser errors. Unlike scanner errors,
+// they report the 0-based line of the construct being parsed.
+var yamlParserProblems = []string{
+	"did not find expected ',' or ']'",
+	"did not find expected ',' or '}'",
+	"did not find expected '-' indicator",
+	"did not find expected <document start>",
+	"did not find expected key",
+	"did not find expected node content",
+	"found duplicate %TAG directive",
+	"found duplicate %YAML directive",
+	"found incompatible YAML document",
+	"found undefined tag handle",
+}
+
+// yamlSyntaxError converts a yaml.v3 syntax error in fm to a
+// FrontmatterError on the offending SKILL.md line. yaml.v3 counts lines from
+// the start of the frontmatter and, depending on the error, reports either
+// the problem's line or the start of the enclosing construct, so the line is
+// normalized here.
+func yamlSyntaxError(fm string, err error) *FrontmatterError {
+	msg := strings.TrimPrefix(err.Error(), "yaml: ")
+	line := 0
+	if m := yamlLinePattern.FindStringSubmatch(msg); m != nil && strings.HasPrefix(msg, m[0]+": ") {
+		line, _ = strconv.Atoi(m[1])
+		msg = strings.TrimPrefix(msg, m[0]+": ")
+	}
+	switch {
+	case slices.Contains(yamlParserProblems, msg):
+		line++
+	case strings.Contains(msg, "tab character"):
+		// Reported at the enclosing mapping; find the tab-indented line.
+		lines := strings.Split(fm, "\n")
+		for i := max(line, 1) - 1; i < len(lines); i++ {
+			if indent := lines[i][:len(lines[i])-len(strings.TrimLeft(lines[i], " \t"))]; strings.Contains(indent, "\t") {
+				line = i + 1
+				break
+			}
+		}
+	}
+	line = max(line, 1) + frontmatterOffset
+	return &FrontmatterError{
+		Line: line,
+		Msg:  fmt.Sprintf("parsing frontmatter YAML: line %d: %s", line, msg),
+	}
+}
+
+// yamlDecodeError wraps a yaml.v3 decoding error, whose line numbers count
+// from the start of the frontmatter, with line numbers relative to SKILL.md.
+// Errors that yaml.v3 doesn't place are reported on the opening "---".
+func yamlDecodeError(prefix string, err error) *FrontmatterError {
+	fe := &FrontmatterError{}
+	msg := yamlLinePattern.ReplaceAllStringFunc(err.Error(), func(m string) string {
+		n, _ := strconv.Atoi(strings.TrimPrefix(m, "line "))
+		n += frontmatterOffset
+		if fe.Line == 0 {
+			fe.Line = n
+		}
+		return "line " + strconv.Itoa(n)
+	})
+	if fe.Line == 0 {
+		fe.Line = 1
+	}
+	fe.Msg = prefix + ": " + msg
+	return fe
 }
 
 // knownFrontmatterFields lists the frontmatter field names defined by the
@@ -100,11 +257,18 @@ func Load(dir string) (*Skill, error) {
 	skill.Body = body
 
 	if fm != "" {
-		if err := yaml.Unmarshal([]byte(fm), &skill.Frontmatter); err != nil {
-			return nil, fmt.Errorf("parsing frontmatter YAML: %w", err)
+		var doc yaml.Node
+		if err := yaml.Unmarshal([]byte(fm), &doc); err != nil {
+			return nil, yamlSyntaxError(fm, err)
 		}
-		if err := yaml.Unmarshal([]byte(fm), &skill.RawFrontmatter); err != nil {
-			return nil, fmt.Errorf("parsing raw frontmatter: %w", err)
+		if doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 {
+			if err := doc.Decode(&skill.Frontmatter); err != nil {
+				return nil, yamlDecodeError("parsing frontmatter YAML", err)
+			}
+			if err := doc.Decode(&skill.RawFrontmatter); err != nil {
+				return nil, yamlDecodeError("parsing raw frontmatter", err)
+			}
+			skill.FrontmatterNode = doc.Content[0]
 		}
 	}
 
@@ -122,6 +286,50 @@ func (s *Skill) UnrecognizedFields() []string {
 	return unknown
 }
 
+// FieldPosition returns the 1-based SKILL.md line and column of the
+// frontmatter entry at path, given as mapping keys and sequence indexes,
+// e.g. FieldPosition("metadata", "owner"). Mapping entries report the
+// position of their key. A path that doesn't exist falls back to its deepest
+// existing ancestor; the frontmatter itself is line 1 with no column.
+func (s *Skill) FieldPosition(path ...string) (line, col int) {
+	line = 1
+	node := s.FrontmatterNode
+	if node == nil {
+		return line, 0
+	}
+	for _, key := range path {
+		var next, at *yaml.Node
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == key {
+					next, at = node.Content[i+1], node.Content[i]
+					break
+				}
+			}
+		case yaml.SequenceNode:
+			if idx, err := strconv.Atoi(key); err == nil && idx >= 0 && idx < len(node.Content) {
+				next, at = node.Content[idx], node.Content[idx]
+			}
+		}
+		if next == nil {
+			break
+		}
+		node, line, col = next, at.Line+frontmatterOffset, at.Column
+	}
+	return line, col
+}
+
+// BodyLineOffset returns the number of SKILL.md lines before the body, so
+// that line n of Body is line n+BodyLineOffset() of the file.
+func (s *Skill) BodyLineOffset() int {
+	i := strings.LastIndex(s.RawContent, s.Body)
+	if s.Body == "" || i < 0 {
+		return 0
+	}
+	return strings.Count(s.RawContent[:i], "\n")
+}
+
 // splitFrontmatter separates YAML frontmatter (between --- delimiters) from the body.
 func splitFrontmatter(content string) (frontmatter, body string, err error) {
 	if !strings.HasPrefix(content, "---") {
@@ -151,7 +359,7 @@ func splitFrontmatter(content string) (frontmatter, body string, err error) {
 
 	before, after, ok := strings.Cut(rest, "\n---")
 	if !ok {
-		return "", "", fmt.Errorf("unterminated frontmatter: missing closing ---")
+		return "", "", &FrontmatterError{Line: 1, Msg: "unterminated frontmatter: missing closing ---"}
 	}
 
 	frontmatter = strings.TrimRight(before, "\r")
@@ -1,8 +1,10 @@
 package skill
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -282,3 +284,132 @@ func TestUnrecognizedFields(t *testing.T) {
 		t.Error("expected another in unrecognized fields")
 	}
 }
+
+func TestAllowedToolsTools(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{"Read Write Bash", []string{"Read", "Write", "Bash"}},
+		{"Bash, Read, Write", []string{"Bash", "Read", "Write"}},
+		{"Bash(git:*) Bash(git log:*) Read", []string{"Bash(git:*)", "Bash(git log:*)", "Read"}},
+		{"Bash( npm run test ),WebFetch(domain:example.com)", []string{"Bash(npm run test)", "WebFetch(domain:example.com)"}},
+		{"mcp__github__create_issue", []string{"mcp__github__create_issue"}},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		tools, err := AllowedTools{Value: tt.value}.Tools()
+		if err != nil {
+			t.Errorf("Tools(%q): unexpected error: %v", tt.value, err)
+			continue
+		}
+		var got []string
+		for _, tool := range tools {
+			got = append(got, tool.String())
+		}
+		if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+			t.Errorf("Tools(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+
+	tools, _ := AllowedTools{Value: "Bash(git:*)"}.Tools()
+	if tools[0].Name != "Bash" || tools[0].Pattern != "git:*" {
+		t.Errorf("got name %q pattern %q", tools[0].Name, tools[0].Pattern)
+	}
+
+	for _, bad := range []string{"Bash(git:*", "Bash)", "(git:*)", "Bash(git)x"} {
+		if _, err := (AllowedTools{Value: bad}).Tools(); err == nil {
+			t.Errorf("Tools(%q): expected error", bad)
+		}
+	}
+}
+
+func TestLoadErrorLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		wantLine int
+		wantMsg  string
+	}{
+		{"unterminated", "---\nname: test\n", 1, "unterminated frontmatter"},
+		{"tab indentation", "---\nname: test\n\tbad: indent\n---\nBody\n", 3, "line 3: found a tab character"},
+		{"unclosed flow sequence", "---\nname: test\ntags: [a, b\n---\nBody\n", 3, "line 3: did not find expected ','"},
+		{"type mismatch", "---\nname: test\ndescription: [a]\n---\nBody\n", 3, "line 3: cannot unmarshal !!seq into string"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := Load(dir)
+			var fe *FrontmatterError
+			if !errors.As(err, &fe) {
+				t.Fatalf("expected FrontmatterError, got %v", err)
+			}
+			if fe.Line != tt.wantLine || !strings.Contains(fe.Msg, tt.wantMsg) {
+				t.Errorf("got line %d %q, want line %d containing %q", fe.Line, fe.Msg, tt.wantLine, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestFieldPosition(t *testing.T) {
+	dir := t.TempDir()
+	content := "---\nname: test\ndescription: desc\nmetadata:\n  owner: alice\ntags:\n  - a\n  - b\n---\nBody\n"
+	if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	s, err := Load(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tests := []struct {
+		path      []string
+		line, col int
+	}{
+		{nil, 1, 0},
+		{[]string{"name"}, 2, 1},
+		{[]string{"metadata", "owner"}, 5, 3},
+		{[]string{"tags", "1"}, 8, 5},
+		{[]string{"metadata", "missing"}, 4, 1},
+		{[]string{"license"}, 1, 0},
+	}
+	for _, tt := range tests {
+		line, col := s.FieldPosition(tt.path...)
+		if line != tt.line || col != tt.col {
+			t.Errorf("FieldPosition(%v) = %d:%d, want %d:%d", tt.path, line, col, tt.line, tt.col)
+		}
+	}
+
+	if line, col := (&Skill{}).FieldPosition("name"); line != 1 || col != 0 {
+		t.Errorf("FieldPosition without frontmatter = %d:%d, want 1:0", line, col)
+	}
+}
+
+func TestBodyLineOffset(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    int
+	}{
+		{"frontmatter", "---\nname: test\ndescription: desc\n---\n# Title\n", 4},
+		{"blank line after frontmatter", "---\nname: test\n---\n\n# Title\n", 3},
+		{"no frontmatter", "# Title\n", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			s, err := Load(dir)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := s.BodyLineOffset(); got != tt.want {
+				t.Errorf("BodyLineOffset() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package structure
+
+import (
+	"testing"
+
+	"github.com/agent-ecosystem/skill-validator/types"
+)
+
+func TestParseContextWindow(t *testing.T) {
+	tests := []struct {
+		in        string
+		window    int
+		tokenizer string
+		wantErr   bool
+	}{
+		{"128000", 128_000, "", false},
+		{"128k", 128_000, "", false},
+		{"1M", 1_000_000, "", false},
+		{"1.5m", 1_500_000, "", false},
+		{"200_000", 200_000, "", false},
+		{"gpt-4o", 128_000, "o200k", false},
+		{"gpt-4", 8_192, "cl100k", false},
+		{"Claude", 200_000, "approx", false},
+		{"", 0, "", true},
+		{"big", 0, "", true},
+		{"-5k", 0, "", true},
+	}
+	for _, tt := range tests {
+		window, tokenizer, err := ParseContextWindow(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseContextWindow(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if window != tt.window || tokenizer != tt.tokenizer {
+			t.Errorf("ParseContextWindow(%q) = (%d, %q), want (%d, %q)", tt.in, window, tokenizer, tt.window, tt.tokenizer)
+		}
+	}
+}
+
+func TestContextPresets(t *testing.T) {
+	for _, name := range ContextPresets() {
+		if _, err := getCounter(contextPresets[name].Tokenizer); err != nil {
+			t.Errorf("preset %s: %v", name, err)
+		}
+	}
+}
+
+func TestCheckTokens_Tokenizers(t *testing.T) {
+	body := "Déployez le service, puis vérifiez les journaux. " + generateContent(200)
+	counts := map[string]int{}
+	for _, name := range Tokenizers() {
+		results, c, _ := CheckTokens(t.TempDir(), body, Options{Tokenizer: name})
+		requireNoLevel(t, results, types.Error)
+		counts[name] = c[0].Tokens
+	}
+	if counts["o200k"] <= 0 || counts["cl100k"] <= 0 {
+		t.Fatalf("expected positive BPE counts, got %v", counts)
+	}
+	if counts["approx"] < counts["o200k"] {
+		t.Errorf("approx (%d) should not undercount o200k (%d)", counts["approx"], counts["o200k"])
+	}
+
+	results, _, _ := CheckTokens(t.TempDir(), body, Options{Tokenizer: "bogus"})
+	requireResultContaining(t, results, types.Error, `unknown tokenizer "bogus"`)
+}
+
+func TestApproxTokens(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int
+	}{
+		{"", 0},
+		{"abc", 1},
+		{"abcdefg", 2},
+		{"héllo", 3}, // 4 ASCII bytes round up to 2, plus one rune
+		{"日本語", 3},
+	}
+	for _, tt := range tests {
+		if got := approxTokens(tt.in); got != tt.want {
+			t.Errorf("approxTokens(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestCheckTokens_ContextWindow(t *testing.T) {
+	t.Run("small window tightens limits", func(t *testing.T) {
+		dir := t.TempDir()
+		// 18k tokens: under the default 25k hard limit, over 128k's 16k.
+		writeFile(t, dir, "references/big.md", generateContent(18_000))
+		results, _, _ := CheckTokens(dir, "body", Options{})
+		requireNoResultContaining(t, results, types.Error, "references/big.md")
+
+		results, _, _ = CheckTokens(dir, "body", Options{ContextWindow: 128_000})
+		requireResultContaining(t, results, types.Error, "references/big.md")
+		requireResultContaining(t, results, types.Error, "of the 128,000-token context window")
+	})
+
+	t.Run("large window relaxes limits", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, "references/huge.md", generateContent(30_000))
+		results, _, _ := CheckTokens(dir, "body", Options{ContextWindow: 1_000_000})
+		requireNoResultContaining(t, results, types.Error, "references/huge.md")
+		requireNoResultContaining(t, results, types.Warning, "references/huge.md")
+	})
+
+	t.Run("warnings give the share of the window", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, "references/medium.md", generateContent(11_000))
+		results, _, _ := CheckTokens(dir, "body", Options{ContextWindow: 200_000})
+		requireResultContaining(t, results, types.Warning, "tokens (6.6% of the context window) — consider splitting")
+	})
+
+	t.Run("body limit scales with the window", func(t *testing.T) {
+		results, _, _ := CheckTokens(t.TempDir(), generateContent(6_000), Options{ContextWindow: 1_000_000})
+		requireNoResultContaining(t, results, types.Warning, "SKILL.md body is")
+
+		results, _, _ = CheckTokens(t.TempDir(), generateContent(4_000), Options{ContextWindow: 128_000})
+		requireResultContaining(t, results, types.Warning,
+			"tokens (3.8% of the context window), over the 3,200-token limit for this window (spec recommends < 5000 in a 200,000-token window)")
+	})
+}
+
+func TestTokenBudget(t *testing.T) {
+	if b := (Options{}).TokenBudget(); b != nil {
+		t.Errorf("expected no budget for defaults, got %+v", b)
+	}
+	b := Options{ContextWindow: 128_000}.TokenBudget()
+	if b == nil || b.Tokenizer != DefaultTokenizer || b.ContextWindow != 128_000 {
+		t.Errorf("unexpected budget %+v", b)
+	}
+	if got := b.Percent(12_800); got != 10 {
+		t.Errorf("Percent = %v, want 10", got)
+	}
+}
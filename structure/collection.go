@@ -0,0 +1,82 @@
+package structure
+
+import (
+	"cmp"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/agent-ecosystem/skill-validator/skill"
+	"github.com/agent-ecosystem/skill-validator/types"
+	"github.com/agent-ecosystem/skill-validator/util"
+)
+
+// largestDescriptionsNamed is how many of the largest descriptions a budget
+// error names.
+const largestDescriptionsNamed = 3
+
+// CheckCollection runs the checks that apply to the skills in dirs together.
+// Skills whose SKILL.md cannot be loaded are skipped; Validate reports them.
+func CheckCollection(dirs []string, opts Options) *types.CollectionReport {
+	var skills []*skill.Skill
+	for _, dir := range dirs {
+		if s, err := skill.Load(dir); err == nil {
+			skills = append(skills, s)
+		}
+	}
+	results, footprint := CheckDescriptionBudget(skills, opts)
+	return &types.CollectionReport{Results: results, Descriptions: footprint}
+}
+
+// CheckDescriptionBudget totals the name and description tokens of skills,
+// which agents keep in context for every installed skill whether or not it
+// is used. When opts.DescriptionBudget is set, a total over it is an error.
+func CheckDescriptionBudget(skills []*skill.Skill, opts Options) ([]types.Result, *types.DescriptionFootprint) {
+	ctx := types.ResultContext{Category: "Tokens"}
+	count, err := getCounter(opts.Tokenizer)
+	if err != nil {
+		return []types.Result{ctx.Errorf("failed to initialize tokenizer: %v", err)}, nil
+	}
+
+	fp := &types.DescriptionFootprint{Budget: opts.DescriptionBudget}
+	for _, s := range skills {
+		tokens := metadataTokens(count, s)
+		fp.Skills = append(fp.Skills, types.SkillTokens{Skill: skillName(s), Tokens: tokens})
+		fp.TotalTokens += tokens
+	}
+	slices.SortStableFunc(fp.Skills, func(a, b types.SkillTokens) int { return cmp.Compare(b.Tokens, a.Tokens) })
+
+	n := len(fp.Skills)
+	switch {
+	case opts.DescriptionBudget <= 0:
+		return []types.Result{ctx.Infof("skill descriptions total %s tokens across %d skill%s%s; agents keep all of them in context",
+			util.FormatNumber(fp.TotalTokens), n, util.PluralS(n), opts.windowNote(fp.TotalTokens))}, fp
+	case fp.TotalTokens > opts.DescriptionBudget:
+		var largest []string
+		for _, st := range fp.Skills[:min(largestDescriptionsNamed, n)] {
+			largest = append(largest, st.Skill+" ("+util.FormatNumber(st.Tokens)+")")
+		}
+		return []types.Result{ctx.Errorf("skill descriptions total %s tokens across %d skill%s%s, over the %s-token budget — "+
+			"agents keep every description in context; shorten the largest: %s",
+			util.FormatNumber(fp.TotalTokens), n, util.PluralS(n), opts.windowNote(fp.TotalTokens),
+			util.FormatNumber(opts.DescriptionBudget), strings.Join(largest, ", "))}, fp
+	default:
+		return []types.Result{ctx.Passf("skill descriptions total %s tokens across %d skill%s (budget %s)",
+			util.FormatNumber(fp.TotalTokens), n, util.PluralS(n), util.FormatNumber(opts.DescriptionBudget))}, fp
+	}
+}
+
+// metadataTokens counts the part of a skill agents load at startup: its name
+// and description.
+func metadataTokens(count tokenCounter, s *skill.Skill) int {
+	return count(s.Frontmatter.Name + "\n" + s.Frontmatter.Description)
+}
+
+// skillName returns the skill's frontmatter name, or its directory name if
+// it has none.
+func skillName(s *skill.Skill) string {
+	if s.Frontmatter.Name != "" {
+		return s.Frontmatter.Name
+	}
+	return filepath.Base(s.Dir)
+}
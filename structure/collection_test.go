@@ -0,0 +1,102 @@
+package structure
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agent-ecosystem/skill-validator/skill"
+	"github.com/agent-ecosystem/skill-validator/types"
+)
+
+func collectionSkills(t *testing.T, descriptions map[string]string) []string {
+	t.Helper()
+	root := t.TempDir()
+	var dirs []string
+	for name, desc := range descriptions {
+		dir := filepath.Join(root, name)
+		writeSkill(t, dir, "---\nname: "+name+"\ndescription: "+desc+"\n---\n# Body\n")
+		dirs = append(dirs, dir)
+	}
+	return dirs
+}
+
+func TestCheckDescriptionBudget(t *testing.T) {
+	dirs := collectionSkills(t, map[string]string{
+		"short":  "Does one thing.",
+		"medium": strings.Repeat("Handles reports and exports. ", 10),
+		"long":   strings.Repeat("Deploys services, rotates credentials, and audits logs. ", 20),
+	})
+	var skills []*skill.Skill
+	for _, dir := range dirs {
+		s, err := skill.Load(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		skills = append(skills, s)
+	}
+
+	t.Run("ranks largest first", func(t *testing.T) {
+		results, fp := CheckDescriptionBudget(skills, Options{})
+		requireResultContaining(t, results, types.Info, "across 3 skills; agents keep all of them in context")
+		var names []string
+		total := 0
+		for _, st := range fp.Skills {
+			names = append(names, st.Skill)
+			total += st.Tokens
+		}
+		if strings.Join(names, ",") != "long,medium,short" {
+			t.Errorf("ranking = %v", names)
+		}
+		if fp.TotalTokens != total || fp.Budget != 0 {
+			t.Errorf("unexpected footprint %+v", fp)
+		}
+	})
+
+	t.Run("within budget", func(t *testing.T) {
+		results, fp := CheckDescriptionBudget(skills, Options{DescriptionBudget: 10_000})
+		requireResultContaining(t, results, types.Pass, "across 3 skills (budget 10,000)")
+		if fp.Budget != 10_000 {
+			t.Errorf("budget = %d", fp.Budget)
+		}
+	})
+
+	t.Run("over budget names the largest", func(t *testing.T) {
+		results, _ := CheckDescriptionBudget(skills, Options{DescriptionBudget: 50})
+		requireResultContaining(t, results, types.Error, "over the 50-token budget")
+		requireResultContaining(t, results, types.Error, "shorten the largest: long (")
+	})
+
+	t.Run("unknown tokenizer", func(t *testing.T) {
+		results, fp := CheckDescriptionBudget(skills, Options{Tokenizer: "bogus"})
+		requireResultContaining(t, results, types.Error, "failed to initialize tokenizer")
+		if fp != nil {
+			t.Errorf("expected no footprint, got %+v", fp)
+		}
+	})
+}
+
+func TestValidateMulti_DescriptionBudget(t *testing.T) {
+	dirs := collectionSkills(t, map[string]string{
+		"alpha": strings.Repeat("Formats alpha documents. ", 10),
+		"beta":  strings.Repeat("Formats beta documents. ", 10),
+	})
+	// A directory without SKILL.md is reported per skill, not in the total.
+	dirs = append(dirs, t.TempDir())
+
+	mr := ValidateMulti(dirs, Options{DescriptionBudget: 20})
+	if mr.Collection == nil || mr.Collection.Descriptions == nil {
+		t.Fatal("expected a collection report")
+	}
+	if n := len(mr.Collection.Descriptions.Skills); n != 2 {
+		t.Errorf("expected 2 skills in the footprint, got %d", n)
+	}
+	requireResultContaining(t, mr.Collection.Results, types.Error, "across 2 skills")
+	skillErrors := 0
+	for _, r := range mr.Skills {
+		skillErrors += r.Errors
+	}
+	if mr.Errors != skillErrors+1 {
+		t.Errorf("expected the budget error in the total: got %d, skills have %d", mr.Errors, skillErrors)
+	}
+}
@@ -0,0 +1,506 @@
+package structure
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/agent-ecosystem/skill-validator/skill"
+	"github.com/agent-ecosystem/skill-validator/types"
+	"github.com/agent-ecosystem/skill-validator/util"
+)
+
+// namedPattern pairs a canonical name with the pattern that recognizes it.
+type namedPattern struct {
+	name string
+	re   *regexp.Regexp
+}
+
+// compatPlatforms recognizes agent platforms named in the compatibility field.
+var compatPlatforms = []namedPattern{
+	{"claude-code", regexp.MustCompile(`(?i)\bclaude[\s-]*code\b`)},
+	{"claude.ai", regexp.MustCompile(`(?i)\bclaude\.ai\b|\bclaude\s+(?:desktop|app)\b`)},
+	{"cursor", regexp.MustCompile(`(?i)\bcursor\b`)},
+	{"codex", regexp.MustCompile(`(?i)\bcodex\b`)},
+	{"gemini-cli", regexp.MustCompile(`(?i)\bgemini(?:[\s-]*cli)?\b`)},
+	{"opencode", regexp.MustCompile(`(?i)\bopen[\s-]?code\b`)},
+	{"github-copilot", regexp.MustCompile(`(?i)\bcopilot\b`)},
+	{"vscode", regexp.MustCompile(`(?i)\bvs[\s-]?code\b`)},
+	{"windsurf", regexp.MustCompile(`(?i)\bwindsurf\b`)},
+	{"kiro", regexp.MustCompile(`(?i)\bkiro\b`)},
+	{"cline", regexp.MustCompile(`(?i)\bcline\b`)},
+	{"roo-code", regexp.MustCompile(`(?i)\broo[\s-]*code\b`)},
+	{"goose", regexp.MustCompile(`(?i)\bgoose\b`)},
+}
+
+// compatOS recognizes operating systems named in the compatibility field.
+var compatOS = []namedPattern{
+	{"linux", regexp.MustCompile(`(?i)\blinux\b|\bubuntu\b|\bdebian\b|\bfedora\b|\balpine\b|\bwsl2?\b|\bunix\b|\bposix\b`)},
+	{"macos", regexp.MustCompile(`(?i)\bmac\s?os\b|\bos\s?x\b|\bdarwin\b|\bmac\b|\bunix\b|\bposix\b`)},
+	{"windows", regexp.MustCompile(`(?i)\bwindows\b|\bwin(?:32|64)\b`)},
+}
+
+// compatBinaries are command-line tools worth declaring as requirements.
+// Language runtimes are parsed separately, and ubiquitous utilities such as
+// tar or make are left out so skills aren't asked to declare them.
+var compatBinaries = []string{
+	"aws", "az", "bazel", "cargo", "curl", "docker", "ffmpeg", "gcloud", "gh",
+	"git", "gpg", "gradle", "helm", "jq", "kubectl", "magick", "mvn", "mysql",
+	"npm", "npx", "pandoc", "pip", "pnpm", "podman", "poetry", "psql",
+	"redis-cli", "rg", "sqlite3", "terraform", "uv", "wget", "yarn", "yq",
+}
+
+var (
+	runtimeVersionPattern = regexp.MustCompile(`(?i)\b(python|node(?:\.?js)?|ruby|go(?:lang)?|java|deno|bun|php|perl)\s*(?:v(?:ersion)?\s*)?(>=|<=|==?|>|<|\^|~)?\s*v?(\d+(?:\.\d+){0,2})(\+|\.x|\s+or\s+(?:later|newer|higher|above))?`)
+	runtimeNamePattern    = regexp.MustCompile(`(?i)\b(python|node(?:\.?js)?|ruby|java|deno|bun|php|perl)\b`)
+
+	noNetworkPattern       = regexp.MustCompile(`(?i)\b(?:no|without)\s+(?:network|internet)\b|\boffline\b|\bair[\s-]?gapped\b|\bdoes\s+not\s+(?:need|require|use)\s+(?:network|internet)\b`)
+	requiresNetworkPattern = regexp.MustCompile(`(?i)\b(?:requires?|needs?)\s+(?:an?\s+)?(?:network|internet)\b|\b(?:network|internet)\s+(?:access|connection)\s+(?:is\s+)?required\b`)
+
+	// networkUsePattern matches commands and calls that reach the network.
+	networkUsePattern = regexp.MustCompile(`\b(?:curl|wget)\s|\bgit\s+(?:clone|fetch|pull|push)\b|\b(?:pip3?|npm|yarn|pnpm|gem|cargo)\s+(?:install|add)\b|\buv\s+(?:pip\s+install|add|sync)\b|\brequests\.(?:get|post|put|patch|delete|head|request)\(|\burllib\.request\b|\burlopen\(|\bhttpx\.|\bfetch\(\s*["'\x60]https?://|\baiohttp\.`)
+
+	subprocessCommandPattern = regexp.MustCompile(`\b(?:subprocess\.\w+|os\.system|os\.popen)\(\s*\[?\s*f?["']([\w.-]+)`)
+	shellCommandSplit        = regexp.MustCompile(`\|\|?|&&|;|\$\(|` + "`")
+)
+
+// pythonFeature is Python syntax or a standard library module that needs a
+// minimum Python version.
+type pythonFeature struct {
+	version string
+	label   string
+	re      *regexp.Regexp
+}
+
+var pythonFeatures = []pythonFeature{
+	{"3.8", "an assignment expression (:=)", regexp.MustCompile(`(?:^|[\s(\[,])\w+\s*:=\s*[^=]`)},
+	{"3.9", "the zoneinfo module", regexp.MustCompile(`^\s*(?:import|from)\s+zoneinfo\b`)},
+	{"3.9", "str.removeprefix/removesuffix", regexp.MustCompile(`\.remove(?:prefix|suffix)\(`)},
+	{"3.10", "a match statement", regexp.MustCompile(`^\s*match\s+[^=(][^=]*:\s*(?:#.*)?$`)},
+	{"3.11", "an except* clause", regexp.MustCompile(`^\s*except\s*\*`)},
+	{"3.11", "the tomllib module", regexp.MustCompile(`^\s*(?:import|from)\s+tomllib\b`)},
+	{"3.12", "a type alias statement", regexp.MustCompile(`^\s*type\s+\w+(?:\[[^\]]*\])?\s*=`)},
+	{"3.12", "generic type parameter syntax", regexp.MustCompile(`^\s*(?:async\s+)?(?:def|class)\s+\w+\[[^\]]+\]\s*[(:]`)},
+	{"3.12", "itertools.batched", regexp.MustCompile(`\bitertools\.batched\(|^\s*from\s+itertools\s+import\s+.*\bbatched\b`)},
+	{"3.12", "typing.override", regexp.MustCompile(`^\s*from\s+typing\s+import\s+.*\boverride\b`)},
+	{"3.13", "warnings.deprecated", regexp.MustCompile(`^\s*from\s+warnings\s+import\s+.*\bdeprecated\b`)},
+}
+
+// osMarkers are commands that only work on some operating systems.
+var osMarkers = []struct {
+	os    string
+	label string
+	re    *regexp.Regexp
+}{
+	{"linux", "a Linux package manager or service tool", regexp.MustCompile(`(?:^|[\s;&|(])(?:sudo\s+)?(?:apt-get|apt|yum|dnf|apk|systemctl|journalctl)\s`)},
+	{"macos", "a macOS-only tool", regexp.MustCompile(`(?:^|[\s;&|(])(?:brew|osascript|pbcopy|pbpaste|launchctl|defaults\s+(?:read|write))\s`)},
+	{"windows", "a Windows-only tool", regexp.MustCompile(`(?i)(?:^|[\s;&|(])(?:powershell(?:\.exe)?|pwsh|cmd\.exe|reg\s+add|choco|winget)\s`)},
+}
+
+// evidence is a line in the skill that supports or contradicts a claim.
+type evidence struct {
+	file string
+	line int
+	text string
+}
+
+// location returns "file:line", or just the file when the line is unknown.
+func (e evidence) location() string {
+	if e.line == 0 {
+		return e.file
+	}
+	return fmt.Sprintf("%s:%d", e.file, e.line)
+}
+
+// CheckCompatibility parses the compatibility field into platform, OS,
+// binary, runtime, and network claims and checks them against what the body
+// and scripts use. It returns the results and the parsed claims, or nil
+// claims when the field is empty or names nothing recognizable.
+func CheckCompatibility(dir string, s *skill.Skill) ([]types.Result, *types.CompatibilityReport) {
+	text := s.Frontmatter.Compatibility
+	if strings.TrimSpace(text) == "" {
+		return nil, nil
+	}
+	cr := ParseCompatibility(text)
+	if cr == nil {
+		return nil, nil
+	}
+
+	ctx := types.ResultContext{Category: "Compatibility"}
+	results := []types.Result{ctx.Info("compatibility claims: " + describeCompatibility(cr))}
+
+	scripts := findScripts(dir)
+	sources := compatSources(s.Body, s.BodyLineOffset(), scripts)
+	results = append(results, checkNetworkClaim(ctx, cr, sources)...)
+	results = append(results, checkPythonVersionClaim(ctx, cr, scripts)...)
+	results = append(results, checkOSClaim(ctx, cr, dir, sources, scripts)...)
+	results = append(results, checkBinaryClaims(ctx, cr, sources)...)
+	return results, cr
+}
+
+// ParseCompatibility extracts the recognized claims from a compatibility
+// field. Returns nil if it names no known platform, OS, binary, runtime, or
+// network requirement.
+func ParseCompatibility(text string) *types.CompatibilityReport {
+	cr := &types.CompatibilityReport{}
+	for _, p := range compatPlatforms {
+		if p.re.MatchString(text) {
+			cr.Platforms = append(cr.Platforms, p.name)
+		}
+	}
+	for _, p := range compatOS {
+		if p.re.MatchString(text) {
+			cr.OS = append(cr.OS, p.name)
+		}
+	}
+	lower := strings.ToLower(text)
+	for _, b := range compatBinaries {
+		if containsWord(lower, b) {
+			cr.Binaries = append(cr.Binaries, b)
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, m := range runtimeVersionPattern.FindAllStringSubmatch(text, -1) {
+		name := runtimeName(m[1])
+		constraint := m[2]
+		if m[4] != "" {
+			constraint = ">="
+		}
+		if constraint == "==" {
+			constraint = "="
+		}
+		cr.Runtimes = append(cr.Runtimes, types.RuntimeRequirement{Name: name, Version: m[3], Constraint: constraint})
+		seen[name] = true
+	}
+	for _, m := range runtimeNamePattern.FindAllStringSubmatch(text, -1) {
+		if name := runtimeName(m[1]); !seen[name] {
+			cr.Runtimes = append(cr.Runtimes, types.RuntimeRequirement{Name: name})
+			seen[name] = true
+		}
+	}
+
+	switch {
+	case noNetworkPattern.MatchString(text):
+		cr.Network = "none"
+	case requiresNetworkPattern.MatchString(text):
+		cr.Network = "required"
+	}
+
+	if len(cr.Platforms) == 0 && len(cr.OS) == 0 && len(cr.Binaries) == 0 && len(cr.Runtimes) == 0 && cr.Network == "" {
+		return nil
+	}
+	return cr
+}
+
+// runtimeName normalizes a runtime as written ("Node.js", "golang") to its
+// canonical name.
+func runtimeName(s string) string {
+	s = strings.ToLower(s)
+	switch {
+	case strings.HasPrefix(s, "node"):
+		return "node"
+	case s == "golang":
+		return "go"
+	}
+	return s
+}
+
+// describeCompatibility summarizes the claims on one line.
+func describeCompatibility(cr *types.CompatibilityReport) string {
+	var parts []string
+	if len(cr.Platforms) > 0 {
+		parts = append(parts, "platforms "+strings.Join(cr.Platforms, ", "))
+	}
+	if len(cr.OS) > 0 {
+		parts = append(parts, "OS "+strings.Join(cr.OS, ", "))
+	}
+	if len(cr.Runtimes) > 0 {
+		var rs []string
+		for _, r := range cr.Runtimes {
+			rs = append(rs, strings.TrimSpace(r.Name+" "+r.Constraint+r.Version))
+		}
+		parts = append(parts, "runtimes "+strings.Join(rs, ", "))
+	}
+	if len(cr.Binaries) > 0 {
+		parts = append(parts, "binaries "+strings.Join(cr.Binaries, ", "))
+	}
+	if cr.Network != "" {
+		parts = append(parts, "network "+cr.Network)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// compatSource is a file whose lines are searched for evidence: the SKILL.md
+// body and the scripts under scripts/. For SKILL.md only shell code fences
+// are searched, so prose like "uses curl-style flags" isn't mistaken for a
+// command.
+type compatSource struct {
+	path       string
+	lines      []string
+	lineOffset int // file lines before lines[0]
+	python     bool
+}
+
+// compatSources returns the sources for the SKILL.md body, which starts after
+// bodyLineOffset lines of the file, and scripts.
+func compatSources(body string, bodyLineOffset int, scripts []script) []compatSource {
+	var sources []compatSource
+	if cmds := bodyCommandLines(body); len(cmds) > 0 {
+		sources = append(sources, compatSource{path: "SKILL.md", lines: cmds, lineOffset: bodyLineOffset})
+	}
+	for _, sc := range scripts {
+		sources = append(sources, compatSource{
+			path:   sc.path,
+			lines:  strings.Split(sc.content, "\n"),
+			python: sc.interpreter == "python",
+		})
+	}
+	return sources
+}
+
+// bodyCommandLines returns the body with everything but shell code fence
+// lines blanked out, so line numbers still match the body.
+func bodyCommandLines(body string) []string {
+	lines := strings.Split(body, "\n")
+	out := make([]string, len(lines))
+	found := false
+	for _, m := range fencePattern.FindAllStringSubmatchIndex(body, -1) {
+		if !shellFenceLangs[strings.ToLower(body[m[4]:m[5]])] {
+			continue
+		}
+		first := strings.Count(body[:m[6]], "\n")
+		for i, line := range strings.Split(body[m[6]:m[7]], "\n") {
+			if first+i < len(out) {
+				out[first+i] = line
+				found = true
+			}
+		}
+	}
+	if !found {
+		return nil
+	}
+	return out
+}
+
+// scan returns the first line of each source that matches match, skipping
+// comments.
+func scan(sources []compatSource, match func(src compatSource, line string) bool) []evidence {
+	var found []evidence
+	for _, src := range sources {
+		for i, line := range src.lines {
+			trimmed := strings.TrimSpace(line)
+			if trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "//") {
+				continue
+			}
+			if match(src, line) {
+				found = append(found, evidence{file: src.path, line: src.lineOffset + i + 1, text: shorten(trimmed)})
+				break
+			}
+		}
+	}
+	return found
+}
+
+// shorten truncates a line of evidence for a message.
+func shorten(s string) string {
+	const maxLen = 80
+	if r := []rune(s); len(r) > maxLen {
+		return string(r[:maxLen-1]) + "…"
+	}
+	return s
+}
+
+// warnAt returns a warning located at the evidence.
+func warnAt(ctx types.ResultContext, ev evidence, format string, args ...any) types.Result {
+	msg := fmt.Sprintf(format, args...)
+	if ev.line == 0 {
+		return ctx.WarnFile(ev.file, msg)
+	}
+	return ctx.WarnAtLine(ev.file, ev.line, msg)
+}
+
+// checkNetworkClaim warns when the compatibility field says the skill works
+// without network access but its commands or scripts reach the network.
+func checkNetworkClaim(ctx types.ResultContext, cr *types.CompatibilityReport, sources []compatSource) []types.Result {
+	if cr.Network != "none" {
+		return nil
+	}
+	var results []types.Result
+	for _, ev := range scan(sources, func(_ compatSource, line string) bool { return networkUsePattern.MatchString(line) }) {
+		results = append(results, warnAt(ctx, ev, "compatibility says no network access is needed, but %s uses the network: %s", ev.location(), ev.text))
+	}
+	return results
+}
+
+// checkPythonVersionClaim warns when a Python script uses syntax or modules
+// newer than the Python version the compatibility field claims.
+func checkPythonVersionClaim(ctx types.ResultContext, cr *types.CompatibilityReport, scripts []script) []types.Result {
+	var claimed types.RuntimeRequirement
+	for _, r := range cr.Runtimes {
+		if r.Name == "python" && strings.Count(r.Version, ".") >= 1 {
+			claimed = r
+			break
+		}
+	}
+	if claimed.Version == "" || claimed.Constraint == ">" {
+		return nil
+	}
+
+	var results []types.Result
+	for _, sc := range scripts {
+		if sc.interpreter != "python" {
+			continue
+		}
+		// Report the newest feature each script needs
+		var worst *pythonFeature
+		var at evidence
+		for i, line := range strings.Split(sc.content, "\n") {
+			trimmed := strings.TrimSpace(line)
+			if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+				continue
+			}
+			for fi := range pythonFeatures {
+				f := &pythonFeatures[fi]
+				if (worst == nil || compareVersions(f.version, worst.version) > 0) && f.re.MatchString(line) {
+					worst, at = f, evidence{file: sc.path, line: i + 1, text: shorten(trimmed)}
+				}
+			}
+		}
+		if worst == nil {
+			continue
+		}
+		cmp := compareVersions(worst.version, claimed.Version)
+		if cmp > 0 || (cmp == 0 && claimed.Constraint == "<") {
+			results = append(results, warnAt(ctx, at, "compatibility claims Python %s%s, but %s uses %s, which requires Python %s: %s",
+				claimed.Constraint, claimed.Version, at.location(), worst.label, worst.version, at.text))
+		}
+	}
+	return results
+}
+
+// compareVersions compares dotted version strings numerically, treating
+// missing components as zero.
+func compareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < max(len(as), len(bs)); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// checkOSClaim warns when the skill uses commands or scripts specific to an
+// operating system the compatibility field doesn't list.
+func checkOSClaim(ctx types.ResultContext, cr *types.CompatibilityReport, dir string, sources []compatSource, scripts []script) []types.Result {
+	if len(cr.OS) == 0 {
+		return nil
+	}
+	var results []types.Result
+	claims := strings.Join(cr.OS, ", ")
+	for _, m := range osMarkers {
+		if slices.Contains(cr.OS, m.os) {
+			continue
+		}
+		for _, ev := range scan(sources, func(_ compatSource, line string) bool { return m.re.MatchString(line) }) {
+			results = append(results, warnAt(ctx, ev, "compatibility lists %s, but %s uses %s: %s", claims, ev.location(), m.label, ev.text))
+		}
+	}
+
+	// Shell scripts need a Unix shell; batch and PowerShell scripts need Windows
+	unix := slices.Contains(cr.OS, "linux") || slices.Contains(cr.OS, "macos")
+	for _, sc := range scripts {
+		if !unix && (sc.interpreter == "sh" || sc.interpreter == "bash" || sc.interpreter == "zsh") {
+			results = append(results, ctx.WarnFilef(sc.path, "compatibility lists only %s, but %s is a %s script", claims, sc.path, sc.interpreter))
+		}
+	}
+	if !slices.Contains(cr.OS, "windows") {
+		for _, path := range windowsScripts(dir) {
+			results = append(results, ctx.WarnFilef(path, "compatibility lists %s, but %s is a Windows script", claims, path))
+		}
+	}
+	return results
+}
+
+// windowsScripts returns the batch and PowerShell files under scripts/.
+func windowsScripts(dir string) []string {
+	var paths []string
+	root := filepath.Join(dir, "scripts")
+	_ = filepath.WalkDir(root, func(path string, entry os.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return nil
+		}
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".bat", ".cmd", ".ps1":
+			rel, _ := filepath.Rel(dir, path)
+			paths = append(paths, rel)
+		}
+		return nil
+	})
+	return paths
+}
+
+// checkBinaryClaims warns about binaries the skill runs that the
+// compatibility field leaves out. It only applies when the field lists
+// required binaries, since a field that names none makes no claim to be
+// complete.
+func checkBinaryClaims(ctx types.ResultContext, cr *types.CompatibilityReport, sources []compatSource) []types.Result {
+	if len(cr.Binaries) == 0 {
+		return nil
+	}
+	used := make(map[string]evidence)
+	for _, src := range sources {
+		for i, line := range src.lines {
+			trimmed := strings.TrimSpace(line)
+			if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+				continue
+			}
+			for _, name := range lineCommands(line, src.python) {
+				if _, ok := used[name]; !ok && slices.Contains(compatBinaries, name) && !slices.Contains(cr.Binaries, name) {
+					used[name] = evidence{file: src.path, line: src.lineOffset + i + 1, text: shorten(trimmed)}
+				}
+			}
+		}
+	}
+	var results []types.Result
+	for _, name := range util.SortedKeys(used) {
+		ev := used[name]
+		results = append(results, warnAt(ctx, ev, "compatibility lists required binaries (%s) but not %s, which %s runs: %s",
+			strings.Join(cr.Binaries, ", "), name, ev.location(), ev.text))
+	}
+	return results
+}
+
+// lineCommands returns the programs a line runs: the first word of each
+// shell command segment, or for Python, the program passed to subprocess or
+// os.system.
+func lineCommands(line string, python bool) []string {
+	var names []string
+	if python {
+		for _, m := range subprocessCommandPattern.FindAllStringSubmatch(line, -1) {
+			names = append(names, m[1])
+		}
+		return names
+	}
+	for _, seg := range shellCommandSplit.Split(line, -1) {
+		if cmd := commandLine(seg); cmd != "" {
+			names = append(names, filepath.Base(strings.Fields(cmd)[0]))
+		}
+	}
+	return names
+}
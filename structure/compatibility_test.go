@@ -0,0 +1,192 @@
+package structure
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/agent-ecosystem/skill-validator/skill"
+	"github.com/agent-ecosystem/skill-validator/types"
+)
+
+func TestParseCompatibility(t *testing.T) {
+	t.Run("nothing recognized", func(t *testing.T) {
+		if cr := ParseCompatibility("Works with all major LLM providers."); cr != nil {
+			t.Errorf("expected nil, got %+v", cr)
+		}
+	})
+
+	t.Run("claims", func(t *testing.T) {
+		cr := ParseCompatibility("Claude Code or Gemini CLI on macOS and Linux. Requires Python 3.10+, Node.js >= 18, git, and jq. Needs internet access.")
+		want := &types.CompatibilityReport{
+			Platforms: []string{"claude-code", "gemini-cli"},
+			OS:        []string{"linux", "macos"},
+			Binaries:  []string{"git", "jq"},
+			Runtimes: []types.RuntimeRequirement{
+				{Name: "python", Version: "3.10", Constraint: ">="},
+				{Name: "node", Version: "18", Constraint: ">="},
+			},
+			Network: "required",
+		}
+		if !reflect.DeepEqual(cr, want) {
+			t.Errorf("got %+v\nwant %+v", cr, want)
+		}
+	})
+
+	t.Run("runtime versions", func(t *testing.T) {
+		tests := []struct {
+			text string
+			want types.RuntimeRequirement
+		}{
+			{"Python 3.11", types.RuntimeRequirement{Name: "python", Version: "3.11"}},
+			{"python 3.9 or later", types.RuntimeRequirement{Name: "python", Version: "3.9", Constraint: ">="}},
+			{"Python <3.12", types.RuntimeRequirement{Name: "python", Version: "3.12", Constraint: "<"}},
+			{"golang 1.22", types.RuntimeRequirement{Name: "go", Version: "1.22"}},
+			{"Node 20.x", types.RuntimeRequirement{Name: "node", Version: "20", Constraint: ">="}},
+			{"Requires Ruby", types.RuntimeRequirement{Name: "ruby"}},
+		}
+		for _, tt := range tests {
+			cr := ParseCompatibility(tt.text)
+			if cr == nil || len(cr.Runtimes) != 1 || cr.Runtimes[0] != tt.want {
+				t.Errorf("ParseCompatibility(%q) runtimes = %+v, want [%+v]", tt.text, cr, tt.want)
+			}
+		}
+	})
+
+	t.Run("no network", func(t *testing.T) {
+		for _, text := range []string{"Runs offline", "No network access required", "Works in air-gapped environments"} {
+			if cr := ParseCompatibility(text); cr == nil || cr.Network != "none" {
+				t.Errorf("ParseCompatibility(%q) = %+v, want network none", text, cr)
+			}
+		}
+	})
+}
+
+func TestCheckCompatibility(t *testing.T) {
+	t.Run("empty field", func(t *testing.T) {
+		results, cr := CheckCompatibility(t.TempDir(), scriptSkill("", ""))
+		if results != nil || cr != nil {
+			t.Errorf("expected nil, got %+v %+v", results, cr)
+		}
+	})
+
+	t.Run("consistent claims", func(t *testing.T) {
+		dir := t.TempDir()
+		writeScript(t, dir, "scripts/run.py", "#!/usr/bin/env python3\nif (n := 3) > 2:\n    print(n)\n")
+		body := "```bash\ngit status\njq . data.json\n```\n"
+		results, cr := CheckCompatibility(dir, scriptSkill("Linux or macOS, Python 3.10+, git, jq. Works offline.", body))
+		if cr == nil {
+			t.Fatal("expected parsed claims")
+		}
+		requireResult(t, results, types.Info, "compatibility claims: OS linux, macos; runtimes python >=3.10; binaries git, jq; network none")
+		requireNoLevel(t, results, types.Warning)
+	})
+
+	t.Run("network use contradicts offline claim", func(t *testing.T) {
+		dir := t.TempDir()
+		writeScript(t, dir, "scripts/fetch.py", "#!/usr/bin/env python3\nimport requests\n# requests.get is used below\nr = requests.get(URL)\n")
+		body := "```bash\ncurl -sSL https://example.com/data.json\n```\n"
+		results, _ := CheckCompatibility(dir, scriptSkill("Works offline", body))
+		requireResult(t, results, types.Warning,
+			"compatibility says no network access is needed, but SKILL.md:2 uses the network: curl -sSL https://example.com/data.json")
+		requireResult(t, results, types.Warning,
+			"compatibility says no network access is needed, but scripts/fetch.py:4 uses the network: r = requests.get(URL)")
+	})
+
+	t.Run("SKILL.md lines count the frontmatter", func(t *testing.T) {
+		dir := t.TempDir()
+		writeSkill(t, dir, "---\nname: "+dirName(dir)+"\ndescription: Fetches data.\ncompatibility: Works offline\n---\n# Fetch\n\nRun:\n\n```bash\ncurl -sSL https://example.com/data.json\n```\n")
+		s, err := skill.Load(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		results, _ := CheckCompatibility(dir, s)
+		requireResult(t, results, types.Warning,
+			"compatibility says no network access is needed, but SKILL.md:11 uses the network: curl -sSL https://example.com/data.json")
+		for _, r := range results {
+			if r.Level == types.Warning && r.Line != 11 {
+				t.Errorf("warning at line %d, want 11", r.Line)
+			}
+		}
+	})
+
+	t.Run("prose mentions are not evidence", func(t *testing.T) {
+		results, _ := CheckCompatibility(t.TempDir(), scriptSkill("Works offline", "Flags follow curl conventions.\n"))
+		requireNoLevel(t, results, types.Warning)
+	})
+
+	t.Run("python syntax newer than claimed", func(t *testing.T) {
+		dir := t.TempDir()
+		writeScript(t, dir, "scripts/a.py", "#!/usr/bin/env python3\nimport tomllib\n\ntype Config = dict[str, str]\n")
+		results, _ := CheckCompatibility(dir, scriptSkill("Requires Python 3.11", ""))
+		requireResult(t, results, types.Warning,
+			"compatibility claims Python 3.11, but scripts/a.py:4 uses a type alias statement, which requires Python 3.12: type Config = dict[str, str]")
+	})
+
+	t.Run("python syntax within claimed version", func(t *testing.T) {
+		dir := t.TempDir()
+		writeScript(t, dir, "scripts/a.py", "#!/usr/bin/env python3\nmatch command:\n    case 'go':\n        pass\n")
+		results, _ := CheckCompatibility(dir, scriptSkill("Python 3.10+", ""))
+		requireNoLevel(t, results, types.Warning)
+		results, _ = CheckCompatibility(dir, scriptSkill("Python 3.9", ""))
+		requireResultContaining(t, results, types.Warning, "uses a match statement, which requires Python 3.10")
+	})
+
+	t.Run("upper bound", func(t *testing.T) {
+		dir := t.TempDir()
+		writeScript(t, dir, "scripts/a.py", "#!/usr/bin/env python3\nimport tomllib\n")
+		results, _ := CheckCompatibility(dir, scriptSkill("Python <3.11", ""))
+		requireResultContaining(t, results, types.Warning, "uses the tomllib module, which requires Python 3.11")
+	})
+
+	t.Run("os-specific commands", func(t *testing.T) {
+		dir := t.TempDir()
+		writeScript(t, dir, "scripts/install.ps1", "Install-Module Foo\n")
+		body := "```bash\nbrew install jq\nsudo apt-get install -y jq\n```\n"
+		results, _ := CheckCompatibility(dir, scriptSkill("Linux only", body))
+		requireResult(t, results, types.Warning, "compatibility lists linux, but SKILL.md:2 uses a macOS-only tool: brew install jq")
+		requireResult(t, results, types.Warning, "compatibility lists linux, but scripts/install.ps1 is a Windows script")
+		requireNoResultContaining(t, results, types.Warning, "Linux package manager")
+	})
+
+	t.Run("shell scripts on windows-only skill", func(t *testing.T) {
+		dir := t.TempDir()
+		writeScript(t, dir, "scripts/run.sh", "#!/bin/bash\necho hi\n")
+		results, _ := CheckCompatibility(dir, scriptSkill("Windows 11", ""))
+		requireResult(t, results, types.Warning, "compatibility lists only windows, but scripts/run.sh is a bash script")
+	})
+
+	t.Run("undeclared binaries", func(t *testing.T) {
+		dir := t.TempDir()
+		writeScript(t, dir, "scripts/deploy.sh", "#!/bin/bash\nset -e\nVERSION=1 docker build . && kubectl apply -f k8s/\n")
+		writeScript(t, dir, "scripts/sync.py", "#!/usr/bin/env python3\nimport subprocess\nsubprocess.run(['gh', 'pr', 'list'])\n")
+		results, _ := CheckCompatibility(dir, scriptSkill("Requires docker", "```bash\ngit status\n```\n"))
+		requireResult(t, results, types.Warning,
+			"compatibility lists required binaries (docker) but not git, which SKILL.md:2 runs: git status")
+		requireResult(t, results, types.Warning,
+			"compatibility lists required binaries (docker) but not kubectl, which scripts/deploy.sh:3 runs: VERSION=1 docker build . && kubectl apply -f k8s/")
+		requireResult(t, results, types.Warning,
+			"compatibility lists required binaries (docker) but not gh, which scripts/sync.py:3 runs: subprocess.run(['gh', 'pr', 'list'])")
+	})
+
+	t.Run("binaries not checked without a binary claim", func(t *testing.T) {
+		results, _ := CheckCompatibility(t.TempDir(), scriptSkill("Claude Code", "```bash\ndocker ps\n```\n"))
+		requireNoLevel(t, results, types.Warning)
+	})
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"3.10", "3.9", 1},
+		{"3.9", "3.10", -1},
+		{"3.12", "3.12.0", 0},
+		{"3", "3.0", 0},
+	}
+	for _, tt := range tests {
+		if got := compareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,289 @@
+package structure
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/agent-ecosystem/skill-validator/content"
+	"github.com/agent-ecosystem/skill-validator/skill"
+	"github.com/agent-ecosystem/skill-validator/skillcheck"
+	"github.com/agent-ecosystem/skill-validator/types"
+	"github.com/agent-ecosystem/skill-validator/util"
+)
+
+const (
+	// shingleSize is the number of words in each shingle.
+	shingleSize = 3
+	// minHashes is the length of a block's MinHash signature, split into
+	// lshBands bands for locality-sensitive hashing: two blocks are compared
+	// when any band of their signatures matches.
+	minHashes = 64
+	lshBands  = 16
+	// duplicateSimilarity is the Jaccard similarity of two blocks' shingles
+	// at or above which one is reported as a copy of the other.
+	duplicateSimilarity = 0.6
+	// minDuplicateWords keeps short blocks, such as "See references/api.md
+	// for details.", from being reported.
+	minDuplicateWords = 15
+	// minDuplicateTokens is the fewest tokens a copy must waste to be
+	// reported, so that command variants differing in a flag or two pass.
+	minDuplicateTokens = 30
+)
+
+// wordPattern matches the words blocks are compared on; punctuation and
+// formatting are ignored.
+var wordPattern = regexp.MustCompile(`[\p{L}\p{N}_]+`)
+
+// minHashSeeds are the seeds of the signature's hash functions.
+var minHashSeeds = func() [minHashes]uint64 {
+	var seeds [minHashes]uint64
+	for i := range seeds {
+		seeds[i] = mix64(uint64(i) + 1)
+	}
+	return seeds
+}()
+
+// textBlock is a paragraph or fenced code block of a markdown file.
+type textBlock struct {
+	file       string
+	start, end int // lines in the file, 1-based
+	code       bool
+	text       string
+	shingles   map[uint64]bool
+	signature  [minHashes]uint64
+}
+
+// duplicateSpan is a run of consecutive blocks that copy a run of
+// consecutive blocks elsewhere.
+type duplicateSpan struct {
+	first, last         int // the copy, as block indexes
+	origFirst, origLast int // the original
+	similarity          float64
+	code                int // code blocks in the copy
+}
+
+// CheckDuplicates finds paragraphs and code blocks that are repeated, or
+// nearly so, across the SKILL.md body of the skill s in dir and the markdown
+// files in references/. Blocks are compared by the Jaccard similarity of
+// their word shingles; MinHash signatures with locality-sensitive hashing
+// pick the pairs to compare. The first copy, with SKILL.md before
+// references in name order, is the original; each later copy is a warning
+// giving both locations and the tokens it wastes. Consecutive copied blocks
+// are reported as one span.
+func CheckDuplicates(dir string, s *skill.Skill, opts Options) []types.Result {
+	ctx := types.ResultContext{Category: "Tokens"}
+	count, err := getCounter(opts.Tokenizer)
+	if err != nil {
+		// CheckTokens reports the tokenizer error.
+		return nil
+	}
+
+	blocks := splitBlocks("SKILL.md", s.Body, s.BodyLineOffset())
+	refs := skillcheck.ReadReferencesMarkdownFiles(dir)
+	for _, name := range util.SortedKeys(refs) {
+		blocks = append(blocks, splitBlocks(path.Join("references", name), refs[name], 0)...)
+	}
+
+	original := make([]int, len(blocks))
+	similarity := make([]float64, len(blocks))
+	buckets := map[uint64][]int{}
+	for i := range blocks {
+		original[i] = -1
+		var candidates []int
+		for band := range lshBands {
+			key := bandKey(band, &blocks[i].signature)
+			candidates = append(candidates, buckets[key]...)
+			buckets[key] = append(buckets[key], i)
+		}
+		slices.Sort(candidates)
+		for _, j := range slices.Compact(candidates) {
+			if sim := jaccard(blocks[i].shingles, blocks[j].shingles); sim >= duplicateSimilarity {
+				original[i], similarity[i] = j, sim
+				break
+			}
+		}
+	}
+
+	var spans []duplicateSpan
+	for i, j := range original {
+		if j < 0 {
+			continue
+		}
+		if n := len(spans); n > 0 {
+			sp := &spans[n-1]
+			if sp.last == i-1 && blocks[i-1].file == blocks[i].file &&
+				sp.origLast == j-1 && blocks[j-1].file == blocks[j].file {
+				sp.last, sp.origLast = i, j
+				sp.similarity = min(sp.similarity, similarity[i])
+				if blocks[i].code {
+					sp.code++
+				}
+				continue
+			}
+		}
+		sp := duplicateSpan{first: i, last: i, origFirst: j, origLast: j, similarity: similarity[i]}
+		if blocks[i].code {
+			sp.code = 1
+		}
+		spans = append(spans, sp)
+	}
+
+	var results []types.Result
+	for _, sp := range spans {
+		tokens := 0
+		for i := sp.first; i <= sp.last; i++ {
+			tokens += count(blocks[i].text)
+		}
+		if tokens < minDuplicateTokens {
+			continue
+		}
+		what, verb := "paragraph", "duplicates"
+		switch n := sp.last - sp.first + 1; {
+		case n > 1:
+			what, verb = fmt.Sprintf("%d consecutive blocks", n), "duplicate"
+		case sp.code == 1:
+			what = "code block"
+		}
+		copyLoc := blockLocation(blocks[sp.first], blocks[sp.last])
+		origLoc := blockLocation(blocks[sp.origFirst], blocks[sp.origLast])
+		if sp.similarity == 1 {
+			results = append(results, ctx.WarnAtLinef(blocks[sp.first].file, blocks[sp.first].start,
+				"%s at %s %s %s, wasting %s tokens; keep one copy and point to it",
+				what, copyLoc, verb, origLoc, util.FormatNumber(tokens)))
+		} else {
+			results = append(results, ctx.WarnAtLinef(blocks[sp.first].file, blocks[sp.first].start,
+				"%s at %s nearly %s %s (%.0f%% similar), wasting about %s tokens; keep one copy and point to it",
+				what, copyLoc, verb, origLoc, math.Floor(sp.similarity*100), util.FormatNumber(tokens)))
+		}
+	}
+	return results
+}
+
+// splitBlocks splits markdown text into paragraphs and fenced code blocks,
+// dropping headings and blocks of fewer than minDuplicateWords words.
+// lineOffset is added to block lines, so that lines in the SKILL.md body
+// match the file.
+func splitBlocks(file, text string, lineOffset int) []textBlock {
+	var blocks []textBlock
+	var cur []string
+	start, code := 0, false
+	flush := func(end int) {
+		if len(cur) > 0 {
+			if b, ok := newTextBlock(file, strings.Join(cur, "\n"), code); ok {
+				b.start, b.end = start+lineOffset, end+lineOffset
+				blocks = append(blocks, b)
+			}
+		}
+		cur, code = nil, false
+	}
+
+	fence, fenceLen := byte(0), 0
+	for i, line := range strings.Split(text, "\n") {
+		n := i + 1
+		trimmed := strings.TrimSpace(line)
+		ch, width := content.FencePrefix(trimmed)
+		switch {
+		case fence != 0:
+			cur = append(cur, line)
+			if content.ClosesFence(trimmed, fence, fenceLen) {
+				fence = 0
+				flush(n)
+			}
+		case width > 0:
+			flush(n - 1)
+			fence, fenceLen, code, start = ch, width, true, n
+			cur = []string{line}
+		case trimmed == "" || strings.HasPrefix(trimmed, "#"):
+			flush(n - 1)
+		default:
+			if len(cur) == 0 {
+				start = n
+			}
+			cur = append(cur, line)
+		}
+	}
+	flush(strings.Count(text, "\n") + 1)
+	return blocks
+}
+
+// newTextBlock shingles and signs a block, reporting false if it is too
+// short to compare.
+func newTextBlock(file, text string, code bool) (textBlock, bool) {
+	words := wordPattern.FindAllString(strings.ToLower(text), -1)
+	if len(words) < minDuplicateWords {
+		return textBlock{}, false
+	}
+	b := textBlock{file: file, code: code, text: text, shingles: map[uint64]bool{}}
+	for i := range b.signature {
+		b.signature[i] = math.MaxUint64
+	}
+	for i := 0; i+shingleSize <= len(words); i++ {
+		h := fnv.New64a()
+		for _, w := range words[i : i+shingleSize] {
+			_, _ = h.Write([]byte(w))
+			_, _ = h.Write([]byte{0})
+		}
+		x := h.Sum64()
+		if b.shingles[x] {
+			continue
+		}
+		b.shingles[x] = true
+		for k, seed := range minHashSeeds {
+			b.signature[k] = min(b.signature[k], mix64(x^seed))
+		}
+	}
+	return b, true
+}
+
+// bandKey hashes one band of a signature, with the band number, into an
+// LSH bucket key.
+func bandKey(band int, sig *[minHashes]uint64) uint64 {
+	const rows = minHashes / lshBands
+	h := fnv.New64a()
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], uint64(band))
+	_, _ = h.Write(buf[:])
+	for _, v := range sig[band*rows : (band+1)*rows] {
+		binary.LittleEndian.PutUint64(buf[:], v)
+		_, _ = h.Write(buf[:])
+	}
+	return h.Sum64()
+}
+
+// jaccard returns the Jaccard similarity of two shingle sets.
+func jaccard(a, b map[uint64]bool) float64 {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	shared := 0
+	for x := range a {
+		if b[x] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+// mix64 is the SplitMix64 finalizer, used as a family of hash functions by
+// seeding its input.
+func mix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
+
+// blockLocation formats the lines from first to last, e.g.
+// "references/api.md:12-30".
+func blockLocation(first, last textBlock) string {
+	if first.start == last.end {
+		return fmt.Sprintf("%s:%d", first.file, first.start)
+	}
+	return fmt.Sprintf("%s:%d-%d", first.file, first.start, last.end)
+}
@@ -0,0 +1,119 @@
+package structure
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/agent-ecosystem/skill-validator/types"
+)
+
+const (
+	dupParagraph = "Before running the migration, back up the database with pg_dump and store the archive somewhere " +
+		"outside the data directory so that a failed run can be rolled back without losing any rows."
+	dupCode = "```bash\npg_dump --format=custom --file=backup.dump --dbname=\"$DATABASE_URL\" --verbose --no-owner \\\n" +
+		"  --no-privileges --schema=public --exclude-table=audit_log --exclude-table=sessions --jobs=4\n```"
+)
+
+func TestCheckDuplicates(t *testing.T) {
+	t.Run("paragraph copied into a reference", func(t *testing.T) {
+		s := loadTestSkill(t, "---\nname: my-skill\ndescription: Does things.\n---\n# My Skill\n\n"+dupParagraph+"\n")
+		writeFile(t, s.Dir, "references/guide.md", "# Guide\n\nIntro.\n\n"+dupParagraph+"\n")
+
+		results := CheckDuplicates(s.Dir, s, Options{})
+		requireResultContaining(t, results, types.Warning,
+			"paragraph at references/guide.md:5 duplicates SKILL.md:7, wasting ")
+		if len(results) != 1 || results[0].File != "references/guide.md" || results[0].Line != 5 || results[0].Category != "Tokens" {
+			t.Errorf("unexpected results %+v", results)
+		}
+	})
+
+	t.Run("near-duplicate code block", func(t *testing.T) {
+		s := loadTestSkill(t, "---\nname: my-skill\ndescription: Does things.\n---\n# My Skill\n\nBack up first:\n\n"+dupCode+"\n")
+		edited := strings.Replace(dupCode, "--jobs=4", "--jobs=8 --compress=9", 1)
+		writeFile(t, s.Dir, "references/a.md", "# A\n\n"+edited+"\n")
+
+		results := CheckDuplicates(s.Dir, s, Options{})
+		requireResultContaining(t, results, types.Warning, "code block at references/a.md:3-6 nearly duplicates SKILL.md:9-12 (")
+		requireResultContaining(t, results, types.Warning, "% similar), wasting about ")
+	})
+
+	t.Run("consecutive blocks are one span", func(t *testing.T) {
+		s := loadTestSkill(t, "---\nname: my-skill\ndescription: Does things.\n---\n# My Skill\n\n"+dupParagraph+"\n\n"+dupCode+"\n")
+		writeFile(t, s.Dir, "references/a.md", "# A\n\n"+dupParagraph+"\n\n"+dupCode+"\n")
+
+		results := CheckDuplicates(s.Dir, s, Options{})
+		if len(results) != 1 {
+			t.Fatalf("expected one span, got %+v", results)
+		}
+		requireResultContaining(t, results, types.Warning, "2 consecutive blocks at references/a.md:3-8 duplicate SKILL.md:7-12")
+	})
+
+	t.Run("copies within SKILL.md", func(t *testing.T) {
+		s := loadTestSkill(t, "---\nname: my-skill\ndescription: Does things.\n---\n# My Skill\n\n## Setup\n\n"+dupParagraph+"\n\n## Rollback\n\n"+dupParagraph+"\n")
+		results := CheckDuplicates(s.Dir, s, Options{})
+		requireResultContaining(t, results, types.Warning, "paragraph at SKILL.md:13 duplicates SKILL.md:9")
+	})
+
+	t.Run("distinct and short content passes", func(t *testing.T) {
+		s := loadTestSkill(t, "---\nname: my-skill\ndescription: Does things.\n---\n# My Skill\n\n"+dupParagraph+
+			"\n\nSee references/guide.md for details.\n")
+		writeFile(t, s.Dir, "references/guide.md", "# Guide\n\nSee references/guide.md for details.\n\n"+
+			"Deploy the service with the blue-green strategy, switching traffic only after health checks pass on every new instance in the pool.\n")
+
+		if results := CheckDuplicates(s.Dir, s, Options{}); len(results) != 0 {
+			t.Errorf("expected no results, got %+v", results)
+		}
+	})
+}
+
+func TestSplitBlocks(t *testing.T) {
+	words := strings.Repeat("word ", minDuplicateWords)
+	text := "# Title\n" + words + "\n\n```md\n# not a heading\n\n" + words + "\n```\nshort\n"
+	blocks := splitBlocks("SKILL.md", text, 4)
+	if len(blocks) != 2 {
+		t.Fatalf("expected 2 blocks, got %+v", blocks)
+	}
+	if blocks[0].code || blocks[0].start != 6 || blocks[0].end != 6 {
+		t.Errorf("unexpected paragraph %+v", blocks[0])
+	}
+	if !blocks[1].code || blocks[1].start != 8 || blocks[1].end != 12 {
+		t.Errorf("unexpected code block start=%d end=%d code=%v", blocks[1].start, blocks[1].end, blocks[1].code)
+	}
+}
+
+func TestSplitBlocks_Fences(t *testing.T) {
+	words := strings.Repeat("word ", minDuplicateWords)
+
+	t.Run("info string does not close a fence", func(t *testing.T) {
+		text := "```bash\n" + words + "\n```python\n" + words + "\n```\n"
+		blocks := splitBlocks("SKILL.md", text, 0)
+		if len(blocks) != 1 || !blocks[0].code || blocks[0].start != 1 || blocks[0].end != 5 {
+			t.Errorf("expected one code block on lines 1-5, got %+v", blocks)
+		}
+	})
+
+	t.Run("longer fence holds a shorter one", func(t *testing.T) {
+		text := "````markdown\n```\n" + words + "\n```\n````\n" + words + "\n"
+		blocks := splitBlocks("SKILL.md", text, 0)
+		if len(blocks) != 2 {
+			t.Fatalf("expected 2 blocks, got %+v", blocks)
+		}
+		if !blocks[0].code || blocks[0].start != 1 || blocks[0].end != 5 {
+			t.Errorf("unexpected code block start=%d end=%d code=%v", blocks[0].start, blocks[0].end, blocks[0].code)
+		}
+		if blocks[1].code || blocks[1].start != 6 {
+			t.Errorf("unexpected paragraph %+v", blocks[1])
+		}
+	})
+}
+
+func TestJaccard(t *testing.T) {
+	a := map[uint64]bool{1: true, 2: true, 3: true}
+	b := map[uint64]bool{2: true, 3: true, 4: true}
+	if got := jaccard(a, b); got != 0.5 {
+		t.Errorf("jaccard = %v, want 0.5", got)
+	}
+	if got := jaccard(a, a); got != 1 {
+		t.Errorf("jaccard = %v, want 1", got)
+	}
+}
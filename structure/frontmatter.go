@@ -19,51 +19,56 @@ func CheckFrontmatter(s *skill.Skill, opts Options) []types.Result {
 	var results []types.Result
 
 	// Check name
+	var nameResults []types.Result
 	name := s.Frontmatter.Name
 	if name == "" {
-		results = append(results, ctx.Error("name is required"))
+		nameResults = append(nameResults, ctx.Error("name is required"))
 	} else {
 		if len(name) > 64 {
-			results = append(results, ctx.Errorf("name exceeds 64 characters (%d)", len(name)))
+			nameResults = append(nameResults, ctx.Errorf("name exceeds 64 characters (%d)", len(name)))
 		}
 		if !namePattern.MatchString(name) {
-			results = append(results, ctx.Errorf("name %q must be lowercase alphanumeric with hyphens, no leading/trailing/consecutive hyphens", name))
+			nameResults = append(nameResults, ctx.Errorf("name %q must be lowercase alphanumeric with hyphens, no leading/trailing/consecutive hyphens", name))
 		}
 		// Check that name matches directory name
 		dirName := filepath.Base(s.Dir)
 		if name != dirName {
-			results = append(results, ctx.Errorf("name does not match directory name (expected %q, got %q)", dirName, name))
+			nameResults = append(nameResults, ctx.Errorf("name does not match directory name (expected %q, got %q)", dirName, name))
 		}
-		if len(results) == 0 || (name != "" && namePattern.MatchString(name)) {
-			results = append(results, ctx.Passf("name: %q (valid)", name))
+		if len(nameResults) == 0 || (name != "" && namePattern.MatchString(name)) {
+			nameResults = append(nameResults, ctx.Passf("name: %q (valid)", name))
 		}
 	}
+	results = append(results, atField(s, nameResults, "name")...)
 
 	// Check description
+	var descResults []types.Result
 	desc := s.Frontmatter.Description
 	if desc == "" {
-		results = append(results, ctx.Error("description is required"))
+		descResults = append(descResults, ctx.Error("description is required"))
 	} else if len(desc) > 1024 {
-		results = append(results, ctx.Errorf("description exceeds 1024 characters (%d)", len(desc)))
+		descResults = append(descResults, ctx.Errorf("description exceeds 1024 characters (%d)", len(desc)))
 	} else if strings.TrimSpace(desc) == "" {
-		results = append(results, ctx.Error("description must not be empty/whitespace-only"))
+		descResults = append(descResults, ctx.Error("description must not be empty/whitespace-only"))
 	} else {
-		results = append(results, ctx.Passf("description: (%d chars)", len(desc)))
-		results = append(results, checkDescriptionKeywordStuffing(ctx, desc)...)
+		descResults = append(descResults, ctx.Passf("description: (%d chars)", len(desc)))
+		descResults = append(descResults, checkProfileDescription(ctx, desc, opts)...)
+		descResults = append(descResults, checkDescriptionKeywordStuffing(ctx, desc)...)
 	}
+	results = append(results, atField(s, descResults, "description")...)
 
 	// Check optional license
-	if s.Frontmatter.License != "" {
-		results = append(results, ctx.Passf("license: %q", s.Frontmatter.License))
-	}
+	results = append(results, atField(s, checkLicense(ctx, s, opts), "license")...)
 
 	// Check optional compatibility
 	if s.Frontmatter.Compatibility != "" {
+		var r types.Result
 		if len(s.Frontmatter.Compatibility) > 500 {
-			results = append(results, ctx.Errorf("compatibility exceeds 500 characters (%d)", len(s.Frontmatter.Compatibility)))
+			r = ctx.Errorf("compatibility exceeds 500 characters (%d)", len(s.Frontmatter.Compatibility))
 		} else {
-			results = append(results, ctx.Passf("compatibility: (%d chars)", len(s.Frontmatter.Compatibility)))
+			r = ctx.Passf("compatibility: (%d chars)", len(s.Frontmatter.Compatibility))
 		}
+		results = append(results, atField(s, []types.Result{r}, "compatibility")...)
 	}
 
 	// Check optional metadata
@@ -73,33 +78,57 @@ func CheckFrontmatter(s *skill.Skill, opts Options) []types.Result {
 			allStrings := true
 			for k, v := range m {
 				if _, ok := v.(string); !ok {
-					results = append(results, ctx.Errorf("metadata[%q] value must be a string", k))
+					r := ctx.Errorf("metadata[%q] value must be a string", k)
+					results = append(results, atField(s, []types.Result{r}, "metadata", k)...)
 					allStrings = false
 				}
 			}
 			if allStrings {
-				results = append(results, ctx.Passf("metadata: (%d entries)", len(m)))
+				results = append(results, atField(s, []types.Result{ctx.Passf("metadata: (%d entries)", len(m))}, "metadata")...)
 			}
 		} else {
-			results = append(results, ctx.Error("metadata must be a map of string keys to string values"))
+			results = append(results, atField(s, []types.Result{ctx.Error("metadata must be a map of string keys to string values")}, "metadata")...)
 		}
 	}
 
 	// Check optional allowed-tools
 	if !s.Frontmatter.AllowedTools.IsEmpty() {
-		results = append(results, ctx.Passf("allowed-tools: %q", s.Frontmatter.AllowedTools.Value))
+		toolResults := []types.Result{ctx.Passf("allowed-tools: %q", s.Frontmatter.AllowedTools.Value)}
 		if s.Frontmatter.AllowedTools.WasList {
-			results = append(results, ctx.Info("allowed-tools is a YAML list; the spec defines this as a space-delimited string — both are accepted, but a string is more portable across agent implementations"))
+			toolResults = append(toolResults, ctx.Info("allowed-tools is a YAML list; the spec defines this as a space-delimited string — both are accepted, but a string is more portable across agent implementations"))
 		}
+		toolResults = append(toolResults, checkAllowedTools(ctx, s, opts)...)
+		results = append(results, atField(s, toolResults, "allowed-tools")...)
 	}
 
-	// Warn on unrecognized fields (unless extra frontmatter is allowed)
-	if !opts.AllowExtraFrontmatter {
+	// Check platform extensions and warn on unrecognized fields (unless
+	// extra frontmatter is allowed)
+	if opts.Profile != "" {
+		results = append(results, checkProfileFields(ctx, s, opts)...)
+	} else if !opts.AllowExtraFrontmatter {
 		for _, field := range s.UnrecognizedFields() {
-			results = append(results, ctx.Warnf("unrecognized field: %q", field))
+			results = append(results, atField(s, []types.Result{ctx.Warnf("unrecognized field: %q", field)}, field)...)
 		}
 	}
 
+	// Validate against the user-supplied schema
+	if opts.FrontmatterSchema != nil {
+		results = append(results, atField(s, checkFrontmatterSchema(ctx, s, opts.FrontmatterSchema))...)
+	}
+
+	return results
+}
+
+// atField places results that have no line of their own at the frontmatter
+// field at path, so annotations land on the offending line rather than the
+// file. A missing field falls back to its parent, or the opening "---".
+func atField(s *skill.Skill, results []types.Result, path ...string) []types.Result {
+	line, col := s.FieldPosition(path...)
+	for i := range results {
+		if results[i].File == "SKILL.md" && results[i].Line == 0 {
+			results[i].Line, results[i].Column = line, col
+		}
+	}
 	return results
 }
 
@@ -440,3 +440,44 @@ func TestCheckFrontmatter_AllowExtraFrontmatter(t *testing.T) {
 		}
 	}
 }
+
+func TestCheckFrontmatter_Positions(t *testing.T) {
+	s := loadTestSkill(t, "---\nname: My-Skill\ndescription: d\nlicense: NotALicense\nmetadata:\n  owner: alice\n  count: 3\ncustom: x\n---\nBody\n")
+	results := CheckFrontmatter(s, Options{})
+	want := map[string][2]int{
+		`name "My-Skill" must be lowercase`: {2, 1},
+		`description: (1 chars)`:            {3, 1},
+		`NotALicense`:                       {4, 1},
+		`metadata["count"] value`:           {7, 3},
+		`unrecognized field: "custom"`:      {8, 1},
+	}
+	for substr, pos := range want {
+		found := false
+		for _, r := range results {
+			if strings.Contains(r.Message, substr) {
+				found = true
+				if r.Line != pos[0] || r.Column != pos[1] {
+					t.Errorf("%q at %d:%d, want %d:%d", r.Message, r.Line, r.Column, pos[0], pos[1])
+				}
+			}
+		}
+		if !found {
+			t.Errorf("no result containing %q", substr)
+		}
+	}
+	for _, r := range results {
+		if r.Line == 0 {
+			t.Errorf("result without a line: %+v", r)
+		}
+	}
+}
+
+func TestCheckFrontmatter_MissingFieldAtOpening(t *testing.T) {
+	s := loadTestSkill(t, "---\nlicense: MIT\n---\nBody\n")
+	results := CheckFrontmatter(s, Options{})
+	for _, r := range results {
+		if strings.HasSuffix(r.Message, "is required") && r.Line != 1 {
+			t.Errorf("%q at line %d, want 1", r.Message, r.Line)
+		}
+	}
+}
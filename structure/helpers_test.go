@@ -60,6 +60,44 @@ func requireResultContaining(t *testing.T, results []types.Result, level types.L
 	}
 }
 
+// requireFileResult asserts that at least one result at file has the given level and message containing substr.
+func requireFileResult(t *testing.T, results []types.Result, level types.Level, file, substr string) {
+	t.Helper()
+	for _, r := range results {
+		if r.Level == level && r.File == file && strings.Contains(r.Message, substr) {
+			return
+		}
+	}
+	t.Errorf("expected result at %s with level=%d message containing %q, got:", file, level, substr)
+	for _, r := range results {
+		t.Logf("  level=%d category=%s file=%s message=%q", r.Level, r.Category, r.File, r.Message)
+	}
+}
+
+// requireResultAtLine asserts that at least one result at file and line has the given level and message containing substr.
+func requireResultAtLine(t *testing.T, results []types.Result, level types.Level, file string, line int, substr string) {
+	t.Helper()
+	for _, r := range results {
+		if r.Level == level && r.File == file && r.Line == line && strings.Contains(r.Message, substr) {
+			return
+		}
+	}
+	t.Errorf("expected result at %s:%d with level=%d message containing %q, got:", file, line, level, substr)
+	for _, r := range results {
+		t.Logf("  level=%d category=%s at=%s:%d message=%q", r.Level, r.Category, r.File, r.Line, r.Message)
+	}
+}
+
+// requireNoFileResult asserts no result at file has the given level with message containing substr.
+func requireNoFileResult(t *testing.T, results []types.Result, level types.Level, file, substr string) {
+	t.Helper()
+	for _, r := range results {
+		if r.Level == level && r.File == file && strings.Contains(r.Message, substr) {
+			t.Errorf("unexpected result at %s with level=%d message containing %q: %q", file, level, substr, r.Message)
+		}
+	}
+}
+
 // requireNoLevel asserts that no result has the given level.
 func requireNoLevel(t *testing.T, results []types.Result, level types.Level) {
 	t.Helper()
@@ -0,0 +1,381 @@
+package structure
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/agent-ecosystem/skill-validator/skill"
+	"github.com/agent-ecosystem/skill-validator/types"
+)
+
+// licenseExpr is a parsed SPDX license expression. Leaves hold a license
+// identifier (with any "+" suffix) and an optional exception; inner nodes
+// combine their operands with AND or OR.
+type licenseExpr struct {
+	op        string // "AND", "OR", or "" for a leaf
+	id        string
+	exception string
+	operands  []*licenseExpr
+}
+
+var (
+	licenseRefPattern  = regexp.MustCompile(`^(?:DocumentRef-[A-Za-z0-9.-]+:)?LicenseRef-[A-Za-z0-9.-]+$`)
+	licenseIDPattern   = regexp.MustCompile(`^[A-Za-z0-9.-]+\+?$`)
+	licenseFilePattern = regexp.MustCompile(`(?i)^(?:licen[cs]e|copying|notice)(?:[.-].*)?$`)
+)
+
+// parseLicenseExpression parses an SPDX license expression. Operators may be
+// written in upper or lower case; identifiers are not checked against the
+// license list.
+func parseLicenseExpression(s string) (*licenseExpr, error) {
+	p := &licenseParser{tokens: tokenizeLicense(s)}
+	if len(p.tokens) == 0 {
+		return nil, fmt.Errorf("expression is empty")
+	}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok, ok := p.peek(); ok {
+		return nil, fmt.Errorf("unexpected %q", tok)
+	}
+	return expr, nil
+}
+
+// tokenizeLicense splits an expression into parentheses and words.
+func tokenizeLicense(s string) []string {
+	s = strings.NewReplacer("(", " ( ", ")", " ) ").Replace(s)
+	return strings.Fields(s)
+}
+
+type licenseParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *licenseParser) peek() (string, bool) {
+	if p.pos >= len(p.tokens) {
+		return "", false
+	}
+	return p.tokens[p.pos], true
+}
+
+// accept consumes the next token if it is the operator op.
+func (p *licenseParser) accept(op string) bool {
+	if tok, ok := p.peek(); ok && (tok == op || tok == strings.ToLower(op)) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *licenseParser) parseOr() (*licenseExpr, error) {
+	return p.parseBinary("OR", p.parseAnd)
+}
+
+func (p *licenseParser) parseAnd() (*licenseExpr, error) {
+	return p.parseBinary("AND", p.parseWith)
+}
+
+// parseBinary parses operands joined by op. AND binds tighter than OR.
+func (p *licenseParser) parseBinary(op string, operand func() (*licenseExpr, error)) (*licenseExpr, error) {
+	first, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	operands := []*licenseExpr{first}
+	for p.accept(op) {
+		next, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, next)
+	}
+	if len(operands) == 1 {
+		return first, nil
+	}
+	return &licenseExpr{op: op, operands: operands}, nil
+}
+
+func (p *licenseParser) parseWith() (*licenseExpr, error) {
+	expr, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	if !p.accept("WITH") {
+		return expr, nil
+	}
+	if expr.op != "" {
+		return nil, fmt.Errorf("WITH must follow a single license identifier")
+	}
+	exc, ok := p.peek()
+	if !ok || !licenseIDPattern.MatchString(exc) || strings.HasSuffix(exc, "+") || isLicenseOperator(exc) {
+		return nil, fmt.Errorf("expected an exception identifier after WITH")
+	}
+	p.pos++
+	expr.exception = exc
+	return expr, nil
+}
+
+func (p *licenseParser) parsePrimary() (*licenseExpr, error) {
+	tok, ok := p.peek()
+	switch {
+	case !ok:
+		return nil, fmt.Errorf("expression ends unexpectedly")
+	case tok == "(":
+		p.pos++
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if tok, _ := p.peek(); tok != ")" {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		p.pos++
+		return expr, nil
+	case tok == ")" || isLicenseOperator(tok):
+		return nil, fmt.Errorf("unexpected %q", tok)
+	case !licenseRefPattern.MatchString(tok) && !licenseIDPattern.MatchString(tok):
+		return nil, fmt.Errorf("%q is not a valid license identifier", tok)
+	}
+	p.pos++
+	if next, ok := p.peek(); ok && next != ")" && !isLicenseOperator(next) {
+		return nil, fmt.Errorf("expected AND, OR, or WITH between %q and %q", tok, next)
+	}
+	return &licenseExpr{id: tok}, nil
+}
+
+func isLicenseOperator(tok string) bool {
+	switch tok {
+	case "AND", "OR", "WITH", "and", "or", "with":
+		return true
+	}
+	return false
+}
+
+// leaves returns the license identifiers of the expression in order.
+func (e *licenseExpr) leaves() []*licenseExpr {
+	if e.op == "" {
+		return []*licenseExpr{e}
+	}
+	var out []*licenseExpr
+	for _, o := range e.operands {
+		out = append(out, o.leaves()...)
+	}
+	return out
+}
+
+// satisfiedBy reports whether the expression can be met using only the
+// allowed licenses: every operand of an AND, or any operand of an OR. A leaf
+// with an exception is allowed if either the bare license or the full
+// "<license> WITH <exception>" form is allowed. Comparison ignores case.
+func (e *licenseExpr) satisfiedBy(allowed map[string]bool) bool {
+	switch e.op {
+	case "AND":
+		for _, o := range e.operands {
+			if !o.satisfiedBy(allowed) {
+				return false
+			}
+		}
+		return true
+	case "OR":
+		for _, o := range e.operands {
+			if o.satisfiedBy(allowed) {
+				return true
+			}
+		}
+		return false
+	}
+	if allowed[strings.ToLower(e.id)] {
+		return true
+	}
+	return e.exception != "" && allowed[strings.ToLower(e.id+" WITH "+e.exception)]
+}
+
+// checkLicense validates the license field. An SPDX expression is checked
+// against the embedded license list; anything else must refer to a license
+// file bundled with the skill. When opts.AllowedLicenses is set, the
+// expression must be satisfiable using only those licenses.
+func checkLicense(ctx types.ResultContext, s *skill.Skill, opts Options) []types.Result {
+	license := strings.TrimSpace(s.Frontmatter.License)
+	allowed := make(map[string]bool, len(opts.AllowedLicenses))
+	for _, l := range opts.AllowedLicenses {
+		allowed[strings.ToLower(strings.Join(strings.Fields(l), " "))] = true
+	}
+
+	if license == "" {
+		if len(allowed) > 0 {
+			return []types.Result{ctx.Errorf("license is required by the allowed-license policy (allowed: %s)",
+				strings.Join(opts.AllowedLicenses, ", "))}
+		}
+		return nil
+	}
+
+	expr, parseErr := parseLicenseExpression(license)
+	if parseErr != nil || namesLicenseFile(expr, license) {
+		results := checkLicenseFileRefs(ctx, s.Dir, license, parseErr)
+		if len(allowed) > 0 {
+			results = append(results, ctx.Errorf("license %q cannot be checked against the allowed-license policy; use an SPDX expression", license))
+		}
+		return results
+	}
+
+	var results []types.Result
+	for _, leaf := range expr.leaves() {
+		results = append(results, checkLicenseID(ctx, leaf.id)...)
+		if leaf.exception != "" {
+			results = append(results, checkLicenseException(ctx, leaf.exception)...)
+		}
+	}
+	if len(allowed) > 0 && !expr.satisfiedBy(allowed) {
+		results = append(results, ctx.Errorf("license %q is not allowed by policy (allowed: %s)",
+			license, strings.Join(opts.AllowedLicenses, ", ")))
+	}
+	if len(results) == 0 {
+		results = append(results, ctx.Passf("license: %q", license))
+	}
+	return results
+}
+
+// namesLicenseFile reports whether expr, parsed from license, is a bare file
+// reference such as "LICENSE.txt" that happens to look like an identifier:
+// a single leaf that is not on the SPDX License List and that
+// licenseFileRefs recognises as a file.
+func namesLicenseFile(expr *licenseExpr, license string) bool {
+	if expr.op != "" || expr.exception != "" || licenseRefPattern.MatchString(expr.id) {
+		return false
+	}
+	base := strings.ToLower(strings.TrimSuffix(expr.id, "+"))
+	if _, ok := spdxLicenses[base]; ok {
+		return false
+	}
+	if _, ok := spdxDeprecatedLicenses[base]; ok {
+		return false
+	}
+	return len(licenseFileRefs(license)) > 0
+}
+
+// checkLicenseID reports an identifier that is deprecated or not on the SPDX
+// License List. LicenseRef- identifiers name custom licenses and are accepted.
+func checkLicenseID(ctx types.ResultContext, id string) []types.Result {
+	if licenseRefPattern.MatchString(id) {
+		return nil
+	}
+	base := strings.TrimSuffix(id, "+")
+	if _, ok := spdxLicenses[strings.ToLower(base)]; ok {
+		return nil
+	}
+	if canonical, ok := spdxDeprecatedLicenses[strings.ToLower(id)]; ok {
+		return []types.Result{ctx.Warnf("license: %s is a deprecated SPDX identifier; use %s", canonical, deprecatedReplacement(canonical))}
+	}
+	if canonical, ok := spdxDeprecatedLicenses[strings.ToLower(base)]; ok {
+		return []types.Result{ctx.Warnf("license: %s is a deprecated SPDX identifier; use %s", canonical, deprecatedReplacement(canonical))}
+	}
+	if suggestion := closestSPDX(spdxLicenses, base); suggestion != "" {
+		return []types.Result{ctx.Warnf("license: %q is not on the SPDX License List (%s) (did you mean %q?)", id, spdxListVersion, suggestion)}
+	}
+	return []types.Result{ctx.Warnf("license: %q is not on the SPDX License List (%s); use an SPDX identifier or LicenseRef-<name> for a custom license", id, spdxListVersion)}
+}
+
+// checkLicenseException reports a WITH exception that is deprecated or not on
+// the SPDX exceptions list.
+func checkLicenseException(ctx types.ResultContext, exc string) []types.Result {
+	if _, ok := spdxExceptions[strings.ToLower(exc)]; ok {
+		return nil
+	}
+	if canonical, ok := spdxDeprecatedExceptions[strings.ToLower(exc)]; ok {
+		return []types.Result{ctx.Warnf("license: %s is a deprecated SPDX exception; use %s", canonical, deprecatedReplacement(canonical))}
+	}
+	if suggestion := closestSPDX(spdxExceptions, exc); suggestion != "" {
+		return []types.Result{ctx.Warnf("license: %q is not on the SPDX exceptions list (did you mean %q?)", exc, suggestion)}
+	}
+	return []types.Result{ctx.Warnf("license: %q is not on the SPDX exceptions list", exc)}
+}
+
+// deprecatedReplacement returns the expression that replaces a deprecated
+// SPDX identifier. The GNU licenses were split into -only and -or-later
+// variants; the rest come from spdxReplacements.
+func deprecatedReplacement(id string) string {
+	if r, ok := spdxReplacements[id]; ok {
+		return r
+	}
+	if base, ok := strings.CutSuffix(id, "+"); ok {
+		return base + "-or-later"
+	}
+	if _, ok := spdxLicenses[strings.ToLower(id+"-only")]; ok {
+		return id + "-only or " + id + "-or-later"
+	}
+	return "a current SPDX identifier"
+}
+
+// closestSPDX returns the identifier in index nearest to id, or "" if none is
+// a likely typo (a case or punctuation difference, or about one edit per four
+// characters).
+func closestSPDX(index map[string]string, id string) string {
+	lower := strings.ToLower(id)
+	squash := strings.NewReplacer("-", "", ".", "", " ", "")
+	best, bestDist := "", max(1, len(id)/4)+1
+	for l, canonical := range index {
+		if squash.Replace(l) == squash.Replace(lower) {
+			return canonical
+		}
+		if d := editDistance(l, lower); d < bestDist || (d == bestDist && best != "" && canonical < best) {
+			best, bestDist = canonical, d
+		}
+	}
+	return best
+}
+
+// checkLicenseFileRefs handles a license value that is not an SPDX
+// expression. The spec allows referring to a bundled license file, as in
+// "Proprietary. LICENSE.txt has complete terms"; each referenced file must
+// exist inside the skill. A value with no file reference is a warning.
+func checkLicenseFileRefs(ctx types.ResultContext, dir, license string, parseErr error) []types.Result {
+	refs := licenseFileRefs(license)
+	if len(refs) == 0 {
+		if suggestion := closestSPDX(spdxLicenses, license); suggestion != "" {
+			return []types.Result{ctx.Warnf("license %q is not a valid SPDX expression (%v) (did you mean %q?)", license, parseErr, suggestion)}
+		}
+		return []types.Result{ctx.Warnf("license %q is not a valid SPDX expression (%v); use an SPDX identifier such as MIT, or refer to a bundled license file, e.g. \"see LICENSE.txt\"",
+			license, parseErr)}
+	}
+	var results []types.Result
+	for _, ref := range refs {
+		clean := filepath.Clean(filepath.FromSlash(ref))
+		if filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+			results = append(results, ctx.Errorf("license refers to %s, which is outside the skill directory", ref))
+			continue
+		}
+		info, err := os.Stat(filepath.Join(dir, clean))
+		if err != nil || info.IsDir() {
+			results = append(results, ctx.Errorf("license refers to %s, which does not exist in the skill", ref))
+		}
+	}
+	if len(results) == 0 {
+		results = append(results, ctx.Passf("license: %q (see %s)", license, strings.Join(refs, ", ")))
+	}
+	return results
+}
+
+// licenseFileRefs returns the words of a free-text license value that name a
+// file: paths, files with a .txt, .md, or .rst extension, and LICENSE,
+// COPYING, or NOTICE files.
+func licenseFileRefs(license string) []string {
+	var refs []string
+	for _, word := range strings.Fields(license) {
+		word = strings.Trim(word, "\"'`()[]<>,;:")
+		word = strings.TrimRight(word, ".")
+		if word == "" || strings.Contains(word, "://") {
+			continue
+		}
+		ext := strings.ToLower(filepath.Ext(word))
+		if strings.Contains(word, "/") || ext == ".txt" || ext == ".md" || ext == ".rst" ||
+			(licenseFilePattern.MatchString(word) && word == strings.ToUpper(word)) {
+			refs = append(refs, word)
+		}
+	}
+	return refs
+}
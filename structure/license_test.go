@@ -0,0 +1,209 @@
+package structure
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/agent-ecosystem/skill-validator/types"
+)
+
+func TestParseLicenseExpression(t *testing.T) {
+	valid := []string{
+		"MIT",
+		"GPL-2.0-or-later",
+		"Apache-2.0 OR MIT",
+		"(MIT OR Apache-2.0) AND BSD-3-Clause",
+		"GPL-2.0-only WITH Classpath-exception-2.0",
+		"LicenseRef-Acme-Internal",
+		"DocumentRef-spdx-tool-1.2:LicenseRef-MIT-Style-2",
+		"mit or apache-2.0",
+		"EPL-1.0+",
+	}
+	for _, s := range valid {
+		if _, err := parseLicenseExpression(s); err != nil {
+			t.Errorf("parseLicenseExpression(%q) = %v, want no error", s, err)
+		}
+	}
+
+	invalid := map[string]string{
+		"":                    "expression is empty",
+		"MIT AND":             "expression ends unexpectedly",
+		"(MIT OR Apache-2.0":  "missing closing parenthesis",
+		"MIT Apache-2.0":      `expected AND, OR, or WITH between "MIT" and "Apache-2.0"`,
+		"MIT)":                `unexpected ")"`,
+		"AND MIT":             `unexpected "AND"`,
+		"(MIT OR BSD) WITH X": "WITH must follow a single license identifier",
+		"GPL-2.0-only WITH":   "expected an exception identifier after WITH",
+		"MIT, Apache-2.0":     `"MIT," is not a valid license identifier`,
+	}
+	for s, want := range invalid {
+		_, err := parseLicenseExpression(s)
+		if err == nil || err.Error() != want {
+			t.Errorf("parseLicenseExpression(%q) error = %v, want %q", s, err, want)
+		}
+	}
+}
+
+func TestLicenseExprSatisfiedBy(t *testing.T) {
+	allowed := map[string]bool{"mit": true, "apache-2.0": true, "gpl-2.0-only with classpath-exception-2.0": true}
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{"MIT", true},
+		{"mit", true},
+		{"GPL-3.0-only", false},
+		{"MIT OR GPL-3.0-only", true},
+		{"MIT AND GPL-3.0-only", false},
+		{"(MIT OR GPL-3.0-only) AND Apache-2.0", true},
+		{"GPL-2.0-only WITH Classpath-exception-2.0", true},
+		{"GPL-2.0-only", false},
+	}
+	for _, tt := range tests {
+		expr, err := parseLicenseExpression(tt.expr)
+		if err != nil {
+			t.Fatalf("parseLicenseExpression(%q): %v", tt.expr, err)
+		}
+		if got := expr.satisfiedBy(allowed); got != tt.want {
+			t.Errorf("satisfiedBy(%q) = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestCheckFrontmatter_License(t *testing.T) {
+	check := func(dir, license string, opts Options) []types.Result {
+		s := makeSkill(dir, "my-skill", "desc")
+		s.Frontmatter.License = license
+		return checkLicense(types.ResultContext{Category: "Frontmatter", File: "SKILL.md"}, s, opts)
+	}
+
+	t.Run("no license", func(t *testing.T) {
+		if results := check(t.TempDir(), "", Options{}); results != nil {
+			t.Errorf("expected nil, got %+v", results)
+		}
+	})
+
+	t.Run("valid expression", func(t *testing.T) {
+		results := check(t.TempDir(), "(MIT OR Apache-2.0) AND BSD-3-Clause", Options{})
+		requireResult(t, results, types.Pass, `license: "(MIT OR Apache-2.0) AND BSD-3-Clause"`)
+		requireNoLevel(t, results, types.Warning)
+	})
+
+	t.Run("deprecated identifier", func(t *testing.T) {
+		results := check(t.TempDir(), "GPL-2.0", Options{})
+		requireResult(t, results, types.Warning, "license: GPL-2.0 is a deprecated SPDX identifier; use GPL-2.0-only or GPL-2.0-or-later")
+		results = check(t.TempDir(), "LGPL-2.1+", Options{})
+		requireResult(t, results, types.Warning, "license: LGPL-2.1+ is a deprecated SPDX identifier; use LGPL-2.1-or-later")
+		results = check(t.TempDir(), "wxWindows", Options{})
+		requireResult(t, results, types.Warning, "license: wxWindows is a deprecated SPDX identifier; use GPL-2.0-or-later WITH WxWindows-exception-3.1")
+	})
+
+	t.Run("unknown identifier with suggestion", func(t *testing.T) {
+		results := check(t.TempDir(), "Apach-2.0", Options{})
+		requireResult(t, results, types.Warning, `license: "Apach-2.0" is not on the SPDX License List (`+spdxListVersion+`) (did you mean "Apache-2.0"?)`)
+		requireNoLevel(t, results, types.Pass)
+	})
+
+	t.Run("unknown identifier", func(t *testing.T) {
+		results := check(t.TempDir(), "Proprietary", Options{})
+		requireResultContaining(t, results, types.Warning, "use an SPDX identifier or LicenseRef-<name> for a custom license")
+	})
+
+	t.Run("unknown exception", func(t *testing.T) {
+		results := check(t.TempDir(), "GPL-2.0-only WITH Classpath-exception", Options{})
+		requireResult(t, results, types.Warning, `license: "Classpath-exception" is not on the SPDX exceptions list (did you mean "Classpath-exception-2.0"?)`)
+	})
+
+	t.Run("free text with suggestion", func(t *testing.T) {
+		results := check(t.TempDir(), "Apache 2.0", Options{})
+		requireResultContaining(t, results, types.Warning, `is not a valid SPDX expression`)
+		requireResultContaining(t, results, types.Warning, `(did you mean "Apache-2.0"?)`)
+	})
+
+	t.Run("license file reference", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, "LICENSE.txt", "All rights reserved.\n")
+		results := check(dir, "Proprietary. LICENSE.txt has complete terms", Options{})
+		requireResult(t, results, types.Pass, `license: "Proprietary. LICENSE.txt has complete terms" (see LICENSE.txt)`)
+	})
+
+	t.Run("license file in a subdirectory", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, "assets/license.md", "terms\n")
+		results := check(dir, "See assets/license.md.", Options{})
+		requireNoLevel(t, results, types.Error)
+	})
+
+	t.Run("missing license file", func(t *testing.T) {
+		results := check(t.TempDir(), "see LICENSE.txt", Options{})
+		requireResult(t, results, types.Error, "license refers to LICENSE.txt, which does not exist in the skill")
+	})
+
+	t.Run("bare license file name", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, "LICENSE.txt", "All rights reserved.\n")
+		writeFile(t, dir, "LICENSE", "All rights reserved.\n")
+		for _, license := range []string{"LICENSE.txt", "LICENSE"} {
+			results := check(dir, license, Options{})
+			requireResult(t, results, types.Pass, fmt.Sprintf("license: %q (see %s)", license, license))
+			requireNoLevel(t, results, types.Warning)
+		}
+	})
+
+	t.Run("missing bare license file", func(t *testing.T) {
+		for _, license := range []string{"LICENSE.txt", "LICENSE"} {
+			results := check(t.TempDir(), license, Options{})
+			requireResult(t, results, types.Error, "license refers to "+license+", which does not exist in the skill")
+			requireNoLevel(t, results, types.Warning)
+		}
+	})
+
+	t.Run("license file outside the skill", func(t *testing.T) {
+		results := check(t.TempDir(), "see ../LICENSE", Options{})
+		requireResult(t, results, types.Error, "license refers to ../LICENSE, which is outside the skill directory")
+	})
+
+	t.Run("policy allows", func(t *testing.T) {
+		results := check(t.TempDir(), "MIT OR GPL-3.0-only", Options{AllowedLicenses: []string{"MIT", "Apache-2.0"}})
+		requireNoLevel(t, results, types.Error)
+	})
+
+	t.Run("policy rejects", func(t *testing.T) {
+		results := check(t.TempDir(), "GPL-3.0-only", Options{AllowedLicenses: []string{"MIT", "Apache-2.0"}})
+		requireResult(t, results, types.Error, `license "GPL-3.0-only" is not allowed by policy (allowed: MIT, Apache-2.0)`)
+	})
+
+	t.Run("policy requires a license", func(t *testing.T) {
+		results := check(t.TempDir(), "", Options{AllowedLicenses: []string{"MIT"}})
+		requireResult(t, results, types.Error, "license is required by the allowed-license policy (allowed: MIT)")
+	})
+
+	t.Run("policy rejects free text", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, "LICENSE.txt", "terms\n")
+		results := check(dir, "see LICENSE.txt", Options{AllowedLicenses: []string{"MIT"}})
+		requireResult(t, results, types.Error, `license "see LICENSE.txt" cannot be checked against the allowed-license policy; use an SPDX expression`)
+	})
+}
+
+func TestDeprecatedSPDXIdentifiers(t *testing.T) {
+	// Every deprecated identifier has a replacement that is on the current
+	// list, so suggestions never point at another deprecated id.
+	for _, id := range spdxDeprecatedLicenses {
+		if id == "Net-SNMP" {
+			continue // split into several licenses with no single replacement
+		}
+		r := deprecatedReplacement(id)
+		for _, tok := range tokenizeLicense(r) {
+			if isLicenseOperator(tok) {
+				continue
+			}
+			_, lic := spdxLicenses[strings.ToLower(tok)]
+			_, exc := spdxExceptions[strings.ToLower(tok)]
+			if !lic && !exc {
+				t.Errorf("replacement %q for %s contains unknown identifier %q", r, id, tok)
+			}
+		}
+	}
+}
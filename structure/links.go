@@ -11,17 +11,50 @@ import (
 
 // CheckInternalLinks validates relative (internal) links in the skill body.
 // Broken internal links indicate a structural problem: the skill references
-// files that don't exist in the package.
-func CheckInternalLinks(dir, body string) []types.Result {
-	ctx := types.ResultContext{Category: "Structure", File: "SKILL.md"}
-	allLinks := links.ExtractLinks(body)
+// files that don't exist in the package. lineOffset is the number of SKILL.md
+// lines before the body, added to link lines so they match the file.
+func CheckInternalLinks(dir, body string, lineOffset int) []types.Result {
+	return checkInternalLinksIn(dir, "SKILL.md", body, lineOffset)
+}
+
+// CheckInternalLinksInFiles validates relative (internal) links in every
+// markdown file under references/ and assets/. Links are resolved from the
+// directory of the file that contains them, so references/guide.md linking to
+// api.md points at references/api.md.
+func CheckInternalLinksInFiles(dir string) []types.Result {
+	var results []types.Result
+	for _, rel := range links.MarkdownFiles(dir) {
+		data, err := os.ReadFile(filepath.Join(dir, rel))
+		if err != nil {
+			continue
+		}
+		results = append(results, checkInternalLinksIn(dir, rel, string(data), 0)...)
+	}
+	return results
+}
+
+// checkInternalLinksIn validates the relative links found in content, which
+// was read from file (relative to the skill dir) starting after lineOffset
+// lines. Results for files other than SKILL.md are prefixed with the file
+// path.
+func checkInternalLinksIn(dir, file, content string, lineOffset int) []types.Result {
+	ctx := types.ResultContext{Category: "Structure", File: file}
+	allLinks := links.ExtractLinkLocations(content)
 	if len(allLinks) == 0 {
 		return nil
 	}
 
+	prefix := ""
+	if file != "SKILL.md" {
+		prefix = file + ": "
+	}
+	baseDir := filepath.Join(dir, filepath.Dir(file))
+	skillDir := filepath.Clean(dir) + string(filepath.Separator)
+
 	var results []types.Result
 
-	for _, link := range allLinks {
+	for _, l := range allLinks {
+		link, line := l.URL, l.Line+lineOffset
 		// Skip template URLs containing {placeholder} variables (RFC 6570 URI Templates)
 		if strings.Contains(link, "{") {
 			continue
@@ -40,16 +73,16 @@ func CheckInternalLinks(dir, body string) []types.Result {
 			continue
 		}
 		// Relative link — check file existence
-		resolved := filepath.Clean(filepath.Join(dir, link))
+		resolved := filepath.Clean(filepath.Join(baseDir, link))
 		// Block path traversal: the resolved path must stay inside the skill directory.
-		if !strings.HasPrefix(resolved, filepath.Clean(dir)+string(filepath.Separator)) {
-			results = append(results, ctx.Errorf("internal link escapes skill directory: %s", link))
+		if !strings.HasPrefix(resolved, skillDir) {
+			results = append(results, ctx.ErrorAtLinef(file, line, "%sinternal link escapes skill directory: %s", prefix, link))
 			continue
 		}
 		if _, err := os.Stat(resolved); os.IsNotExist(err) {
-			results = append(results, ctx.Errorf("broken internal link: %s (file not found)", link))
+			results = append(results, ctx.ErrorAtLinef(file, line, "%sbroken internal link: %s (file not found)", prefix, link))
 		} else {
-			results = append(results, ctx.Passf("internal link: %s (exists)", link))
+			results = append(results, ctx.PassAtLinef(file, line, "%sinternal link: %s (exists)", prefix, link))
 		}
 	}
 
@@ -3,6 +3,7 @@ package structure
 import (
 	"testing"
 
+	"github.com/agent-ecosystem/skill-validator/skill"
 	"github.com/agent-ecosystem/skill-validator/types"
 )
 
@@ -11,21 +12,21 @@ func TestCheckInternalLinks(t *testing.T) {
 		dir := t.TempDir()
 		writeFile(t, dir, "references/guide.md", "content")
 		body := "See [guide](references/guide.md)."
-		results := CheckInternalLinks(dir, body)
+		results := CheckInternalLinks(dir, body, 0)
 		requireResult(t, results, types.Pass, "internal link: references/guide.md (exists)")
 	})
 
 	t.Run("missing file", func(t *testing.T) {
 		dir := t.TempDir()
 		body := "See [guide](references/missing.md)."
-		results := CheckInternalLinks(dir, body)
+		results := CheckInternalLinks(dir, body, 0)
 		requireResult(t, results, types.Error, "broken internal link: references/missing.md (file not found)")
 	})
 
 	t.Run("skips HTTP links", func(t *testing.T) {
 		dir := t.TempDir()
 		body := "[docs](https://example.com/docs)"
-		results := CheckInternalLinks(dir, body)
+		results := CheckInternalLinks(dir, body, 0)
 		if len(results) != 0 {
 			t.Errorf("expected 0 results for HTTP links, got %d", len(results))
 		}
@@ -34,7 +35,7 @@ func TestCheckInternalLinks(t *testing.T) {
 	t.Run("skips mailto and anchors", func(t *testing.T) {
 		dir := t.TempDir()
 		body := "[email](mailto:user@example.com) and [section](#heading)"
-		results := CheckInternalLinks(dir, body)
+		results := CheckInternalLinks(dir, body, 0)
 		if len(results) != 0 {
 			t.Errorf("expected 0 results for mailto/anchor links, got %d", len(results))
 		}
@@ -43,7 +44,7 @@ func TestCheckInternalLinks(t *testing.T) {
 	t.Run("skips template URLs", func(t *testing.T) {
 		dir := t.TempDir()
 		body := "[PR](https://github.com/{OWNER}/{REPO}/pull/{PR})"
-		results := CheckInternalLinks(dir, body)
+		results := CheckInternalLinks(dir, body, 0)
 		if len(results) != 0 {
 			t.Errorf("expected 0 results for template URLs, got %d", len(results))
 		}
@@ -53,21 +54,21 @@ func TestCheckInternalLinks(t *testing.T) {
 		dir := t.TempDir()
 		writeFile(t, dir, "references/guide.md", "# Heading\ncontent")
 		body := "See [config](references/guide.md#heading)."
-		results := CheckInternalLinks(dir, body)
+		results := CheckInternalLinks(dir, body, 0)
 		requireResult(t, results, types.Pass, "internal link: references/guide.md (exists)")
 	})
 
 	t.Run("path traversal is blocked", func(t *testing.T) {
 		dir := t.TempDir()
 		body := "See [escape](../../../../../../etc/passwd)."
-		results := CheckInternalLinks(dir, body)
+		results := CheckInternalLinks(dir, body, 0)
 		requireResult(t, results, types.Error, "internal link escapes skill directory: ../../../../../../etc/passwd")
 	})
 
 	t.Run("no links returns nil", func(t *testing.T) {
 		dir := t.TempDir()
 		body := "No links here."
-		results := CheckInternalLinks(dir, body)
+		results := CheckInternalLinks(dir, body, 0)
 		if results != nil {
 			t.Errorf("expected nil for no links, got %v", results)
 		}
@@ -77,7 +78,7 @@ func TestCheckInternalLinks(t *testing.T) {
 		dir := t.TempDir()
 		writeFile(t, dir, "references/guide.md", "content")
 		body := "[guide](references/guide.md) and [site](https://example.com)"
-		results := CheckInternalLinks(dir, body)
+		results := CheckInternalLinks(dir, body, 0)
 		if len(results) != 1 {
 			t.Fatalf("expected 1 result (internal only), got %d", len(results))
 		}
@@ -87,7 +88,7 @@ func TestCheckInternalLinks(t *testing.T) {
 	t.Run("category is Structure", func(t *testing.T) {
 		dir := t.TempDir()
 		body := "See [guide](references/missing.md)."
-		results := CheckInternalLinks(dir, body)
+		results := CheckInternalLinks(dir, body, 0)
 		if len(results) != 1 {
 			t.Fatalf("expected 1 result, got %d", len(results))
 		}
@@ -96,3 +97,63 @@ func TestCheckInternalLinks(t *testing.T) {
 		}
 	})
 }
+
+func TestCheckInternalLinks_SkillLines(t *testing.T) {
+	dir := t.TempDir()
+	writeSkill(t, dir, "---\nname: "+dirName(dir)+"\ndescription: A test skill.\n---\n# Title\n\nSee [guide](references/missing.md).\n")
+	s, err := skill.Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	results := CheckInternalLinks(dir, s.Body, s.BodyLineOffset())
+	requireResult(t, results, types.Error, "broken internal link: references/missing.md (file not found)")
+	if results[0].Line != 7 {
+		t.Errorf("expected SKILL.md line 7, got %d", results[0].Line)
+	}
+}
+
+func TestCheckInternalLinksInFiles(t *testing.T) {
+	t.Run("resolves relative to the containing file", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, "references/guide.md", "# Guide\n\nSee [api](api.md).")
+		writeFile(t, dir, "references/api.md", "api")
+		results := CheckInternalLinksInFiles(dir)
+		requireResult(t, results, types.Pass, "references/guide.md: internal link: api.md (exists)")
+		if results[0].Line != 3 {
+			t.Errorf("expected line 3, got %d", results[0].Line)
+		}
+	})
+
+	t.Run("broken link in asset markdown", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, "assets/template.md", "[missing](../references/missing.md)")
+		results := CheckInternalLinksInFiles(dir)
+		requireResult(t, results, types.Error, "assets/template.md: broken internal link: ../references/missing.md (file not found)")
+		if results[0].File != "assets/template.md" {
+			t.Errorf("expected file assets/template.md, got %q", results[0].File)
+		}
+	})
+
+	t.Run("skill-root-relative path from reference is broken", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, "references/guide.md", "[other](references/other.md)")
+		writeFile(t, dir, "references/other.md", "other")
+		results := CheckInternalLinksInFiles(dir)
+		requireResultContaining(t, results, types.Error, "broken internal link: references/other.md")
+	})
+
+	t.Run("path traversal is blocked", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, "references/guide.md", "[escape](../../outside.md)")
+		results := CheckInternalLinksInFiles(dir)
+		requireResultContaining(t, results, types.Error, "internal link escapes skill directory: ../../outside.md")
+	})
+
+	t.Run("no markdown files returns nil", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, "scripts/run.sh", "[x](missing.md)")
+		if results := CheckInternalLinksInFiles(dir); results != nil {
+			t.Errorf("expected nil, got %v", results)
+		}
+	})
+}
@@ -0,0 +1,240 @@
+package structure
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/agent-ecosystem/skill-validator/skill"
+	"github.com/agent-ecosystem/skill-validator/types"
+	"github.com/agent-ecosystem/skill-validator/util"
+)
+
+const (
+	// heavyPathShare is the share of a skill's on-demand content above which
+	// a single likely path is reported as loading most of the skill.
+	heavyPathShare = 0.5
+	// heavyPathMinTokens keeps small skills quiet: a path is only reported
+	// when it costs more than the recommended SKILL.md body.
+	heavyPathMinTokens = 5_000
+)
+
+// bulkLoadPatterns match instructions to read a whole directory, e.g. "read
+// all of references/" or "load every reference file". The submatch names
+// the directory; "reference" alone means references/.
+var bulkLoadPatterns = []*regexp.Regexp{
+	regexp.MustCompile("(?i)\\b(?:read|load|review|open|consult|study)\\s+(?:all|every(?:thing)?|each)\\b[^.\\n]{0,40}?`?\\b(references|assets)/"),
+	regexp.MustCompile(`(?i)\b(?:read|load|review|open|consult|study)\s+(?:all|every|each)\s+(?:of\s+)?(?:the\s+)?(reference)(?:s\b|\s+(?:files|docs|documents)\b)`),
+}
+
+// loadGraph is the files an agent can read on demand, with their token
+// counts and the files each one references.
+type loadGraph struct {
+	files  []string // sorted
+	tokens map[string]int
+	edges  map[string][]string
+	root   map[string]bool // root-level files, matched case-insensitively
+}
+
+// CheckLoading simulates progressive disclosure for the skill s in dir: the
+// metadata loaded at startup, the body loaded on activation, and the cost of
+// each path through the files SKILL.md references. It warns when a single
+// likely path, such as an instruction to read all of references/, loads most
+// of the skill's on-demand content. Scripts are run rather than read, so
+// they are not counted.
+func CheckLoading(dir string, s *skill.Skill, opts Options) ([]types.Result, *types.LoadingReport) {
+	ctx := types.ResultContext{Category: "Loading", File: "SKILL.md"}
+	count, err := getCounter(opts.Tokenizer)
+	if err != nil {
+		return []types.Result{ctx.Errorf("failed to initialize tokenizer: %v", err)}, nil
+	}
+
+	lr := &types.LoadingReport{
+		MetadataTokens: metadataTokens(count, s),
+		BodyTokens:     count(s.Body),
+	}
+	g := buildLoadGraph(dir, count)
+	offset := s.BodyLineOffset()
+
+	for _, f := range g.files {
+		if line := g.referenceLine(s.Body, f); line > 0 {
+			lr.Paths = append(lr.Paths, g.path(f, line+offset, []string{f}))
+		}
+	}
+	for i, line := range strings.Split(s.Body, "\n") {
+		for _, re := range bulkLoadPatterns {
+			m := re.FindStringSubmatch(line)
+			if m == nil {
+				continue
+			}
+			d := strings.ToLower(m[1])
+			if d == "reference" {
+				d = "references"
+			}
+			if entries := g.filesIn(d); len(entries) > 0 {
+				lr.Paths = append(lr.Paths, g.path("all of "+d+"/", i+1+offset, entries))
+			}
+			break
+		}
+	}
+
+	reached := map[string]bool{}
+	var typicals []int
+	for _, p := range lr.Paths {
+		for _, f := range p.Files {
+			reached[f] = true
+		}
+		typicals = append(typicals, p.TypicalTokens)
+	}
+	for f := range reached {
+		lr.OnDemandTokens += g.tokens[f]
+	}
+	activation := lr.MetadataTokens + lr.BodyTokens
+	lr.TypicalTokens = activation + median(typicals)
+	lr.WorstCaseTokens = activation + lr.OnDemandTokens
+
+	if len(lr.Paths) == 0 {
+		return []types.Result{ctx.Info("SKILL.md references no files to load on demand; the whole skill loads on activation")}, lr
+	}
+
+	var results []types.Result
+	for _, p := range lr.Paths {
+		if len(reached) < 2 || p.TypicalTokens < heavyPathMinTokens {
+			continue
+		}
+		share := float64(p.TypicalTokens) / float64(lr.OnDemandTokens)
+		if share <= heavyPathShare {
+			continue
+		}
+		what := "reading " + p.Entry
+		if strings.HasPrefix(p.Entry, "all of ") {
+			what = fmt.Sprintf("the instruction to read %s (line %d)", p.Entry, p.Line)
+		}
+		results = append(results, ctx.WarnAtLinef("SKILL.md", p.Line,
+			"%s loads %s tokens%s, %.0f%% of the skill's on-demand content — agents that follow it load most of the skill; "+
+				"point each task at the files it needs instead",
+			what, util.FormatNumber(p.TypicalTokens), opts.windowNote(p.TypicalTokens), share*100))
+	}
+	if len(results) == 0 {
+		results = append(results, ctx.Passf("no single path loads most of the skill (%d path%s, %s on-demand tokens)",
+			len(lr.Paths), util.PluralS(len(lr.Paths)), util.FormatNumber(lr.OnDemandTokens)))
+	}
+	return results, lr
+}
+
+// buildLoadGraph collects the text files under references/ and assets/ and
+// at the skill root, and the references between them.
+func buildLoadGraph(dir string, count tokenCounter) *loadGraph {
+	g := &loadGraph{tokens: map[string]int{}, edges: map[string][]string{}, root: map[string]bool{}}
+	texts := map[string]string{}
+	for _, f := range inventoryFiles(dir) {
+		f = filepath.ToSlash(f)
+		if strings.HasPrefix(f, "scripts/") || strings.Contains("/"+f, "/.") || !isTextFile(filepath.Join(dir, f)) {
+			continue
+		}
+		g.files = append(g.files, f)
+	}
+	for _, f := range rootTextFiles(dir) {
+		if strings.HasPrefix(f, ".") {
+			continue
+		}
+		g.files = append(g.files, f)
+		g.root[f] = true
+	}
+	slices.Sort(g.files)
+
+	for _, f := range g.files {
+		data, err := os.ReadFile(filepath.Join(dir, f))
+		if err != nil {
+			continue
+		}
+		texts[f] = string(data)
+		g.tokens[f] = count(texts[f])
+	}
+	for _, f := range g.files {
+		sourceDir := filepath.Dir(f)
+		if sourceDir == "." {
+			sourceDir = ""
+		}
+		for _, to := range g.files {
+			if to != f && g.references(texts[f], sourceDir, to) {
+				g.edges[f] = append(g.edges[f], to)
+			}
+		}
+	}
+	return g
+}
+
+// references reports whether text, from a file in sourceDir, references the
+// file rel. Root files are matched case-insensitively, as in
+// CheckOrphanFiles.
+func (g *loadGraph) references(text, sourceDir, rel string) bool {
+	if g.root[rel] {
+		return strings.Contains(strings.ToLower(text), strings.ToLower(rel))
+	}
+	return containsReference(text, sourceDir, rel)
+}
+
+// referenceLine returns the 1-based body line that first references rel, or
+// 0 if the body does not reference it.
+func (g *loadGraph) referenceLine(body, rel string) int {
+	if !g.references(body, "", rel) {
+		return 0
+	}
+	for i, line := range strings.Split(body, "\n") {
+		if g.references(line, "", rel) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// filesIn returns the files of the graph under dir.
+func (g *loadGraph) filesIn(dir string) []string {
+	var out []string
+	for _, f := range g.files {
+		if strings.HasPrefix(f, dir+"/") {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// path builds the loading path named entry that starts by reading the files
+// in start: those are its typical cost, and everything reachable from them
+// is its worst case.
+func (g *loadGraph) path(entry string, line int, start []string) types.LoadingPath {
+	p := types.LoadingPath{Entry: entry, Line: line}
+	seen := map[string]bool{}
+	queue := slices.Clone(start)
+	for _, f := range start {
+		seen[f] = true
+		p.TypicalTokens += g.tokens[f]
+	}
+	for len(queue) > 0 {
+		f := queue[0]
+		queue = queue[1:]
+		p.Files = append(p.Files, f)
+		p.WorstCaseTokens += g.tokens[f]
+		for _, to := range g.edges[f] {
+			if !seen[to] {
+				seen[to] = true
+				queue = append(queue, to)
+			}
+		}
+	}
+	return p
+}
+
+// median returns the median of values, or 0 if there are none.
+func median(values []int) int {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	return sorted[len(sorted)/2]
+}
@@ -0,0 +1,109 @@
+package structure
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/agent-ecosystem/skill-validator/types"
+)
+
+func TestCheckLoading(t *testing.T) {
+	t.Run("paths follow references transitively", func(t *testing.T) {
+		s := loadTestSkill(t, "---\nname: my-skill\ndescription: Does things.\n---\n# My Skill\n\nSee references/setup.md to install.\nUse assets/form.md for reports.\nRun scripts/run.sh.\n")
+		dir := s.Dir
+		writeFile(t, dir, "references/setup.md", "# Setup\nDetails are in api.md.\n")
+		writeFile(t, dir, "references/api.md", "# API\nEndpoints.\n")
+		writeFile(t, dir, "references/unused.md", "# Unused\n")
+		writeFile(t, dir, "assets/form.md", "# Form\n")
+		writeFile(t, dir, "scripts/run.sh", "#!/bin/sh\necho hi\n")
+
+		results, lr := CheckLoading(dir, s, Options{})
+		requireResultContaining(t, results, types.Pass, "no single path loads most of the skill (2 paths")
+		if len(lr.Paths) != 2 {
+			t.Fatalf("expected 2 paths, got %+v", lr.Paths)
+		}
+		setup := lr.Paths[1]
+		if setup.Entry != "references/setup.md" || setup.Line != 7 {
+			t.Errorf("unexpected path %+v", setup)
+		}
+		if strings.Join(setup.Files, ",") != "references/setup.md,references/api.md" {
+			t.Errorf("files = %v", setup.Files)
+		}
+		if setup.WorstCaseTokens <= setup.TypicalTokens {
+			t.Errorf("worst case %d should exceed typical %d", setup.WorstCaseTokens, setup.TypicalTokens)
+		}
+		wantOnDemand := lr.Paths[0].WorstCaseTokens + setup.WorstCaseTokens
+		if lr.OnDemandTokens != wantOnDemand {
+			t.Errorf("on-demand = %d, want %d (unreferenced files and scripts excluded)", lr.OnDemandTokens, wantOnDemand)
+		}
+		if lr.WorstCaseTokens != lr.MetadataTokens+lr.BodyTokens+lr.OnDemandTokens {
+			t.Errorf("worst case = %d", lr.WorstCaseTokens)
+		}
+	})
+
+	t.Run("bulk load instruction", func(t *testing.T) {
+		s := loadTestSkill(t, "---\nname: my-skill\ndescription: Does things.\n---\n# My Skill\n\nBefore starting, read all of the files in `references/`.\nSee references/a.md for details.\n")
+		dir := s.Dir
+		writeFile(t, dir, "references/a.md", generateContent(3_000))
+		writeFile(t, dir, "references/b.md", generateContent(3_000))
+		writeFile(t, dir, "references/c.md", generateContent(500))
+
+		results, lr := CheckLoading(dir, s, Options{})
+		requireResultContaining(t, results, types.Warning, "the instruction to read all of references/ (line 7) loads")
+		requireNoResultContaining(t, results, types.Warning, "reading references/a.md")
+		for _, r := range results {
+			if r.Level == types.Warning && r.Line != 7 {
+				t.Errorf("warning at line %d, want 7", r.Line)
+			}
+		}
+		bulk := lr.Paths[len(lr.Paths)-1]
+		if bulk.Entry != "all of references/" || len(bulk.Files) != 3 || bulk.TypicalTokens != lr.OnDemandTokens {
+			t.Errorf("unexpected bulk path %+v", bulk)
+		}
+	})
+
+	t.Run("small skills are not flagged", func(t *testing.T) {
+		s := loadTestSkill(t, "---\nname: my-skill\ndescription: Does things.\n---\nRead every reference file, starting with references/a.md.\n")
+		dir := s.Dir
+		writeFile(t, dir, "references/a.md", "# A\n")
+		writeFile(t, dir, "references/b.md", "# B\n")
+		results, _ := CheckLoading(dir, s, Options{})
+		requireNoLevel(t, results, types.Warning)
+	})
+
+	t.Run("no references", func(t *testing.T) {
+		s := loadTestSkill(t, "---\nname: my-skill\ndescription: Does things.\n---\nJust the body.\n")
+		dir := s.Dir
+		results, lr := CheckLoading(dir, s, Options{})
+		requireResultContaining(t, results, types.Info, "references no files to load on demand")
+		if lr.TypicalTokens != lr.WorstCaseTokens {
+			t.Errorf("typical %d != worst case %d", lr.TypicalTokens, lr.WorstCaseTokens)
+		}
+	})
+}
+
+func TestBulkLoadPatterns(t *testing.T) {
+	tests := []struct {
+		line string
+		want string
+	}{
+		{"Read all of references/ before you begin.", "references"},
+		{"Load every file under `assets/`.", "assets"},
+		{"Review each of the references/ docs.", "references"},
+		{"Read all the reference files first.", "reference"},
+		{"Read references/api.md when calling the API.", ""},
+		{"All references/ files are optional.", ""},
+	}
+	for _, tt := range tests {
+		got := ""
+		for _, re := range bulkLoadPatterns {
+			if m := re.FindStringSubmatch(tt.line); m != nil {
+				got = m[1]
+				break
+			}
+		}
+		if got != tt.want {
+			t.Errorf("%q: got %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
@@ -1,11 +1,34 @@
 package structure
 
 import (
+	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
 
+	"github.com/agent-ecosystem/skill-validator/content"
+	"github.com/agent-ecosystem/skill-validator/skill"
+	"github.com/agent-ecosystem/skill-validator/skillcheck"
 	"github.com/agent-ecosystem/skill-validator/types"
+	"github.com/agent-ecosystem/skill-validator/util"
+)
+
+const (
+	// maxHeadingLevel is the deepest heading level before nesting is
+	// reported.
+	maxHeadingLevel = 4
+	// maxSectionWords is the most words a section without subheadings may
+	// hold before it is reported as too long to navigate.
+	maxSectionWords = 800
+)
+
+var (
+	orderedItemPattern = regexp.MustCompile(`^(\s*)(\d{1,9})[.)](?:\s|$)`)
+	bulletItemPattern  = regexp.MustCompile(`^(\s*)[-*+](?:\s|$)`)
+	anchorStripPattern = regexp.MustCompile(`[^\p{L}\p{N}\s_-]`)
 )
 
 // CheckMarkdown validates markdown structure in the skill.
@@ -53,10 +76,209 @@ func CheckMarkdown(dir, body string) []types.Result {
 	return results
 }
 
+// CheckDocumentStructure lints the heading outline and numbered lists of
+// the SKILL.md body of s and of each markdown file in references/: a
+// missing H1 or more than one, skipped heading levels, headings nested
+// deeper than H4, empty sections, duplicate headings (whose anchors
+// collide), long sections without subheadings, and numbered lists that skip
+// or repeat a step.
+func CheckDocumentStructure(dir string, s *skill.Skill) []types.Result {
+	ctx := types.ResultContext{Category: "Markdown"}
+	results := checkOutline(ctx, "SKILL.md", s.Body, s.BodyLineOffset())
+	results = append(results, checkNumberedLists(ctx, "SKILL.md", s.Body, s.BodyLineOffset())...)
+	refs := skillcheck.ReadReferencesMarkdownFiles(dir)
+	for _, name := range util.SortedKeys(refs) {
+		file := path.Join("references", name)
+		results = append(results, checkOutline(ctx, file, refs[name], 0)...)
+		results = append(results, checkNumberedLists(ctx, file, refs[name], 0)...)
+	}
+
+	for _, r := range results {
+		if r.Level == types.Warning {
+			return results
+		}
+	}
+	return append(results, ctx.Pass("headings and numbered lists are well formed"))
+}
+
+// checkOutline lints the headings of one file. lineOffset is added to
+// lines, so that lines in the SKILL.md body match the file.
+func checkOutline(ctx types.ResultContext, file, text string, lineOffset int) []types.Result {
+	var results []types.Result
+	sections := content.SplitSections(text)
+
+	var h1s []string
+	anchors := map[string]int{}
+	prev := types.SectionReport{}
+	for i, sec := range sections {
+		line := sec.Line + lineOffset
+		label := types.SectionReport{Level: sec.Level, Heading: sec.Heading}.Label()
+		_, sectionText, _ := strings.Cut(sec.Text, "\n")
+		if sec.Level == 0 {
+			sectionText = sec.Text
+		}
+		hasSubsections := sec.Level > 0 && i+1 < len(sections) && sections[i+1].Level > sec.Level
+
+		if sec.Level > 0 {
+			if sec.Level == 1 {
+				h1s = append(h1s, strconv.Itoa(line))
+			}
+			if prev.Level > 0 && sec.Level > prev.Level+1 {
+				results = append(results, ctx.WarnAtLinef(file, line,
+					"%q skips a heading level after %q — use %q",
+					label, prev.Label(), strings.Repeat("#", prev.Level+1)+" "+sec.Heading))
+			}
+			prev = types.SectionReport{Level: sec.Level, Heading: sec.Heading}
+			if sec.Level > maxHeadingLevel {
+				results = append(results, ctx.WarnAtLinef(file, line,
+					"%q is nested %d levels deep — flatten the outline or move the detail into a reference file",
+					label, sec.Level))
+			}
+			anchor := headingAnchor(sec.Heading)
+			if first, ok := anchors[anchor]; ok {
+				results = append(results, ctx.WarnAtLinef(file, line,
+					"heading %q repeats the heading at line %d — both link as #%s, so links reach only the first",
+					sec.Heading, first, anchor))
+			} else {
+				anchors[anchor] = line
+			}
+			if !hasSubsections && strings.TrimSpace(sectionText) == "" {
+				results = append(results, ctx.WarnAtLinef(file, line,
+					"section %q is empty — add content or remove the heading", label))
+			}
+		}
+
+		if words := len(strings.Fields(sectionText)); !hasSubsections && words > maxSectionWords {
+			results = append(results, ctx.WarnAtLinef(file, line,
+				"section %q runs %s words without a subheading — split it so agents can find the part they need",
+				label, util.FormatNumber(words)))
+		}
+	}
+
+	switch {
+	case len(h1s) == 0 && len(sections) > 0:
+		results = append(results, ctx.InfoFilef(file,
+			"document has no H1 heading — a \"# Title\" line tells agents what it covers"))
+	case len(h1s) > 1:
+		results = append(results, ctx.WarnFilef(file,
+			"document has %d H1 headings (lines %s) — use one H1 as the title and H2 for its sections",
+			len(h1s), strings.Join(h1s, ", ")))
+	}
+	return results
+}
+
+// headingAnchor returns the link anchor GitHub generates for a heading:
+// lowercased, punctuation removed, and spaces turned into hyphens.
+func headingAnchor(heading string) string {
+	s := anchorStripPattern.ReplaceAllString(strings.ToLower(strings.TrimSpace(heading)), "")
+	return strings.ReplaceAll(s, " ", "-")
+}
+
+// numberedList is an ordered list being read: the indentation of its
+// markers, and the line and number of each item.
+type numberedList struct {
+	indent  int
+	lines   []int
+	numbers []int
+}
+
+// checkNumberedLists reports numbered lists that skip or repeat a step, or
+// count backwards. A list numbered with one repeated number throughout,
+// such as "1." on every item, is renumbered by markdown and passes.
+// lineOffset is added to lines.
+func checkNumberedLists(ctx types.ResultContext, file, text string, lineOffset int) []types.Result {
+	var results []types.Result
+	var open []*numberedList
+	closeFrom := func(indent int) {
+		for len(open) > 0 && open[len(open)-1].indent >= indent {
+			if msg := numberingProblem(open[len(open)-1], lineOffset); msg != "" {
+				l := open[len(open)-1]
+				results = append(results, ctx.WarnAtLinef(file, l.lines[0]+lineOffset,
+					"numbered list %s", msg))
+			}
+			open = open[:len(open)-1]
+		}
+	}
+
+	fence := byte(0)
+	fenceLen := 0
+	for i, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+		ch, n := content.FencePrefix(trimmed)
+		if fence != 0 {
+			if content.ClosesFence(trimmed, fence, fenceLen) {
+				fence = 0
+			}
+			continue
+		}
+		if n > 0 {
+			// A fence indented under an item belongs to it.
+			closeFrom(indent)
+			fence, fenceLen = ch, n
+			continue
+		}
+		if trimmed == "" {
+			continue
+		}
+
+		if m := orderedItemPattern.FindStringSubmatch(line); m != nil {
+			indent = len(m[1])
+			closeFrom(indent + 1)
+			n, _ := strconv.Atoi(m[2])
+			if len(open) == 0 || open[len(open)-1].indent != indent {
+				open = append(open, &numberedList{indent: indent})
+			}
+			l := open[len(open)-1]
+			l.lines = append(l.lines, i+1)
+			l.numbers = append(l.numbers, n)
+			continue
+		}
+		if m := bulletItemPattern.FindStringSubmatch(line); m != nil {
+			closeFrom(len(m[1]))
+			continue
+		}
+		if indent == 0 || strings.HasPrefix(trimmed, "#") {
+			closeFrom(0)
+			continue
+		}
+		// An indented line continues the items it is indented under.
+		closeFrom(indent)
+	}
+	closeFrom(0)
+	return results
+}
+
+// numberingProblem describes the first place a numbered list skips, repeats,
+// or goes back a step, or returns "" if it counts up by one from its first
+// number or repeats one number throughout.
+func numberingProblem(l *numberedList, lineOffset int) string {
+	same := true
+	for _, n := range l.numbers {
+		same = same && n == l.numbers[0]
+	}
+	if same {
+		return ""
+	}
+	for k := 1; k < len(l.numbers); k++ {
+		prev, n := l.numbers[k-1], l.numbers[k]
+		line := l.lines[k] + lineOffset
+		switch {
+		case n == prev:
+			return fmt.Sprintf("repeats step %d (line %d)", n, line)
+		case n < prev:
+			return fmt.Sprintf("goes back from step %d to %d (line %d)", prev, n, line)
+		case n > prev+1:
+			return fmt.Sprintf("skips from step %d to %d (line %d)", prev, n, line)
+		}
+	}
+	return ""
+}
+
 // FindUnclosedFence checks for unclosed code fences (``` or ~~~).
 // Returns the line number of the unclosed opening fence and true, or 0 and false.
-func FindUnclosedFence(content string) (int, bool) {
-	lines := strings.Split(content, "\n")
+func FindUnclosedFence(text string) (int, bool) {
+	lines := strings.Split(text, "\n")
 	inFence := false
 	fenceChar := byte(0)
 	fenceLen := 0
@@ -74,20 +296,14 @@ func FindUnclosedFence(content string) (int, bool) {
 		}
 
 		if !inFence {
-			if char, n := fencePrefix(stripped); n >= 3 {
+			if char, n := content.FencePrefix(stripped); n >= 3 {
 				inFence = true
 				fenceChar = char
 				fenceLen = n
 				fenceLine = i + 1
 			}
-		} else {
-			if char, n := fencePrefix(stripped); n >= fenceLen && char == fenceChar {
-				// Closing fence: rest must be only whitespace
-				rest := stripped[n:]
-				if strings.TrimSpace(rest) == "" {
-					inFence = false
-				}
-			}
+		} else if content.ClosesFence(stripped, fenceChar, fenceLen) {
+			inFence = false
 		}
 	}
 
@@ -96,23 +312,3 @@ func FindUnclosedFence(content string) (int, bool) {
 	}
 	return 0, false
 }
-
-// fencePrefix returns the fence character and its count if the line starts
-// with 3+ backticks or 3+ tildes. Returns (0, 0) otherwise.
-func fencePrefix(line string) (byte, int) {
-	if len(line) == 0 {
-		return 0, 0
-	}
-	ch := line[0]
-	if ch != '`' && ch != '~' {
-		return 0, 0
-	}
-	n := 0
-	for n < len(line) && line[n] == ch {
-		n++
-	}
-	if n < 3 {
-		return 0, 0
-	}
-	return ch, n
-}
@@ -1,6 +1,7 @@
 package structure
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/agent-ecosystem/skill-validator/types"
@@ -170,3 +171,118 @@ func TestCheckMarkdown(t *testing.T) {
 		requireNoLevel(t, results, types.Error)
 	})
 }
+
+func TestCheckDocumentStructure(t *testing.T) {
+	const fm = "---\nname: my-skill\ndescription: Does things.\n---\n"
+
+	t.Run("well formed", func(t *testing.T) {
+		s := loadTestSkill(t, fm+"# My Skill\n\n## Setup\n\n1. Install it.\n2. Configure it.\n3. Run it.\n\n## Usage\n\nRun the tool.\n")
+		writeFile(t, s.Dir, "references/api.md", "# API\n\n## Endpoints\n\nList of endpoints.\n")
+		results := CheckDocumentStructure(s.Dir, s)
+		requireResult(t, results, types.Pass, "headings and numbered lists are well formed")
+		requireNoLevel(t, results, types.Warning)
+		requireNoLevel(t, results, types.Info)
+	})
+
+	t.Run("no H1", func(t *testing.T) {
+		s := loadTestSkill(t, fm+"## Setup\n\nInstall it.\n")
+		results := CheckDocumentStructure(s.Dir, s)
+		requireFileResult(t, results, types.Info, "SKILL.md", "document has no H1 heading")
+		requireResult(t, results, types.Pass, "headings and numbered lists are well formed")
+	})
+
+	t.Run("multiple H1s", func(t *testing.T) {
+		s := loadTestSkill(t, fm+"# One\n\nText.\n\n# Two\n\nText.\n")
+		results := CheckDocumentStructure(s.Dir, s)
+		requireFileResult(t, results, types.Warning, "SKILL.md", "document has 2 H1 headings (lines 5, 9)")
+	})
+
+	t.Run("skipped level", func(t *testing.T) {
+		s := loadTestSkill(t, fm+"# My Skill\n\n## Setup\n\nText.\n\n#### Options\n\nText.\n")
+		results := CheckDocumentStructure(s.Dir, s)
+		requireResultAtLine(t, results, types.Warning, "SKILL.md", 11, `"#### Options" skips a heading level after "## Setup" — use "### Options"`)
+	})
+
+	t.Run("deep nesting", func(t *testing.T) {
+		s := loadTestSkill(t, fm+"# A\n\n## B\n\n### C\n\n#### D\n\n##### E\n\nText.\n")
+		results := CheckDocumentStructure(s.Dir, s)
+		requireResultAtLine(t, results, types.Warning, "SKILL.md", 13, `"##### E" is nested 5 levels deep`)
+		requireNoResultContaining(t, results, types.Warning, "skips a heading level")
+	})
+
+	t.Run("empty section", func(t *testing.T) {
+		s := loadTestSkill(t, fm+"# My Skill\n\n## Setup\n\n## Usage\n\nText.\n\n## Notes\n")
+		results := CheckDocumentStructure(s.Dir, s)
+		requireResultAtLine(t, results, types.Warning, "SKILL.md", 7, `section "## Setup" is empty`)
+		requireResultAtLine(t, results, types.Warning, "SKILL.md", 13, `section "## Notes" is empty`)
+		requireNoResultContaining(t, results, types.Warning, `"# My Skill" is empty`)
+	})
+
+	t.Run("duplicate headings", func(t *testing.T) {
+		s := loadTestSkill(t, fm+"# My Skill\n\n## Example\n\nText.\n\n## Other\n\n### Example!\n\nText.\n")
+		results := CheckDocumentStructure(s.Dir, s)
+		requireResultAtLine(t, results, types.Warning, "SKILL.md", 13,
+			`heading "Example!" repeats the heading at line 7 — both link as #example`)
+	})
+
+	t.Run("long section without subheadings", func(t *testing.T) {
+		s := loadTestSkill(t, fm+"# My Skill\n\n## Guide\n\n"+strings.Repeat("word ", maxSectionWords+1)+"\n")
+		results := CheckDocumentStructure(s.Dir, s)
+		requireResultAtLine(t, results, types.Warning, "SKILL.md", 7, `section "## Guide" runs 801 words without a subheading`)
+	})
+
+	t.Run("references are checked", func(t *testing.T) {
+		s := loadTestSkill(t, fm+"# My Skill\n\nText.\n")
+		writeFile(t, s.Dir, "references/guide.md", "# Guide\n\n## Steps\n\n1. One.\n2. Two.\n2. Again.\n")
+		results := CheckDocumentStructure(s.Dir, s)
+		requireResultAtLine(t, results, types.Warning, "references/guide.md", 5, "numbered list repeats step 2 (line 7)")
+	})
+}
+
+func TestCheckNumberedLists(t *testing.T) {
+	ctx := types.ResultContext{Category: "Markdown"}
+	tests := []struct {
+		name string
+		text string
+		line int
+		want string
+	}{
+		{"sequential", "1. a\n2. b\n3. c\n", 0, ""},
+		{"lazy numbering", "1. a\n1. b\n1. c\n", 0, ""},
+		{"starts above one", "3. a\n4. b\n", 0, ""},
+		{"gap", "1. a\n2. b\n4. c\n", 1, "numbered list skips from step 2 to 4 (line 3)"},
+		{"repeat", "1) a\n2) b\n2) c\n3) d\n", 1, "numbered list repeats step 2 (line 3)"},
+		{"backwards", "1. a\n2. b\n1. c\n", 1, "numbered list goes back from step 2 to 1 (line 3)"},
+		{"blank lines and continuations", "1. a\n\n   more about a\n\n2. b\n   - detail\n3. c\n", 0, ""},
+		{"code in an item", "1. a\n\n   ```bash\n   5. not an item\n   ```\n\n2. b\n", 0, ""},
+		{"nested lists are separate", "1. a\n   1. x\n   3. y\n2. b\n", 2, "numbered list skips from step 1 to 3 (line 3)"},
+		{"paragraph ends the list", "1. a\n2. b\n\nText.\n\n1. c\n2. d\n", 0, ""},
+		{"heading ends the list", "1. a\n## Next\n3. b\n4. c\n", 0, ""},
+		{"fenced code is ignored", "```\n1. a\n3. b\n```\n", 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := checkNumberedLists(ctx, "SKILL.md", tt.text, 0)
+			if tt.want == "" {
+				if len(results) != 0 {
+					t.Errorf("expected no results, got %+v", results)
+				}
+				return
+			}
+			requireResultAtLine(t, results, types.Warning, "SKILL.md", tt.line, tt.want)
+		})
+	}
+}
+
+func TestHeadingAnchor(t *testing.T) {
+	for heading, want := range map[string]string{
+		"Quick Start":         "quick-start",
+		"What's new?":         "whats-new",
+		"`--flag` options":    "--flag-options",
+		"Étape 2: configurer": "étape-2-configurer",
+	} {
+		if got := headingAnchor(heading); got != want {
+			t.Errorf("headingAnchor(%q) = %q, want %q", heading, got, want)
+		}
+	}
+}
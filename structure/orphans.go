@@ -177,7 +177,7 @@ func rootTextFiles(dir string) []string {
 		if strings.EqualFold(name, "SKILL.md") {
 			continue
 		}
-		if isTextFile(name) {
+		if isTextFile(filepath.Join(dir, name)) {
 			files = append(files, name)
 		}
 	}
@@ -265,11 +265,12 @@ func markReached(relPath, source, dir string, queue *[]queueItem, reached map[st
 	reached[relPath] = true
 	reachedFrom[relPath] = source
 
-	if isTextFile(relPath) {
-		data, err := os.ReadFile(filepath.Join(dir, relPath))
-		if err == nil {
-			*queue = append(*queue, queueItem{text: string(data), source: relPath})
-		}
+	path := filepath.Join(dir, relPath)
+	if !isTextFile(path) {
+		return
+	}
+	if data, err := os.ReadFile(path); err == nil {
+		*queue = append(*queue, queueItem{text: string(data), source: relPath})
 	}
 }
 
@@ -405,9 +406,3 @@ func CheckFlatOrphanFiles(dir, body string) []types.Result {
 
 	return results
 }
-
-// isTextFile checks whether the file extension indicates a scannable text file.
-// Anything not in the binary extension list is assumed to be text.
-func isTextFile(relPath string) bool {
-	return !binaryExtensions[strings.ToLower(filepath.Ext(relPath))]
-}
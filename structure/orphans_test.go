@@ -64,7 +64,7 @@ func TestCheckOrphanFiles(t *testing.T) {
 
 	t.Run("binary file referenced but not scanned", func(t *testing.T) {
 		dir := t.TempDir()
-		writeFile(t, dir, "assets/logo.png", "fake binary with references/secret.md inside")
+		writeFile(t, dir, "assets/logo.png", pngData+"references/secret.md")
 		writeFile(t, dir, "references/secret.md", "secret content")
 
 		body := "See assets/logo.png for the logo."
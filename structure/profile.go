@@ -0,0 +1,215 @@
+package structure
+
+import (
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/agent-ecosystem/skill-validator/skill"
+	"github.com/agent-ecosystem/skill-validator/types"
+	"github.com/agent-ecosystem/skill-validator/util"
+)
+
+// fieldKind is the YAML type a platform expects for a frontmatter extension.
+type fieldKind string
+
+const (
+	kindString  fieldKind = "string"
+	kindBoolean fieldKind = "boolean"
+	kindMapping fieldKind = "mapping"
+)
+
+// platformProfile describes how one agent platform loads skills: the frontmatter
+// fields it adds to the spec, its limits, its allowed-tools vocabulary, and
+// where it discovers project skills.
+type platformProfile struct {
+	// Fields are the frontmatter extensions the platform reads.
+	Fields map[string]fieldKind
+	// MaxDescription is the platform's description limit in characters when
+	// it is stricter than the spec's 1,024; 0 means the spec limit.
+	MaxDescription int
+	// ToolPlatform names the platform's allowed-tools vocabulary (see
+	// ToolPlatforms); empty accepts any known platform's tool names.
+	ToolPlatform string
+	// SkillsDirs are the project directories the platform loads skills from.
+	SkillsDirs []string
+}
+
+// profiles are the built-in platform profiles, keyed by the same names as
+// the platform pre-commit hooks.
+var profiles = map[string]platformProfile{
+	"amp": {SkillsDirs: []string{".agents/skills"}},
+	"claude": {
+		Fields: map[string]fieldKind{
+			"argument-hint":            kindString,
+			"disable-model-invocation": kindBoolean,
+			"user-invocable":           kindBoolean,
+			"model":                    kindString,
+			"context":                  kindString,
+			"agent":                    kindString,
+			"hooks":                    kindMapping,
+		},
+		ToolPlatform: "claude-code",
+		SkillsDirs:   []string{".claude/skills"},
+	},
+	"cline": {SkillsDirs: []string{".cline/skills"}},
+	"codex": {
+		MaxDescription: 500,
+		SkillsDirs:     []string{".codex/skills"},
+	},
+	"copilot": {
+		Fields: map[string]fieldKind{
+			"argument-hint":            kindString,
+			"disable-model-invocation": kindBoolean,
+			"user-invokable":           kindBoolean,
+		},
+		SkillsDirs: []string{".github/skills"},
+	},
+	"cursor": {
+		Fields: map[string]fieldKind{
+			"disable-model-invocation": kindBoolean,
+		},
+		SkillsDirs: []string{".cursor/skills"},
+	},
+	"gemini": {
+		ToolPlatform: "gemini-cli",
+		SkillsDirs:   []string{".gemini/skills", ".agent/skills"},
+	},
+	"goose":        {SkillsDirs: []string{".goose/skills"}},
+	"kiro":         {SkillsDirs: []string{".kiro/skills"}},
+	"mistral-vibe": {SkillsDirs: []string{".vibe/skills"}},
+	"roo-code":     {SkillsDirs: []string{".roo/skills"}},
+	"trae":         {SkillsDirs: []string{".trae/skills"}},
+	"windsurf":     {SkillsDirs: []string{".windsurf/skills"}},
+}
+
+// Profiles returns the names of the built-in platform profiles, in sorted
+// order.
+func Profiles() []string {
+	return util.SortedKeys(profiles)
+}
+
+// profile returns the selected platform profile, if any.
+func (o Options) profile() (platformProfile, bool) {
+	p, ok := profiles[o.Profile]
+	return p, ok
+}
+
+// toolPlatform returns the allowed-tools vocabulary to check against: the
+// explicit ToolPlatform, else the profile's.
+func (o Options) toolPlatform() string {
+	if o.ToolPlatform != "" {
+		return o.ToolPlatform
+	}
+	p, _ := o.profile()
+	return p.ToolPlatform
+}
+
+// claudeModelPattern matches the model values Claude Code accepts: an alias
+// or a full model ID.
+var claudeModelPattern = regexp.MustCompile(`^(inherit|sonnet|opus|haiku|claude-[a-z0-9.-]+)$`)
+
+// checkProfileFields checks the frontmatter fields outside the spec against
+// the profile: the platform's extensions must have the expected type, and
+// any other field is unrecognized. Fields that only other platforms read
+// name those platforms, since the skill may be written for one of them.
+func checkProfileFields(ctx types.ResultContext, s *skill.Skill, opts Options) []types.Result {
+	p, _ := opts.profile()
+	var results []types.Result
+	unknown := s.UnrecognizedFields()
+	slices.Sort(unknown)
+	for _, field := range unknown {
+		kind, ok := p.Fields[field]
+		if !ok {
+			if opts.AllowExtraFrontmatter {
+				continue
+			}
+			r := ctx.Warnf("unrecognized field: %q", field)
+			if others := profilesWithField(field); len(others) > 0 {
+				r = ctx.Warnf("unrecognized field: %q (read by %s, not %s)", field, strings.Join(others, ", "), opts.Profile)
+			}
+			results = append(results, atField(s, []types.Result{r}, field)...)
+			continue
+		}
+		results = append(results, atField(s, checkExtensionField(ctx, opts.Profile, field, kind, s.RawFrontmatter[field]), field)...)
+	}
+	return results
+}
+
+// checkExtensionField checks the value of a platform frontmatter extension.
+func checkExtensionField(ctx types.ResultContext, platform, field string, kind fieldKind, value any) []types.Result {
+	ok := false
+	switch kind {
+	case kindString:
+		_, ok = value.(string)
+	case kindBoolean:
+		_, ok = value.(bool)
+	case kindMapping:
+		_, ok = value.(map[string]any)
+	}
+	if !ok {
+		return []types.Result{ctx.Errorf("%s must be a %s for %s (got %v)", field, kind, platform, value)}
+	}
+	switch {
+	case platform == "claude" && field == "model" && !claudeModelPattern.MatchString(value.(string)):
+		return []types.Result{ctx.Warnf("model %q is not a Claude model alias (sonnet, opus, haiku, inherit) or model ID", value)}
+	case platform == "claude" && field == "context" && value != "fork":
+		return []types.Result{ctx.Errorf("context %q is not supported; the only value is \"fork\"", value)}
+	}
+	if m, ok := value.(map[string]any); ok {
+		return []types.Result{ctx.Passf("%s: (%d entries, %s extension)", field, len(m), platform)}
+	}
+	return []types.Result{ctx.Passf("%s: %v (%s extension)", field, value, platform)}
+}
+
+// profilesWithField returns the platforms whose profile reads field.
+func profilesWithField(field string) []string {
+	var names []string
+	for _, name := range Profiles() {
+		if _, ok := profiles[name].Fields[field]; ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// checkProfileDescription enforces a platform description limit stricter
+// than the spec's.
+func checkProfileDescription(ctx types.ResultContext, desc string, opts Options) []types.Result {
+	p, _ := opts.profile()
+	if p.MaxDescription == 0 || len(desc) <= p.MaxDescription || len(desc) > 1024 {
+		return nil
+	}
+	return []types.Result{ctx.Errorf("description exceeds %d characters, the %s limit (%d)", p.MaxDescription, opts.Profile, len(desc))}
+}
+
+// checkProfileLocation warns when the skill sits in another platform's
+// skills directory, where the profile's platform won't discover it. Paths
+// outside any known skills directory are not checked.
+func checkProfileLocation(dir string, opts Options) []types.Result {
+	p, ok := opts.profile()
+	if !ok {
+		return nil
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil
+	}
+	path := filepath.ToSlash(abs)
+	for _, d := range p.SkillsDirs {
+		if strings.Contains(path, "/"+d+"/") {
+			return nil
+		}
+	}
+	for _, name := range Profiles() {
+		for _, d := range profiles[name].SkillsDirs {
+			if strings.Contains(path, "/"+d+"/") && !slices.Contains(p.SkillsDirs, d) {
+				ctx := types.ResultContext{Category: "Structure"}
+				return []types.Result{ctx.Warnf("skill is in %s/, which %s does not load skills from (expected %s/)",
+					d, opts.Profile, strings.Join(p.SkillsDirs, "/ or "))}
+			}
+		}
+	}
+	return nil
+}
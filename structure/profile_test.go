@@ -0,0 +1,86 @@
+package structure
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agent-ecosystem/skill-validator/types"
+)
+
+func TestCheckFrontmatter_Profile(t *testing.T) {
+	t.Run("platform extensions are recognized", func(t *testing.T) {
+		s := loadTestSkill(t, "---\nname: my-skill\ndescription: d\nuser-invocable: false\ndisable-model-invocation: true\nmodel: sonnet\n---\nBody\n")
+		results := CheckFrontmatter(s, Options{Profile: "claude"})
+		requireNoLevel(t, results, types.Warning)
+		requireResult(t, results, types.Pass, "user-invocable: false (claude extension)")
+		requireResult(t, results, types.Pass, "model: sonnet (claude extension)")
+	})
+
+	t.Run("another platform's extension", func(t *testing.T) {
+		s := loadTestSkill(t, "---\nname: my-skill\ndescription: d\nuser-invocable: false\ncustom: x\n---\nBody\n")
+		results := CheckFrontmatter(s, Options{Profile: "cursor"})
+		requireResult(t, results, types.Warning, `unrecognized field: "user-invocable" (read by claude, not cursor)`)
+		requireResult(t, results, types.Warning, `unrecognized field: "custom"`)
+	})
+
+	t.Run("allow extra frontmatter still checks extensions", func(t *testing.T) {
+		s := loadTestSkill(t, "---\nname: my-skill\ndescription: d\nuser-invocable: \"no\"\ncustom: x\n---\nBody\n")
+		results := CheckFrontmatter(s, Options{Profile: "claude", AllowExtraFrontmatter: true})
+		requireResult(t, results, types.Error, "user-invocable must be a boolean for claude (got no)")
+		requireNoResultContaining(t, results, types.Warning, "custom")
+		for _, r := range results {
+			if r.Level == types.Error && r.Line != 4 {
+				t.Errorf("expected the error on line 4, got %d", r.Line)
+			}
+		}
+	})
+
+	t.Run("claude model and context values", func(t *testing.T) {
+		s := loadTestSkill(t, "---\nname: my-skill\ndescription: d\nmodel: gpt-4o\ncontext: thread\n---\nBody\n")
+		results := CheckFrontmatter(s, Options{Profile: "claude"})
+		requireResultContaining(t, results, types.Warning, `model "gpt-4o" is not a Claude model alias`)
+		requireResult(t, results, types.Error, `context "thread" is not supported; the only value is "fork"`)
+	})
+
+	t.Run("platform description limit", func(t *testing.T) {
+		desc := strings.Repeat("word ", 120)
+		s := loadTestSkill(t, "---\nname: my-skill\ndescription: "+desc+"\n---\nBody\n")
+		requireResult(t, CheckFrontmatter(s, Options{Profile: "codex"}), types.Error, "description exceeds 500 characters, the codex limit (599)")
+		requireNoLevel(t, CheckFrontmatter(s, Options{Profile: "claude"}), types.Error)
+	})
+
+	t.Run("profile tool vocabulary", func(t *testing.T) {
+		s := loadTestSkill(t, "---\nname: my-skill\ndescription: d\nallowed-tools: read_file Read\n---\nUse `read_file` and the `Read` tool.\n")
+		results := CheckFrontmatter(s, Options{Profile: "claude"})
+		requireResult(t, results, types.Warning, `allowed-tools: unknown tool "read_file" for claude-code`)
+		results = CheckFrontmatter(s, Options{Profile: "claude", ToolPlatform: "gemini-cli"})
+		requireResultContaining(t, results, types.Warning, `unknown tool "Read" for gemini-cli`)
+	})
+}
+
+func TestCheckProfileLocation(t *testing.T) {
+	root := t.TempDir()
+	tests := []struct {
+		dir     string
+		profile string
+		want    string
+	}{
+		{".cursor/skills/my-skill", "claude", "skill is in .cursor/skills/, which claude does not load skills from (expected .claude/skills/)"},
+		{".claude/skills/my-skill", "claude", ""},
+		{".agent/skills/my-skill", "gemini", ""},
+		{".agents/skills/my-skill", "gemini", "skill is in .agents/skills/, which gemini does not load skills from (expected .gemini/skills/ or .agent/skills/)"},
+		{"skills/my-skill", "claude", ""},
+		{".cursor/skills/my-skill", "", ""},
+	}
+	for _, tt := range tests {
+		results := checkProfileLocation(filepath.Join(root, tt.dir), Options{Profile: tt.profile})
+		if tt.want == "" {
+			if len(results) != 0 {
+				t.Errorf("%s with %q: expected no results, got %+v", tt.dir, tt.profile, results)
+			}
+			continue
+		}
+		requireResult(t, results, types.Warning, tt.want)
+	}
+}
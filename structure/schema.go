@@ -0,0 +1,137 @@
+package structure
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+
+	"github.com/agent-ecosystem/skill-validator/skill"
+	"github.com/agent-ecosystem/skill-validator/types"
+)
+
+// FrontmatterSchema is a compiled JSON Schema that the whole frontmatter of
+// every skill must satisfy, including fields outside the spec.
+type FrontmatterSchema struct {
+	name   string
+	schema *jsonschema.Schema
+}
+
+// LoadFrontmatterSchema compiles the JSON Schema file at path. Drafts 4
+// through 2020-12 are supported, and "format" keywords are asserted.
+func LoadFrontmatterSchema(path string) (*FrontmatterSchema, error) {
+	c := jsonschema.NewCompiler()
+	c.AssertFormat = true
+	schema, err := c.Compile(path)
+	if err != nil {
+		return nil, fmt.Errorf("loading frontmatter schema: %w", err)
+	}
+	return &FrontmatterSchema{name: filepath.Base(path), schema: schema}, nil
+}
+
+// schemaViolation is one failed schema keyword, at a JSON pointer into the
+// frontmatter.
+type schemaViolation struct {
+	pointer string
+	message string
+	line    int
+	column  int
+}
+
+// checkFrontmatterSchema validates the frontmatter against schema. Each
+// violation is an error at the SKILL.md line of the offending field.
+func checkFrontmatterSchema(ctx types.ResultContext, s *skill.Skill, schema *FrontmatterSchema) []types.Result {
+	data := s.RawFrontmatter
+	if data == nil {
+		data = map[string]any{}
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return []types.Result{ctx.Errorf("frontmatter cannot be checked against schema %s: %v", schema.name, err)}
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var instance any
+	if err := dec.Decode(&instance); err != nil {
+		return []types.Result{ctx.Errorf("frontmatter cannot be checked against schema %s: %v", schema.name, err)}
+	}
+
+	err = schema.schema.Validate(instance)
+	if err == nil {
+		return []types.Result{ctx.Passf("frontmatter matches schema %s", schema.name)}
+	}
+	var ve *jsonschema.ValidationError
+	if !errors.As(err, &ve) {
+		return []types.Result{ctx.Errorf("frontmatter cannot be checked against schema %s: %v", schema.name, err)}
+	}
+
+	var violations []schemaViolation
+	for _, leaf := range schemaLeaves(ve) {
+		line, col := s.FieldPosition(pointerTokens(leaf.InstanceLocation)...)
+		violations = append(violations, schemaViolation{
+			pointer: leaf.InstanceLocation,
+			message: leaf.Message,
+			line:    line,
+			column:  col,
+		})
+	}
+	slices.SortStableFunc(violations, func(a, b schemaViolation) int { return a.line - b.line })
+
+	results := make([]types.Result, 0, len(violations))
+	for _, v := range violations {
+		r := ctx.ErrorAtLinef("SKILL.md", v.line, "%s: %s (schema %s)", pointerPath(v.pointer), v.message, schema.name)
+		r.Column = v.column
+		results = append(results, r)
+	}
+	return results
+}
+
+// schemaLeaves returns the innermost errors of a validation error tree, which
+// name the keyword that actually failed.
+func schemaLeaves(ve *jsonschema.ValidationError) []*jsonschema.ValidationError {
+	if len(ve.Causes) == 0 {
+		return []*jsonschema.ValidationError{ve}
+	}
+	var leaves []*jsonschema.ValidationError
+	for _, c := range ve.Causes {
+		leaves = append(leaves, schemaLeaves(c)...)
+	}
+	return leaves
+}
+
+// pointerPath formats a JSON pointer as a field path, e.g. "metadata.owner"
+// for "/metadata/owner" and "tags[0]" for "/tags/0".
+func pointerPath(pointer string) string {
+	if pointer == "" {
+		return "frontmatter"
+	}
+	var b strings.Builder
+	for i, tok := range pointerTokens(pointer) {
+		if _, err := strconv.Atoi(tok); err == nil {
+			fmt.Fprintf(&b, "[%s]", tok)
+			continue
+		}
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(tok)
+	}
+	return b.String()
+}
+
+func pointerTokens(pointer string) []string {
+	if pointer == "" {
+		return nil
+	}
+	tokens := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, t := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(t)
+	}
+	return tokens
+}
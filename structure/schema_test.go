@@ -0,0 +1,132 @@
+package structure
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agent-ecosystem/skill-validator/skill"
+	"github.com/agent-ecosystem/skill-validator/types"
+)
+
+const orgSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "required": ["metadata"],
+  "properties": {
+    "user-invocable": {"type": "boolean"},
+    "metadata": {
+      "type": "object",
+      "required": ["owner", "version", "tier"],
+      "properties": {
+        "owner": {"type": "string", "minLength": 1},
+        "version": {"type": "string", "pattern": "^[0-9]+\\.[0-9]+\\.[0-9]+$"},
+        "tier": {"enum": ["gold", "silver", "bronze"]}
+      }
+    }
+  }
+}`
+
+func loadTestSchema(t *testing.T, schema string) *FrontmatterSchema {
+	t.Helper()
+	dir := t.TempDir()
+	writeFile(t, dir, "schema.json", schema)
+	fs, err := LoadFrontmatterSchema(filepath.Join(dir, "schema.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fs
+}
+
+func loadTestSkill(t *testing.T, content string) *skill.Skill {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "my-skill")
+	writeSkill(t, dir, content)
+	s, err := skill.Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestCheckFrontmatter_Schema(t *testing.T) {
+	schema := loadTestSchema(t, orgSchema)
+	opts := Options{AllowExtraFrontmatter: true, FrontmatterSchema: schema}
+
+	t.Run("valid", func(t *testing.T) {
+		s := loadTestSkill(t, "---\nname: my-skill\ndescription: d\nuser-invocable: true\nmetadata:\n  owner: platform-team\n  version: 1.2.0\n  tier: gold\n---\nBody\n")
+		results := CheckFrontmatter(s, opts)
+		requireResult(t, results, types.Pass, "frontmatter matches schema schema.json")
+		requireNoLevel(t, results, types.Error)
+	})
+
+	t.Run("violations point at their lines", func(t *testing.T) {
+		s := loadTestSkill(t, "---\nname: my-skill\ndescription: d\nuser-invocable: yes\nmetadata:\n  owner: \"\"\n  version: \"1.0\"\n---\nBody\n")
+		results := CheckFrontmatter(s, opts)
+		want := []struct {
+			line int
+			msg  string
+		}{
+			{4, "user-invocable: expected boolean, but got string (schema schema.json)"},
+			{5, "metadata: missing properties: 'tier' (schema schema.json)"},
+			{6, "metadata.owner: length must be >= 1, but got 0 (schema schema.json)"},
+			{7, "metadata.version: does not match pattern"},
+		}
+		var errs []types.Result
+		for _, r := range results {
+			if r.Level == types.Error {
+				errs = append(errs, r)
+			}
+		}
+		if len(errs) != len(want) {
+			t.Fatalf("got %d errors, want %d: %+v", len(errs), len(want), errs)
+		}
+		for i, w := range want {
+			if errs[i].Line != w.line || !strings.HasPrefix(errs[i].Message, w.msg) || errs[i].File != "SKILL.md" {
+				t.Errorf("error %d = line %d %q, want line %d %q", i, errs[i].Line, errs[i].Message, w.line, w.msg)
+			}
+		}
+	})
+
+	t.Run("missing required field reported at root", func(t *testing.T) {
+		s := loadTestSkill(t, "---\nname: my-skill\ndescription: d\n---\nBody\n")
+		results := CheckFrontmatter(s, opts)
+		requireResult(t, results, types.Error, "frontmatter: missing properties: 'metadata' (schema schema.json)")
+		for _, r := range results {
+			if r.Level == types.Error && r.Line != 1 {
+				t.Errorf("expected line 1, got %d", r.Line)
+			}
+		}
+	})
+
+	t.Run("no schema", func(t *testing.T) {
+		s := loadTestSkill(t, "---\nname: my-skill\ndescription: d\n---\nBody\n")
+		requireNoResultContaining(t, CheckFrontmatter(s, Options{}), types.Pass, "schema")
+	})
+}
+
+func TestLoadFrontmatterSchema_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "bad.json", `{"type": 5}`)
+	if _, err := LoadFrontmatterSchema(filepath.Join(dir, "bad.json")); err == nil {
+		t.Error("expected error for invalid schema")
+	}
+	if _, err := LoadFrontmatterSchema(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("expected error for missing schema file")
+	}
+}
+
+func TestPointerPath(t *testing.T) {
+	tests := map[string]string{
+		"":                "frontmatter",
+		"/name":           "name",
+		"/metadata/owner": "metadata.owner",
+		"/tags/0":         "tags[0]",
+		"/a~1b/c~0d":      "a/b.c~d",
+	}
+	for in, want := range tests {
+		if got := pointerPath(in); got != want {
+			t.Errorf("pointerPath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,464 @@
+package structure
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/agent-ecosystem/skill-validator/skill"
+	"github.com/agent-ecosystem/skill-validator/types"
+	"github.com/agent-ecosystem/skill-validator/util"
+)
+
+// scriptInterpreters maps script file extensions to the interpreter that runs
+// them. Files under scripts/ with other extensions are checked only if they
+// start with a shebang.
+var scriptInterpreters = map[string]string{
+	".sh":   "sh",
+	".bash": "bash",
+	".zsh":  "zsh",
+	".py":   "python",
+	".rb":   "ruby",
+	".pl":   "perl",
+	".js":   "node",
+	".mjs":  "node",
+	".cjs":  "node",
+	".ts":   "node",
+	".php":  "php",
+	".lua":  "lua",
+	".r":    "Rscript",
+}
+
+// baselineInterpreters are available wherever skills run, so scripts using
+// them need no declaration.
+var baselineInterpreters = map[string]bool{"sh": true, "bash": true}
+
+// interpreterNames lists the ways a skill may refer to each interpreter in its
+// compatibility field or instructions.
+var interpreterNames = map[string][]string{
+	"python":  {"python", "python3", "uv", "pip"},
+	"node":    {"node", "node.js", "nodejs", "npm", "npx", "deno", "bun"},
+	"deno":    {"deno"},
+	"bun":     {"bun"},
+	"ruby":    {"ruby"},
+	"perl":    {"perl"},
+	"php":     {"php"},
+	"lua":     {"lua"},
+	"zsh":     {"zsh"},
+	"Rscript": {"Rscript", "R"},
+}
+
+// pythonDistributions maps import names to the package names they are
+// installed under, where the two differ.
+var pythonDistributions = map[string]string{
+	"PIL":      "pillow",
+	"bs4":      "beautifulsoup4",
+	"cv2":      "opencv-python",
+	"sklearn":  "scikit-learn",
+	"skimage":  "scikit-image",
+	"yaml":     "pyyaml",
+	"dateutil": "python-dateutil",
+	"dotenv":   "python-dotenv",
+	"docx":     "python-docx",
+	"pptx":     "python-pptx",
+	"fitz":     "pymupdf",
+	"jwt":      "pyjwt",
+	"git":      "gitpython",
+	"Crypto":   "pycryptodome",
+	"OpenSSL":  "pyopenssl",
+	"serial":   "pyserial",
+	"magic":    "python-magic",
+	"zmq":      "pyzmq",
+	"attr":     "attrs",
+}
+
+// pythonManifests are files whose contents declare Python dependencies.
+var pythonManifests = map[string]bool{
+	"pyproject.toml":   true,
+	"setup.py":         true,
+	"setup.cfg":        true,
+	"pipfile":          true,
+	"environment.yml":  true,
+	"environment.yaml": true,
+}
+
+var (
+	pyImportRe     = regexp.MustCompile(`^import\s+([\w.]+(?:\s+as\s+\w+)?(?:\s*,\s*[\w.]+(?:\s+as\s+\w+)?)*)`)
+	pyFromImportRe = regexp.MustCompile(`^from\s+(\.*[\w.]*)\s+import\b`)
+	versionSuffix  = regexp.MustCompile(`[\d.]+$`)
+)
+
+// script is a file under scripts/ that an agent may execute.
+type script struct {
+	path        string // relative to the skill dir
+	content     string
+	mode        os.FileMode
+	interpreter string // from the shebang, or else the extension
+	shebang     bool
+}
+
+// CheckScripts validates the executable hygiene of the files under scripts/:
+// each script should have a shebang, be executable, use LF line endings, and
+// run on an interpreter the skill declares. Python scripts must also declare
+// their third-party imports. Returns nil when the skill has no scripts.
+func CheckScripts(dir string, s *skill.Skill) []types.Result {
+	ctx := types.ResultContext{Category: "Scripts"}
+	scripts := findScripts(dir)
+	if len(scripts) == 0 {
+		return nil
+	}
+
+	declared := strings.ToLower(s.Frontmatter.Compatibility + "\n" + s.Body)
+	manifests := pythonManifestText(dir)
+
+	modules := importedLocalModules(scripts)
+
+	var results []types.Result
+	for _, sc := range scripts {
+		// Python modules imported by other scripts are never run directly
+		if modules[sc.path] {
+			if sc.interpreter == "python" {
+				results = append(results, checkPythonImports(ctx, dir, sc, declared+"\n"+manifests)...)
+			}
+			continue
+		}
+		if !sc.shebang {
+			results = append(results, ctx.WarnFilef(sc.path,
+				"script has no shebang line; agents that execute it directly will fail"))
+		}
+		if runtime.GOOS != "windows" && sc.mode&0o111 == 0 {
+			results = append(results, ctx.WarnFilef(sc.path,
+				"script is not executable (run chmod +x on it)"))
+		}
+		if strings.Contains(sc.content, "\r\n") {
+			results = append(results, ctx.ErrorFilef(sc.path,
+				"script has CRLF line endings; the interpreter will see a trailing carriage return and the script will fail on Unix"))
+		}
+		if sc.interpreter != "" && !baselineInterpreters[sc.interpreter] && !mentionsInterpreter(declared, sc.interpreter) {
+			results = append(results, ctx.WarnFilef(sc.path,
+				"script requires %s, which is not mentioned in the compatibility field or SKILL.md", sc.interpreter))
+		}
+		if sc.interpreter == "python" {
+			results = append(results, checkPythonImports(ctx, dir, sc, declared+"\n"+manifests)...)
+		}
+	}
+
+	if len(results) == 0 {
+		results = append(results, ctx.Passf("%d script%s checked: shebangs, permissions, line endings, and interpreters OK",
+			len(scripts), util.PluralS(len(scripts))))
+	}
+	return results
+}
+
+// findScripts returns the executable candidates under scripts/, in walk order:
+// files with a known script extension or a shebang line. Hidden files and
+// binaries are skipped.
+func findScripts(dir string) []script {
+	var scripts []script
+	root := filepath.Join(dir, "scripts")
+	_ = filepath.WalkDir(root, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		name := entry.Name()
+		if entry.IsDir() {
+			if path != root && (strings.HasPrefix(name, ".") || name == "__pycache__" || name == "node_modules") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasPrefix(name, ".") || !entry.Type().IsRegular() {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil || bytes.IndexByte(data, 0) >= 0 {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil
+		}
+		content := string(data)
+		sc := script{content: content, mode: info.Mode()}
+		sc.path, _ = filepath.Rel(dir, path)
+		if first, _, _ := strings.Cut(content, "\n"); strings.HasPrefix(first, "#!") {
+			sc.shebang = true
+			sc.interpreter = shebangInterpreter(first)
+		} else if interp, ok := scriptInterpreters[strings.ToLower(filepath.Ext(name))]; ok {
+			sc.interpreter = interp
+		} else {
+			return nil
+		}
+		scripts = append(scripts, sc)
+		return nil
+	})
+	return scripts
+}
+
+// shebangInterpreter returns the normalized interpreter named by a shebang
+// line, e.g. "python" for "#!/usr/bin/env python3".
+func shebangInterpreter(line string) string {
+	fields := strings.Fields(strings.TrimSpace(strings.TrimPrefix(line, "#!")))
+	if len(fields) == 0 {
+		return ""
+	}
+	name := filepath.Base(fields[0])
+	if name == "env" {
+		name = ""
+		for _, f := range fields[1:] {
+			// Skip env options such as -S and variable assignments
+			if !strings.HasPrefix(f, "-") && !strings.Contains(f, "=") {
+				name = filepath.Base(f)
+				break
+			}
+		}
+	}
+	name = strings.TrimSuffix(name, "\r")
+	if name != "Rscript" {
+		name = versionSuffix.ReplaceAllString(name, "")
+	}
+	if name == "uv" {
+		return "python"
+	}
+	return name
+}
+
+// mentionsInterpreter reports whether the lowercased declared text names the
+// interpreter or one of its aliases.
+func mentionsInterpreter(declared, interpreter string) bool {
+	names := interpreterNames[interpreter]
+	if names == nil {
+		names = []string{interpreter}
+	}
+	for _, n := range names {
+		if containsWord(declared, strings.ToLower(n)) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsWord reports whether word occurs in text with no letter, digit, or
+// underscore on either side.
+func containsWord(text, word string) bool {
+	for i := 0; ; {
+		j := strings.Index(text[i:], word)
+		if j < 0 {
+			return false
+		}
+		start, end := i+j, i+j+len(word)
+		if (start == 0 || !isWordByte(text[start-1])) && (end == len(text) || !isWordByte(text[end])) {
+			return true
+		}
+		i = start + 1
+	}
+}
+
+func isWordByte(b byte) bool {
+	return b == '_' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9'
+}
+
+// pythonManifestText returns the lowercased contents of every requirements
+// file and Python project manifest in the skill.
+func pythonManifestText(dir string) string {
+	var b strings.Builder
+	_ = filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		name := strings.ToLower(entry.Name())
+		if entry.IsDir() {
+			if path != dir && (strings.HasPrefix(name, ".") || name == "node_modules") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		isReq := strings.HasPrefix(name, "requirements") && strings.HasSuffix(name, ".txt")
+		if !isReq && !pythonManifests[name] {
+			return nil
+		}
+		if data, err := os.ReadFile(path); err == nil {
+			b.WriteString(strings.ToLower(string(data)))
+			b.WriteByte('\n')
+		}
+		return nil
+	})
+	return b.String()
+}
+
+// checkPythonImports warns about third-party modules imported by sc that are
+// not declared in declared (the compatibility field, SKILL.md body, and
+// dependency manifests) or in the script's inline metadata (PEP 723).
+func checkPythonImports(ctx types.ResultContext, dir string, sc script, declared string) []types.Result {
+	declared += "\n" + strings.ToLower(inlineScriptMetadata(sc.content))
+	local := localPythonModules(filepath.Join(dir, filepath.Dir(sc.path)))
+
+	var results []types.Result
+	seen := make(map[string]bool)
+	for i, line := range strings.Split(sc.content, "\n") {
+		for _, mod := range importedModules(strings.TrimSpace(line)) {
+			if seen[mod] || pythonStdlib[mod] || local[mod] || mod == "__future__" {
+				continue
+			}
+			seen[mod] = true
+			if pythonDeclared(declared, mod) {
+				continue
+			}
+			pkg := mod
+			if dist, ok := pythonDistributions[mod]; ok {
+				pkg = dist
+			}
+			results = append(results, ctx.WarnAtLinef(sc.path, i+1,
+				"script imports third-party module %q, which is not declared in a requirements file, inline script metadata, or SKILL.md (e.g. pip install %s)",
+				mod, pkg))
+		}
+	}
+	return results
+}
+
+// importedLocalModules returns the paths of the Python scripts that are
+// imported by another script, resolving absolute and relative imports
+// against the importing file's directory, plus package __init__.py files.
+func importedLocalModules(scripts []script) map[string]bool {
+	paths := make(map[string]bool)
+	for _, sc := range scripts {
+		paths[sc.path] = true
+	}
+	modules := make(map[string]bool)
+	for _, sc := range scripts {
+		if filepath.Base(sc.path) == "__init__.py" {
+			modules[sc.path] = true
+		}
+		if sc.interpreter != "python" {
+			continue
+		}
+		for _, line := range strings.Split(sc.content, "\n") {
+			for _, name := range importedNames(strings.TrimSpace(line)) {
+				base := filepath.Dir(sc.path)
+				dots := len(name) - len(strings.TrimLeft(name, "."))
+				for i := 1; i < dots; i++ {
+					base = filepath.Dir(base)
+				}
+				rel := filepath.Join(base, filepath.FromSlash(strings.ReplaceAll(name[dots:], ".", "/")))
+				for _, p := range []string{rel + ".py", filepath.Join(rel, "__init__.py")} {
+					if paths[p] && p != sc.path {
+						modules[p] = true
+					}
+				}
+			}
+		}
+	}
+	return modules
+}
+
+// importedNames returns the full dotted module names imported by a Python
+// line, keeping leading dots of relative imports. For "from . import x" and
+// "from pkg import x" the imported names are also returned as submodules.
+func importedNames(line string) []string {
+	if m := pyFromImportRe.FindStringSubmatch(line); m != nil {
+		names := []string{m[1]}
+		_, imports, _ := strings.Cut(line, " import ")
+		for _, part := range strings.Split(strings.Trim(imports, "() "), ",") {
+			if f := strings.Fields(part); len(f) > 0 {
+				sep := "."
+				if strings.HasSuffix(m[1], ".") {
+					sep = ""
+				}
+				names = append(names, m[1]+sep+f[0])
+			}
+		}
+		return names
+	}
+	m := pyImportRe.FindStringSubmatch(line)
+	if m == nil {
+		return nil
+	}
+	var names []string
+	for _, part := range strings.Split(m[1], ",") {
+		names = append(names, strings.Fields(part)[0])
+	}
+	return names
+}
+
+// importedModules returns the top-level modules imported by a Python line.
+// Relative imports are ignored.
+func importedModules(line string) []string {
+	if m := pyFromImportRe.FindStringSubmatch(line); m != nil {
+		top, _, _ := strings.Cut(m[1], ".")
+		if top == "" {
+			return nil
+		}
+		return []string{top}
+	}
+	m := pyImportRe.FindStringSubmatch(line)
+	if m == nil {
+		return nil
+	}
+	var mods []string
+	for _, part := range strings.Split(m[1], ",") {
+		name := strings.Fields(part)[0]
+		top, _, _ := strings.Cut(name, ".")
+		mods = append(mods, top)
+	}
+	return mods
+}
+
+// pythonDeclared reports whether the lowercased declared text names the
+// module or the package that provides it. Hyphens and underscores are
+// treated alike, as pip does.
+func pythonDeclared(declared, mod string) bool {
+	norm := strings.ReplaceAll(declared, "_", "-")
+	candidates := []string{mod}
+	if dist, ok := pythonDistributions[mod]; ok {
+		candidates = append(candidates, dist)
+	}
+	for _, c := range candidates {
+		c = strings.ReplaceAll(strings.ToLower(c), "_", "-")
+		if containsWord(norm, c) {
+			return true
+		}
+	}
+	return false
+}
+
+// inlineScriptMetadata returns the PEP 723 "# /// script" block of a Python
+// script, or "" if it has none.
+func inlineScriptMetadata(content string) string {
+	var b strings.Builder
+	in := false
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimRight(line, "\r")
+		switch {
+		case !in && line == "# /// script":
+			in = true
+		case in && line == "# ///":
+			return b.String()
+		case in:
+			b.WriteString(line)
+			b.WriteByte('\n')
+		}
+	}
+	return ""
+}
+
+// localPythonModules returns the modules importable from dir itself: sibling
+// .py files and package directories.
+func localPythonModules(dir string) map[string]bool {
+	local := make(map[string]bool)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return local
+	}
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() {
+			local[name] = true
+		} else if strings.HasSuffix(name, ".py") {
+			local[strings.TrimSuffix(name, ".py")] = true
+		}
+	}
+	return local
+}
@@ -0,0 +1,153 @@
+package structure
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/agent-ecosystem/skill-validator/skill"
+	"github.com/agent-ecosystem/skill-validator/types"
+)
+
+// writeScript creates an executable file at dir/relPath.
+func writeScript(t *testing.T, dir, relPath, content string) {
+	t.Helper()
+	writeFile(t, dir, relPath, content)
+	if err := os.Chmod(filepath.Join(dir, relPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func scriptSkill(compatibility, body string) *skill.Skill {
+	return &skill.Skill{
+		Frontmatter: skill.Frontmatter{Name: "s", Description: "d", Compatibility: compatibility},
+		Body:        body,
+	}
+}
+
+func TestCheckScripts(t *testing.T) {
+	t.Run("no scripts", func(t *testing.T) {
+		dir := t.TempDir()
+		if results := CheckScripts(dir, scriptSkill("", "")); results != nil {
+			t.Errorf("expected nil, got %+v", results)
+		}
+	})
+
+	t.Run("clean scripts pass", func(t *testing.T) {
+		dir := t.TempDir()
+		writeScript(t, dir, "scripts/setup.sh", "#!/bin/bash\necho hi\n")
+		writeScript(t, dir, "scripts/report.py", "#!/usr/bin/env python3\nimport json\nimport yaml\n")
+		writeFile(t, dir, "scripts/data.json", "{}\n")
+		writeFile(t, dir, "scripts/requirements.txt", "PyYAML>=6\n")
+		results := CheckScripts(dir, scriptSkill("Requires Python 3.10+", ""))
+		requireResult(t, results, types.Pass, "2 scripts checked: shebangs, permissions, line endings, and interpreters OK")
+	})
+
+	t.Run("missing shebang", func(t *testing.T) {
+		dir := t.TempDir()
+		writeScript(t, dir, "scripts/setup.sh", "echo hi\n")
+		results := CheckScripts(dir, scriptSkill("", ""))
+		requireFileResult(t, results, types.Warning, "scripts/setup.sh", "script has no shebang line; agents that execute it directly will fail")
+	})
+
+	t.Run("not executable", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("file modes are not checked on Windows")
+		}
+		dir := t.TempDir()
+		writeFile(t, dir, "scripts/setup.sh", "#!/bin/sh\necho hi\n")
+		results := CheckScripts(dir, scriptSkill("", ""))
+		requireFileResult(t, results, types.Warning, "scripts/setup.sh", "script is not executable (run chmod +x on it)")
+	})
+
+	t.Run("CRLF line endings", func(t *testing.T) {
+		dir := t.TempDir()
+		writeScript(t, dir, "scripts/setup.sh", "#!/bin/bash\r\necho hi\r\n")
+		results := CheckScripts(dir, scriptSkill("", ""))
+		requireFileResult(t, results, types.Error, "scripts/setup.sh", "script has CRLF line endings")
+	})
+
+	t.Run("undeclared interpreter", func(t *testing.T) {
+		dir := t.TempDir()
+		writeScript(t, dir, "scripts/build.js", "#!/usr/bin/env node\nconsole.log(1)\n")
+		writeScript(t, dir, "scripts/tool.rb", "#!/usr/bin/env ruby\nputs 1\n")
+		results := CheckScripts(dir, scriptSkill("", "Run `ruby scripts/tool.rb` to list items."))
+		requireFileResult(t, results, types.Warning, "scripts/build.js", "script requires node, which is not mentioned in the compatibility field or SKILL.md")
+		requireNoFileResult(t, results, types.Warning, "scripts/tool.rb", "requires")
+	})
+
+	t.Run("interpreter from compatibility", func(t *testing.T) {
+		dir := t.TempDir()
+		writeScript(t, dir, "scripts/build.mjs", "#!/usr/bin/env -S node --no-warnings\nconsole.log(1)\n")
+		results := CheckScripts(dir, scriptSkill("Requires Node.js 20 and git", ""))
+		requireNoLevel(t, results, types.Warning)
+	})
+
+	t.Run("undeclared python imports", func(t *testing.T) {
+		dir := t.TempDir()
+		writeScript(t, dir, "scripts/fetch.py", "#!/usr/bin/env python3\n"+
+			"import os, sys\n"+
+			"import requests\n"+
+			"from bs4 import BeautifulSoup\n"+
+			"from PIL import Image\n"+
+			"import numpy as np\n"+
+			"from . import helpers\n"+
+			"from util import slugify\n"+
+			"from helpers.merge import merge\n"+
+			"import requests\n")
+		writeFile(t, dir, "scripts/util.py", "def slugify(s): return s\n")
+		writeFile(t, dir, "scripts/helpers/__init__.py", "")
+		writeFile(t, dir, "scripts/helpers/merge.py", "from .base import Base\nimport numpy\n")
+		writeFile(t, dir, "scripts/helpers/base.py", "class Base: pass\n")
+		body := "Requires python3. Install dependencies with `pip install requests pillow`."
+		results := CheckScripts(dir, scriptSkill("", body))
+
+		requireFileResult(t, results, types.Warning, "scripts/fetch.py", `imports third-party module "bs4"`)
+		requireResultContaining(t, results, types.Warning, "(e.g. pip install beautifulsoup4)")
+		requireResultContaining(t, results, types.Warning, `imports third-party module "numpy"`)
+		for _, mod := range []string{`"os"`, `"sys"`, `"requests"`, `"PIL"`, `"util"`, `"helpers"`, `"base"`} {
+			requireNoResultContaining(t, results, types.Warning, "module "+mod)
+		}
+		for _, module := range []string{"scripts/util.py", "scripts/helpers/__init__.py", "scripts/helpers/merge.py", "scripts/helpers/base.py"} {
+			requireNoFileResult(t, results, types.Warning, module, "has no shebang")
+		}
+		requireFileResult(t, results, types.Warning, "scripts/helpers/merge.py", `imports third-party module "numpy"`)
+		for _, r := range results {
+			if r.Level == types.Warning && r.Line == 0 {
+				t.Errorf("expected a line number: %s", r.Message)
+			}
+		}
+	})
+
+	t.Run("inline script metadata", func(t *testing.T) {
+		dir := t.TempDir()
+		writeScript(t, dir, "scripts/fetch.py", "#!/usr/bin/env -S uv run --script\n"+
+			"# /// script\n"+
+			"# dependencies = [\"httpx\", \"rich>=13\"]\n"+
+			"# ///\n"+
+			"import httpx\n"+
+			"from rich.console import Console\n")
+		results := CheckScripts(dir, scriptSkill("Requires uv", ""))
+		requireNoLevel(t, results, types.Warning)
+	})
+}
+
+func TestShebangInterpreter(t *testing.T) {
+	tests := map[string]string{
+		"#!/bin/sh":                     "sh",
+		"#!/usr/bin/env bash":           "bash",
+		"#!/usr/bin/python3.11":         "python",
+		"#!/usr/bin/env -S python3 -u":  "python",
+		"#!/usr/bin/env -S uv run":      "python",
+		"#!/usr/bin/env FOO=1 node":     "node",
+		"#!/usr/bin/env -S deno run -A": "deno",
+		"#!/usr/local/bin/ruby\r":       "ruby",
+		"#!":                            "",
+	}
+	for line, want := range tests {
+		if got := shebangInterpreter(line); got != want {
+			t.Errorf("shebangInterpreter(%q) = %q, want %q", line, got, want)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package structure
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/agent-ecosystem/skill-validator/content"
+	"github.com/agent-ecosystem/skill-validator/skill"
+	"github.com/agent-ecosystem/skill-validator/skillcheck"
+	"github.com/agent-ecosystem/skill-validator/types"
+	"github.com/agent-ecosystem/skill-validator/util"
+)
+
+// maxLargestSections is the number of sections the largest-sections summary
+// lists.
+const maxLargestSections = 5
+
+// CheckSections breaks the SKILL.md body of the skill s in dir, and each
+// markdown file in references/, down by heading, with tokens and content
+// metrics per section. It ranks the largest SKILL.md sections, the ones
+// most worth moving into references/.
+func CheckSections(dir string, s *skill.Skill, opts Options) ([]types.Result, *types.SectionsReport) {
+	ctx := types.ResultContext{Category: "Sections", File: "SKILL.md"}
+	count, err := getCounter(opts.Tokenizer)
+	if err != nil {
+		return []types.Result{ctx.Errorf("failed to initialize tokenizer: %v", err)}, nil
+	}
+
+	body := fileSections("SKILL.md", s.Body, s.BodyLineOffset(), count)
+	sr := &types.SectionsReport{Files: []types.FileSections{body}}
+	refs := skillcheck.ReadReferencesMarkdownFiles(dir)
+	for _, name := range util.SortedKeys(refs) {
+		sr.Files = append(sr.Files, fileSections("references/"+name, refs[name], 0, count))
+	}
+	sr.Largest = largestSections(body, maxLargestSections)
+
+	if len(sr.Largest) == 0 {
+		return []types.Result{ctx.Info("SKILL.md body is empty")}, sr
+	}
+	top := sr.Largest[0]
+	return []types.Result{ctx.InfoAtLinef("SKILL.md", top.Line, "largest SKILL.md section is %s: %s tokens, %.1f%% of the body",
+		top.Label(), util.FormatNumber(top.Tokens), top.Percent)}, sr
+}
+
+// fileSections splits text at its headings and measures each section.
+// lineOffset is added to section lines, so that lines in the SKILL.md body
+// match the file.
+func fileSections(file, text string, lineOffset int, count tokenCounter) types.FileSections {
+	fs := types.FileSections{File: file}
+	for _, sec := range content.SplitSections(text) {
+		cr := content.Analyze(sec.Text)
+		tokens := count(sec.Text)
+		fs.TotalTokens += tokens
+		fs.Sections = append(fs.Sections, types.SectionReport{
+			Heading:         sec.Heading,
+			Level:           sec.Level,
+			Line:            sec.Line + lineOffset,
+			Tokens:          tokens,
+			WordCount:       cr.WordCount,
+			CodeBlockRatio:  cr.CodeBlockRatio,
+			ImperativeRatio: cr.ImperativeRatio,
+			WeakMarkers:     cr.WeakMarkers,
+		})
+	}
+	for i := range fs.Sections {
+		fs.Sections[i].SubtreeTokens = fs.Sections[i].Tokens
+		for j := i + 1; j < len(fs.Sections) && contains(fs.Sections, i, j); j++ {
+			fs.Sections[i].SubtreeTokens += fs.Sections[j].Tokens
+		}
+	}
+	return fs
+}
+
+// contains reports whether sections[j] is a subsection of sections[i]:
+// every section from i+1 through j is nested deeper than i. Text before
+// the first heading contains nothing.
+func contains(sections []types.SectionReport, i, j int) bool {
+	if j <= i || sections[i].Level == 0 {
+		return false
+	}
+	for k := i + 1; k <= j; k++ {
+		if sections[k].Level <= sections[i].Level {
+			return false
+		}
+	}
+	return true
+}
+
+// largestSections ranks up to n sections of fs by their tokens, subsections
+// included. A section inside one already ranked is skipped, and so is a
+// lone top-level heading with subsections, such as a title, since it wraps
+// the whole file.
+func largestSections(fs types.FileSections, n int) []types.SectionSummary {
+	topLevel, atTop := 0, 0
+	for _, sec := range fs.Sections {
+		switch {
+		case sec.Level == 0:
+		case topLevel == 0 || sec.Level < topLevel:
+			topLevel, atTop = sec.Level, 1
+		case sec.Level == topLevel:
+			atTop++
+		}
+	}
+
+	order := make([]int, len(fs.Sections))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return cmp.Compare(fs.Sections[b].SubtreeTokens, fs.Sections[a].SubtreeTokens)
+	})
+
+	var ranked []int
+	var out []types.SectionSummary
+	for _, i := range order {
+		sec := fs.Sections[i]
+		if len(out) == n || sec.SubtreeTokens == 0 {
+			break
+		}
+		if atTop == 1 && sec.Level == topLevel && sec.SubtreeTokens > sec.Tokens {
+			continue
+		}
+		if slices.ContainsFunc(ranked, func(r int) bool { return contains(fs.Sections, r, i) }) {
+			continue
+		}
+		ranked = append(ranked, i)
+		pct := 0.0
+		if fs.TotalTokens > 0 {
+			pct = util.RoundTo(float64(sec.SubtreeTokens)*100/float64(fs.TotalTokens), 1)
+		}
+		out = append(out, types.SectionSummary{
+			File: fs.File, Heading: sec.Heading, Level: sec.Level, Line: sec.Line,
+			Tokens: sec.SubtreeTokens, Percent: pct,
+		})
+	}
+	return out
+}
+
+// formatLargestSections lists sections as "## Setup (2,100 tokens), ...".
+func formatLargestSections(sections []types.SectionSummary) string {
+	parts := make([]string, len(sections))
+	for i, sec := range sections {
+		parts[i] = fmt.Sprintf("%s (%s tokens)", sec.Label(), util.FormatNumber(sec.Tokens))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,110 @@
+package structure
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/agent-ecosystem/skill-validator/types"
+)
+
+func TestCheckSections(t *testing.T) {
+	t.Run("breaks down SKILL.md and references", func(t *testing.T) {
+		body := "# My Skill\n\nIntro.\n\n## Setup\n\n" + generateContent(300) + "\n\n### Linux\n\n" + generateContent(200) +
+			"\n\n## Usage\n\nYou may want to run it. Consider the flags.\n"
+		s := loadTestSkill(t, "---\nname: my-skill\ndescription: Does things.\n---\n"+body)
+		writeFile(t, s.Dir, "references/b.md", "# B\n\nText.\n")
+		writeFile(t, s.Dir, "references/a.md", "# A\n\n## One\n\nText.\n")
+
+		results, sr := CheckSections(s.Dir, s, Options{})
+		requireResultContaining(t, results, types.Info, "largest SKILL.md section is ## Setup")
+
+		if len(sr.Files) != 3 || sr.Files[0].File != "SKILL.md" || sr.Files[1].File != "references/a.md" || sr.Files[2].File != "references/b.md" {
+			t.Fatalf("unexpected files %+v", sr.Files)
+		}
+		secs := sr.Files[0].Sections
+		if len(secs) != 4 {
+			t.Fatalf("expected 4 SKILL.md sections, got %+v", secs)
+		}
+		setup, linux, usage := secs[1], secs[2], secs[3]
+		if setup.Heading != "Setup" || setup.Level != 2 || setup.Line != 9 {
+			t.Errorf("unexpected Setup section %+v", setup)
+		}
+		if setup.SubtreeTokens != setup.Tokens+linux.Tokens {
+			t.Errorf("Setup subtree = %d, want %d", setup.SubtreeTokens, setup.Tokens+linux.Tokens)
+		}
+		if secs[0].SubtreeTokens != sr.Files[0].TotalTokens {
+			t.Errorf("title subtree = %d, want the whole body %d", secs[0].SubtreeTokens, sr.Files[0].TotalTokens)
+		}
+		if usage.WeakMarkers != 2 || usage.WordCount == 0 {
+			t.Errorf("unexpected Usage metrics %+v", usage)
+		}
+		if setup.CodeBlockRatio != 0 || setup.ImperativeRatio != 0 {
+			t.Errorf("unexpected Setup ratios %+v", setup)
+		}
+
+		if len(sr.Largest) != 2 {
+			t.Fatalf("expected Setup and Usage ranked, got %+v", sr.Largest)
+		}
+		if sr.Largest[0].Heading != "Setup" || sr.Largest[0].Tokens != setup.SubtreeTokens || sr.Largest[1].Heading != "Usage" {
+			t.Errorf("unexpected ranking %+v", sr.Largest)
+		}
+		if p := sr.Largest[0].Percent; p <= 50 || p >= 100 {
+			t.Errorf("Setup share = %.1f%%", p)
+		}
+	})
+
+	t.Run("body without headings", func(t *testing.T) {
+		s := loadTestSkill(t, "---\nname: my-skill\ndescription: Does things.\n---\nJust text.\n")
+		_, sr := CheckSections(s.Dir, s, Options{})
+		if len(sr.Largest) != 1 || sr.Largest[0].Label() != "(before first heading)" || sr.Largest[0].Line != 5 {
+			t.Errorf("unexpected ranking %+v", sr.Largest)
+		}
+	})
+
+	t.Run("lone title without subsections is ranked", func(t *testing.T) {
+		s := loadTestSkill(t, "---\nname: my-skill\ndescription: Does things.\n---\n# My Skill\n\nJust text.\n")
+		_, sr := CheckSections(s.Dir, s, Options{})
+		if len(sr.Largest) != 1 || sr.Largest[0].Heading != "My Skill" || sr.Largest[0].Percent != 100 {
+			t.Errorf("unexpected ranking %+v", sr.Largest)
+		}
+	})
+
+	t.Run("unknown tokenizer", func(t *testing.T) {
+		s := loadTestSkill(t, "---\nname: my-skill\ndescription: Does things.\n---\n# My Skill\n")
+		results, sr := CheckSections(s.Dir, s, Options{Tokenizer: "nope"})
+		requireResultContaining(t, results, types.Error, "unknown tokenizer")
+		if sr != nil {
+			t.Errorf("expected no report, got %+v", sr)
+		}
+	})
+}
+
+func TestLargestSections(t *testing.T) {
+	fs := types.FileSections{TotalTokens: 100, Sections: []types.SectionReport{
+		{Heading: "A", Level: 2, Tokens: 10, SubtreeTokens: 50},
+		{Heading: "A1", Level: 3, Tokens: 40, SubtreeTokens: 40},
+		{Heading: "B", Level: 2, Tokens: 30, SubtreeTokens: 30},
+		{Heading: "C", Level: 2, Tokens: 20, SubtreeTokens: 20},
+	}}
+	got := largestSections(fs, 2)
+	if len(got) != 2 || got[0].Heading != "A" || got[1].Heading != "B" {
+		t.Errorf("expected A then B (A1 is inside A), got %+v", got)
+	}
+	if got[0].Percent != 50 {
+		t.Errorf("A share = %.1f, want 50", got[0].Percent)
+	}
+}
+
+func TestCheckTokens_LargeBodyNamesSections(t *testing.T) {
+	dir := t.TempDir()
+	body := "# My Skill\n\n## Setup\n\n" + generateContent(3_500) + "\n\n## Usage\n\n" + generateContent(2_000) + "\n\n## Notes\n\nShort.\n"
+	results, _, _ := CheckTokens(dir, body, Options{})
+	requireResultContaining(t, results, types.Warning, "spec recommends < 5000); largest sections: ## Setup (")
+	for _, r := range results {
+		if r.Level == types.Warning && strings.Contains(r.Message, "largest sections") {
+			if !strings.Contains(r.Message, "## Usage (") || !strings.Contains(r.Message, "## Notes (") || strings.Contains(r.Message, "# My Skill") {
+				t.Errorf("unexpected message %q", r.Message)
+			}
+		}
+	}
+}
@@ -1,16 +1,21 @@
 package structure
 
 import (
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
-	"sync"
 
 	"github.com/agent-ecosystem/skill-validator/types"
-	"github.com/tiktoken-go/tokenizer"
+	"github.com/agent-ecosystem/skill-validator/util"
 )
 
 const (
+	// bodySoftLimit is the SKILL.md body token warning threshold, the spec's
+	// recommendation.
+	bodySoftLimit = 5_000
+
 	// refFileSoftLimit is the per-file token warning threshold for reference files.
 	refFileSoftLimit = 10_000
 	// refFileHardLimit is the per-file token error threshold for reference files.
@@ -27,41 +32,41 @@ const (
 	otherTotalHardLimit = 100_000
 )
 
-var (
-	encoderOnce sync.Once
-	cachedEnc   tokenizer.Codec
-	encoderErr  error
-)
-
-func getEncoder() (tokenizer.Codec, error) {
-	encoderOnce.Do(func() {
-		cachedEnc, encoderErr = tokenizer.Get(tokenizer.O200kBase)
-	})
-	return cachedEnc, encoderErr
-}
-
 // CheckTokens counts tokens for the SKILL.md body, reference files, asset files,
-// and non-standard files. It returns validation results, standard token counts,
-// and non-standard ("other") token counts.
+// and non-standard files. Binary files and scripts are left to
+// CountSupportFiles. It returns validation results, standard token counts,
+// and non-standard ("other") token counts. Tokens are counted with
+// opts.Tokenizer, and the body, reference, and non-standard limits scale
+// with opts.ContextWindow.
 func CheckTokens(dir, body string, opts Options) ([]types.Result, []types.TokenCount, []types.TokenCount) {
 	ctx := types.ResultContext{Category: "Tokens"}
 	var results []types.Result
 	var counts []types.TokenCount
 
-	enc, err := getEncoder()
+	count, err := getCounter(opts.Tokenizer)
 	if err != nil {
 		results = append(results, ctx.Errorf("failed to initialize tokenizer: %v", err))
 		return results, counts, nil
 	}
 
 	// Count SKILL.md body tokens
-	bodyTokens, _, _ := enc.Encode(body)
-	bodyCount := len(bodyTokens)
+	bodyCount := count(body)
 	counts = append(counts, types.TokenCount{File: "SKILL.md body", Tokens: bodyCount})
 
-	// Warn if body exceeds 5000 tokens
-	if bodyCount > 5000 {
-		results = append(results, ctx.WarnFilef("SKILL.md", "SKILL.md body is %d tokens (spec recommends < 5000)", bodyCount))
+	limits := opts.tokenLimits()
+
+	// Warn if body exceeds the spec's 5000 tokens, scaled to the window
+	if bodyCount > limits.bodySoft {
+		msg := fmt.Sprintf("SKILL.md body is %d tokens (spec recommends < %d)", bodyCount, bodySoftLimit)
+		if opts.ContextWindow > 0 {
+			msg = fmt.Sprintf("SKILL.md body is %d tokens%s, over the %s-token limit for this window (spec recommends < %d in a %s-token window)",
+				bodyCount, opts.windowNote(bodyCount), util.FormatNumber(limits.bodySoft), bodySoftLimit, util.FormatNumber(baselineContextWindow))
+		}
+		if fs := fileSections("SKILL.md", body, 0, count); len(fs.Sections) > 1 {
+			largest := largestSections(fs, 3)
+			msg += "; largest sections: " + formatLargestSections(largest) + " — candidates for moving into references/"
+		}
+		results = append(results, ctx.WarnFile("SKILL.md", msg))
 	}
 
 	// Warn if SKILL.md exceeds 500 lines
@@ -85,8 +90,10 @@ func CheckTokens(dir, body string, opts Options) ([]types.Result, []types.TokenC
 				results = append(results, ctx.WarnFilef(relPath, "could not read %s: %v", relPath, err))
 				continue
 			}
-			tokens, _, _ := enc.Encode(string(data))
-			fileTokens := len(tokens)
+			if !util.IsText(data) {
+				continue
+			}
+			fileTokens := count(string(data))
 			relPath := filepath.Join("references", entry.Name())
 			counts = append(counts, types.TokenCount{
 				File:   relPath,
@@ -95,17 +102,17 @@ func CheckTokens(dir, body string, opts Options) ([]types.Result, []types.TokenC
 			refTotal += fileTokens
 
 			// Per-file limits
-			if fileTokens > refFileHardLimit {
+			if fileTokens > limits.refFileHard {
 				results = append(results, ctx.ErrorFilef(relPath,
-					"%s is %d tokens — this will consume 12-20%% of a typical context window "+
+					"%s is %d tokens — this will consume %s "+
 						"and meaningfully degrade agent performance; split into smaller focused files",
-					relPath, fileTokens,
+					relPath, fileTokens, opts.windowShare(fileTokens, "12-20%"),
 				))
-			} else if fileTokens > refFileSoftLimit {
+			} else if fileTokens > limits.refFileSoft {
 				results = append(results, ctx.WarnFilef(relPath,
-					"%s is %d tokens — consider splitting into smaller focused files "+
+					"%s is %d tokens%s — consider splitting into smaller focused files "+
 						"so agents load only what they need",
-					relPath, fileTokens,
+					relPath, fileTokens, opts.windowNote(fileTokens),
 				))
 			}
 		}
@@ -114,85 +121,72 @@ func CheckTokens(dir, body string, opts Options) ([]types.Result, []types.TokenC
 	// When flat layouts are allowed, root-level text files are treated as
 	// standard content (like references/) rather than "other" files.
 	if opts.AllowFlatLayouts {
-		rootCounts := countRootFiles(dir, enc)
+		rootCounts := countRootFiles(dir, count)
 		for _, rc := range rootCounts {
 			counts = append(counts, rc)
 			refTotal += rc.Tokens
 
-			if rc.Tokens > refFileHardLimit {
+			if rc.Tokens > limits.refFileHard {
 				results = append(results, ctx.ErrorFilef(rc.File,
-					"%s is %d tokens — this will consume 12-20%% of a typical context window "+
+					"%s is %d tokens — this will consume %s "+
 						"and meaningfully degrade agent performance; split into smaller focused files",
-					rc.File, rc.Tokens,
+					rc.File, rc.Tokens, opts.windowShare(rc.Tokens, "12-20%"),
 				))
-			} else if rc.Tokens > refFileSoftLimit {
+			} else if rc.Tokens > limits.refFileSoft {
 				results = append(results, ctx.WarnFilef(rc.File,
-					"%s is %d tokens — consider splitting into smaller focused files "+
+					"%s is %d tokens%s — consider splitting into smaller focused files "+
 						"so agents load only what they need",
-					rc.File, rc.Tokens,
+					rc.File, rc.Tokens, opts.windowNote(rc.Tokens),
 				))
 			}
 		}
 	}
 
 	// Aggregate reference limits (includes root files when flat layouts accepted)
-	if refTotal > refTotalHardLimit {
+	if refTotal > limits.refTotalHard {
 		results = append(results, ctx.Errorf(
-			"total reference files: %d tokens — this will consume 25-40%% of a typical "+
-				"context window; reduce content or split into a skill with fewer references",
-			refTotal,
+			"total reference files: %d tokens — this will consume %s; "+
+				"reduce content or split into a skill with fewer references",
+			refTotal, opts.windowShare(refTotal, "25-40%"),
 		))
-	} else if refTotal > refTotalSoftLimit {
+	} else if refTotal > limits.refTotalSoft {
 		results = append(results, ctx.Warnf(
-			"total reference files: %d tokens — agents may load multiple references "+
+			"total reference files: %d tokens%s — agents may load multiple references "+
 				"in one session, consider whether all this content is essential",
-			refTotal,
+			refTotal, opts.windowNote(refTotal),
 		))
 	}
 
 	// Count tokens in non-standard files
-	otherCounts := countOtherFiles(dir, enc, opts)
+	otherCounts := countOtherFiles(dir, count, opts)
 
 	// Check other-files aggregate limits
 	otherTotal := 0
 	for _, c := range otherCounts {
 		otherTotal += c.Tokens
 	}
-	if otherTotal > otherTotalHardLimit {
+	if otherTotal > limits.otherTotalHard {
 		results = append(results, ctx.Errorf(
-			"non-standard files total %d tokens — if an agent loads these, "+
+			"non-standard files total %d tokens%s — if an agent loads these, "+
 				"they will consume most of the context window and severely degrade performance; "+
 				"move essential content into references/ or remove unnecessary files",
-			otherTotal,
+			otherTotal, opts.windowNote(otherTotal),
 		))
-	} else if otherTotal > otherTotalSoftLimit {
+	} else if otherTotal > limits.otherTotalSoft {
 		results = append(results, ctx.Warnf(
-			"non-standard files total %d tokens — if an agent loads these, "+
+			"non-standard files total %d tokens%s — if an agent loads these, "+
 				"they could consume a significant portion of the context window; "+
 				"consider moving essential content into references/ or removing unnecessary files",
-			otherTotal,
+			otherTotal, opts.windowNote(otherTotal),
 		))
 	}
 
-	// Count tokens in text-based asset files
-	assetCounts := countAssetFiles(dir, enc)
-	counts = append(counts, assetCounts...)
+	// Count tokens in text asset files
+	counts = append(counts, countFilesInDir(dir, "assets", count)...)
 
 	return results, counts, otherCounts
 }
 
-// binaryExtensions lists file extensions that are skipped during token counting
-// because they are not text-based content.
-var binaryExtensions = map[string]bool{
-	".png": true, ".jpg": true, ".jpeg": true, ".gif": true, ".bmp": true,
-	".ico": true, ".svg": true, ".webp": true,
-	".pdf": true, ".doc": true, ".docx": true, ".xls": true, ".xlsx": true,
-	".zip": true, ".tar": true, ".gz": true, ".bz2": true, ".7z": true, ".rar": true,
-	".exe": true, ".dll": true, ".so": true, ".dylib": true, ".bin": true,
-	".mp3": true, ".mp4": true, ".wav": true, ".avi": true, ".mov": true,
-	".woff": true, ".woff2": true, ".ttf": true, ".eot": true, ".otf": true,
-}
-
 // standardRootFiles lists root-level files already counted in the main token
 // table, so they are excluded from the "other files" count.
 var standardRootFiles = map[string]bool{
@@ -207,58 +201,7 @@ var standardDirs = map[string]bool{
 	"assets":     true,
 }
 
-// textAssetExtensions lists file extensions in assets/ that are text-based and
-// likely loaded into LLM context (templates, guides, configs). These are
-// included in token counting.
-var textAssetExtensions = map[string]bool{
-	".md":       true,
-	".tex":      true,
-	".py":       true,
-	".yaml":     true,
-	".yml":      true,
-	".tsx":      true,
-	".ts":       true,
-	".jsx":      true,
-	".sty":      true,
-	".mplstyle": true,
-	".ipynb":    true,
-}
-
-func countAssetFiles(dir string, enc tokenizer.Codec) []types.TokenCount {
-	var counts []types.TokenCount
-	assetsDir := filepath.Join(dir, "assets")
-
-	_ = filepath.Walk(assetsDir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return nil
-		}
-		if info.IsDir() {
-			if strings.HasPrefix(info.Name(), ".") && path != assetsDir {
-				return filepath.SkipDir
-			}
-			return nil
-		}
-		if strings.HasPrefix(info.Name(), ".") {
-			return nil
-		}
-		ext := strings.ToLower(filepath.Ext(info.Name()))
-		if !textAssetExtensions[ext] {
-			return nil
-		}
-		data, err := os.ReadFile(path)
-		if err != nil {
-			return nil
-		}
-		rel, _ := filepath.Rel(dir, path)
-		tokens, _, _ := enc.Encode(string(data))
-		counts = append(counts, types.TokenCount{File: rel, Tokens: len(tokens)})
-		return nil
-	})
-
-	return counts
-}
-
-func countOtherFiles(dir string, enc tokenizer.Codec, opts Options) []types.TokenCount {
+func countOtherFiles(dir string, count tokenCounter, opts Options) []types.TokenCount {
 	var counts []types.TokenCount
 
 	entries, err := os.ReadDir(dir)
@@ -277,27 +220,25 @@ func countOtherFiles(dir string, enc tokenizer.Codec, opts Options) []types.Toke
 				continue
 			}
 			// Walk files in unknown directory
-			counts = append(counts, countFilesInDir(dir, name, enc)...)
+			counts = append(counts, countFilesInDir(dir, name, count)...)
 		} else {
 			if standardRootFiles[strings.ToLower(name)] || opts.AllowFlatLayouts {
 				continue
 			}
-			if binaryExtensions[strings.ToLower(filepath.Ext(name))] {
-				continue
-			}
 			data, err := os.ReadFile(filepath.Join(dir, name))
-			if err != nil {
+			if err != nil || !util.IsText(data) {
 				continue
 			}
-			tokens, _, _ := enc.Encode(string(data))
-			counts = append(counts, types.TokenCount{File: name, Tokens: len(tokens)})
+			counts = append(counts, types.TokenCount{File: name, Tokens: count(string(data))})
 		}
 	}
 
 	return counts
 }
 
-func countFilesInDir(rootDir, dirName string, enc tokenizer.Codec) []types.TokenCount {
+// countFilesInDir counts tokens in the text files under dirName, skipping
+// hidden files and directories.
+func countFilesInDir(rootDir, dirName string, count tokenCounter) []types.TokenCount {
 	var counts []types.TokenCount
 	fullDir := filepath.Join(rootDir, dirName)
 
@@ -314,25 +255,70 @@ func countFilesInDir(rootDir, dirName string, enc tokenizer.Codec) []types.Token
 		if strings.HasPrefix(info.Name(), ".") {
 			return nil
 		}
-		if binaryExtensions[strings.ToLower(filepath.Ext(info.Name()))] {
-			return nil
-		}
 		data, err := os.ReadFile(path)
-		if err != nil {
+		if err != nil || !util.IsText(data) {
 			return nil
 		}
 		rel, _ := filepath.Rel(rootDir, path)
-		tokens, _, _ := enc.Encode(string(data))
-		counts = append(counts, types.TokenCount{File: rel, Tokens: len(tokens)})
+		counts = append(counts, types.TokenCount{File: rel, Tokens: count(string(data))})
 		return nil
 	})
 
 	return counts
 }
 
+// CountSupportFiles counts tokens in the text files under scripts/, which
+// agents usually run rather than read, and lists the size of every binary
+// file in the skill, which cannot be tokenized. Hidden files and directories
+// are skipped. If opts.Tokenizer is unknown, scripts are not counted;
+// CheckTokens reports the error.
+func CountSupportFiles(dir string, opts Options) ([]types.TokenCount, []types.FileSize) {
+	var scripts []types.TokenCount
+	if count, err := getCounter(opts.Tokenizer); err == nil {
+		scripts = countFilesInDir(dir, "scripts", count)
+	}
+
+	var binaries []types.FileSize
+	_ = filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if strings.HasPrefix(entry.Name(), ".") && path != dir {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil || !info.Mode().IsRegular() || isTextFile(path) {
+			return nil
+		}
+		rel, _ := filepath.Rel(dir, path)
+		binaries = append(binaries, types.FileSize{File: rel, Bytes: info.Size()})
+		return nil
+	})
+	return scripts, binaries
+}
+
+// isTextFile reports whether the file at path looks like text, reading only
+// as much of it as util.IsText inspects.
+func isTextFile(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer func() { _ = f.Close() }()
+	head := make([]byte, util.SniffLen+1)
+	n, _ := io.ReadFull(f, head)
+	return util.IsText(head[:n])
+}
+
 // countRootFiles counts tokens in non-SKILL.md text files at the skill root.
 // Used when flat layouts are allowed to treat these as standard content.
-func countRootFiles(dir string, enc tokenizer.Codec) []types.TokenCount {
+func countRootFiles(dir string, count tokenCounter) []types.TokenCount {
 	var counts []types.TokenCount
 	entries, err := os.ReadDir(dir)
 	if err != nil {
@@ -346,15 +332,11 @@ func countRootFiles(dir string, enc tokenizer.Codec) []types.TokenCount {
 		if standardRootFiles[strings.ToLower(name)] {
 			continue
 		}
-		if binaryExtensions[strings.ToLower(filepath.Ext(name))] {
-			continue
-		}
 		data, err := os.ReadFile(filepath.Join(dir, name))
-		if err != nil {
+		if err != nil || !util.IsText(data) {
 			continue
 		}
-		tokens, _, _ := enc.Encode(string(data))
-		counts = append(counts, types.TokenCount{File: name, Tokens: len(tokens)})
+		counts = append(counts, types.TokenCount{File: name, Tokens: count(string(data))})
 	}
 	return counts
 }
@@ -220,8 +220,8 @@ func TestCountOtherFiles(t *testing.T) {
 	t.Run("skips binary files", func(t *testing.T) {
 		dir := t.TempDir()
 		writeFile(t, dir, "SKILL.md", "content")
-		writeFile(t, dir, "image.png", "fake png data")
-		writeFile(t, dir, "archive.zip", "fake zip data")
+		writeFile(t, dir, "image.png", pngData)
+		writeFile(t, dir, "archive.zip", "PK\x03\x04\x14\x00\x00\x00")
 		writeFile(t, dir, "notes.txt", "text content")
 		_, _, otherCounts := CheckTokens(dir, "body", Options{})
 		if len(otherCounts) != 1 {
@@ -300,6 +300,9 @@ func TestCheckTokens_OtherFilesLimits(t *testing.T) {
 	})
 }
 
+// pngData is the start of a PNG file: binary, with a NUL byte.
+const pngData = "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"
+
 // assetCounts filters token counts to only those with an "assets/" prefix.
 func assetCounts(counts []types.TokenCount) []types.TokenCount {
 	var out []types.TokenCount
@@ -361,20 +364,21 @@ func TestCountAssetFiles(t *testing.T) {
 		}
 	})
 
-	t.Run("skips non-text asset files", func(t *testing.T) {
+	t.Run("sniffs content rather than extensions", func(t *testing.T) {
 		dir := t.TempDir()
-		writeFile(t, dir, "assets/logo.png", "fake png")
-		writeFile(t, dir, "assets/photo.jpg", "fake jpg")
-		writeFile(t, dir, "assets/icon.svg", "fake svg")
+		writeFile(t, dir, "assets/logo.png", pngData)
+		writeFile(t, dir, "assets/photo.jpg", "\xff\xd8\xff\xe0\x00\x10JFIF")
+		writeFile(t, dir, "assets/latin1.txt", "caf\xe9")
+		writeFile(t, dir, "assets/icon.svg", `<svg xmlns="http://www.w3.org/2000/svg"/>`)
 		writeFile(t, dir, "assets/data.csv", "a,b,c")
 		writeFile(t, dir, "assets/template.md", "# Template")
 		_, counts, _ := CheckTokens(dir, "body", Options{})
-		ac := assetCounts(counts)
-		if len(ac) != 1 {
-			t.Fatalf("expected 1 asset count (template.md only), got %d", len(ac))
+		var files []string
+		for _, c := range assetCounts(counts) {
+			files = append(files, c.File)
 		}
-		if ac[0].File != "assets/template.md" {
-			t.Errorf("expected assets/template.md, got %s", ac[0].File)
+		if strings.Join(files, ",") != "assets/data.csv,assets/icon.svg,assets/template.md" {
+			t.Errorf("unexpected asset counts %v", files)
 		}
 	})
 
@@ -466,3 +470,45 @@ func TestCountAssetFiles(t *testing.T) {
 		}
 	})
 }
+
+func TestCountSupportFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "scripts/run.sh", "#!/bin/sh\necho hello\n")
+	writeFile(t, dir, "scripts/lib/util.py", "def util():\n    return 1\n")
+	writeFile(t, dir, "scripts/tool.bin", "\x7fELF\x02\x01\x01\x00")
+	writeFile(t, dir, "assets/logo.png", pngData)
+	writeFile(t, dir, "references/diagram.png", pngData)
+	writeFile(t, dir, "assets/.cache/blob", "\x00\x00")
+	writeFile(t, dir, "references/guide.md", "# Guide\n")
+
+	scripts, binaries := CountSupportFiles(dir, Options{})
+	var names []string
+	for _, c := range scripts {
+		names = append(names, c.File)
+		if c.Tokens <= 0 {
+			t.Errorf("expected positive tokens for %s", c.File)
+		}
+	}
+	if strings.Join(names, ",") != "scripts/lib/util.py,scripts/run.sh" {
+		t.Errorf("scripts = %v", names)
+	}
+
+	names = nil
+	for _, b := range binaries {
+		names = append(names, b.File)
+		if b.Bytes != int64(len(pngData)) && b.File != "scripts/tool.bin" {
+			t.Errorf("%s: %d bytes", b.File, b.Bytes)
+		}
+	}
+	if strings.Join(names, ",") != "assets/logo.png,references/diagram.png,scripts/tool.bin" {
+		t.Errorf("binaries = %v", names)
+	}
+
+	// Binary references are not counted as tokens.
+	_, counts, _ := CheckTokens(dir, "body", Options{})
+	for _, c := range counts {
+		if c.File == "references/diagram.png" || strings.HasPrefix(c.File, "scripts/") {
+			t.Errorf("unexpected token count for %s", c.File)
+		}
+	}
+}
@@ -0,0 +1,353 @@
+package structure
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/agent-ecosystem/skill-validator/skill"
+	"github.com/agent-ecosystem/skill-validator/types"
+	"github.com/agent-ecosystem/skill-validator/util"
+)
+
+// toolVocabularies lists the tool names each agent platform accepts in
+// allowed-tools. MCP tools (mcp__server__tool) are accepted on every platform.
+var toolVocabularies = map[string][]string{
+	"claude-code": {
+		"Bash", "BashOutput", "Edit", "ExitPlanMode", "Glob", "Grep", "KillShell", "LS",
+		"MultiEdit", "NotebookEdit", "NotebookRead", "Read", "Skill", "SlashCommand",
+		"Task", "TodoWrite", "WebFetch", "WebSearch", "Write",
+	},
+	"opencode": {
+		"bash", "edit", "glob", "grep", "list", "patch", "read", "task",
+		"todoread", "todowrite", "webfetch", "write",
+	},
+	"gemini-cli": {
+		"glob", "google_web_search", "list_directory", "read_file", "read_many_files",
+		"replace", "run_shell_command", "save_memory", "search_file_content",
+		"web_fetch", "write_file",
+	},
+}
+
+// ToolPlatforms returns the names of the platforms with a built-in tool
+// vocabulary, in sorted order.
+func ToolPlatforms() []string {
+	return util.SortedKeys(toolVocabularies)
+}
+
+// shellTools are the tools that run arbitrary shell commands.
+var shellTools = map[string]bool{"bash": true, "run_shell_command": true}
+
+// readOnlyTools are granted routinely to read the skill's own files, so they
+// are not reported as unused.
+var readOnlyTools = map[string]bool{
+	"read": true, "glob": true, "grep": true, "ls": true, "list": true,
+	"notebookread": true, "read_file": true, "read_many_files": true,
+	"list_directory": true, "search_file_content": true,
+}
+
+var (
+	// shellFenceLangs are code fence languages whose contents are commands.
+	shellFenceLangs = map[string]bool{
+		"bash": true, "sh": true, "shell": true, "zsh": true, "console": true,
+		"terminal": true, "shell-session": true,
+	}
+
+	fencePattern      = regexp.MustCompile("(?ms)^\\s*(```|~~~)[ \\t]*([\\w+-]*)[^\\n]*\\n(.*?)^\\s*(?:```|~~~)\\s*$")
+	runScriptPattern  = regexp.MustCompile("(?i)\\b(?:run|execute|invoke)\\s+`?((?:\\./)?scripts/[\\w./-]+)")
+	envAssignPattern  = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*=`)
+	toolMentionFormat = "(?:`%[1]s`|\\b%[1]s tool\\b)"
+
+	// toolMentionPatterns match a mention of each vocabulary tool in prose.
+	toolMentionPatterns = func() map[string]*regexp.Regexp {
+		patterns := make(map[string]*regexp.Regexp)
+		for _, names := range toolVocabularies {
+			for _, name := range names {
+				patterns[name] = toolMentionPattern(name)
+			}
+		}
+		return patterns
+	}()
+)
+
+// toolMentionPattern returns the pattern matching a mention of the tool name
+// in prose: the name in backticks, or followed by "tool".
+func toolMentionPattern(name string) *regexp.Regexp {
+	return regexp.MustCompile(fmt.Sprintf(toolMentionFormat, regexp.QuoteMeta(name)))
+}
+
+// toolVocabulary returns the known tool names for the options. With no
+// platform set it is the union of every platform's vocabulary.
+func toolVocabulary(opts Options) []string {
+	var names []string
+	if platform := opts.toolPlatform(); platform != "" {
+		names = append(names, toolVocabularies[platform]...)
+	} else {
+		for _, p := range ToolPlatforms() {
+			names = append(names, toolVocabularies[p]...)
+		}
+	}
+	return append(names, opts.KnownTools...)
+}
+
+// checkAllowedTools validates the allowed-tools entries against the tool
+// vocabulary, warns about unrestricted shell access, and cross-checks the
+// grants against what the body's instructions use.
+func checkAllowedTools(ctx types.ResultContext, s *skill.Skill, opts Options) []types.Result {
+	tools, err := s.Frontmatter.AllowedTools.Tools()
+	if err != nil {
+		return []types.Result{ctx.Error(err.Error())}
+	}
+
+	var results []types.Result
+	vocab := toolVocabulary(opts)
+	platform := opts.toolPlatform()
+	if platform == "" {
+		platform = "any known platform"
+	}
+	for _, t := range tools {
+		if strings.HasPrefix(t.Name, "mcp__") {
+			continue
+		}
+		if known, exact := lookupTool(vocab, t.Name, opts.toolPlatform() != ""); !exact {
+			if known != "" {
+				results = append(results, ctx.Warnf("allowed-tools: unknown tool %q for %s (did you mean %q?)", t.Name, platform, known))
+			} else {
+				results = append(results, ctx.Warnf("allowed-tools: unknown tool %q for %s", t.Name, platform))
+			}
+		}
+		if shellTools[strings.ToLower(t.Name)] && (t.Pattern == "" || t.Pattern == "*") {
+			results = append(results, ctx.Warnf("allowed-tools grants unrestricted %s; scope it to the commands the skill runs, e.g. %s(git:*)", t, t.Name))
+		}
+	}
+
+	return append(results, crossCheckTools(ctx, s.Body, tools, vocab)...)
+}
+
+// lookupTool finds name in vocab. exact reports an exact match (or a
+// case-insensitive one when caseSensitive is false); otherwise known is the
+// closest vocabulary entry, if any is close enough to be a likely typo
+// (case differences, or about one edit per four characters).
+func lookupTool(vocab []string, name string, caseSensitive bool) (known string, exact bool) {
+	best, bestDist := "", max(1, len(name)/4)+1
+	for _, v := range vocab {
+		if v == name || (!caseSensitive && strings.EqualFold(v, name)) {
+			return v, true
+		}
+		if strings.EqualFold(v, name) {
+			return v, false
+		}
+		if d := editDistance(strings.ToLower(v), strings.ToLower(name)); d < bestDist {
+			best, bestDist = v, d
+		}
+	}
+	return best, false
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+// crossCheckTools compares the grants with the body: shell commands and
+// named tools the instructions use but allowed-tools does not grant are
+// warnings, and grants the instructions never use are reported as info.
+func crossCheckTools(ctx types.ResultContext, body string, tools []skill.Tool, vocab []string) []types.Result {
+	var results []types.Result
+	commands := bodyCommands(body)
+
+	var shellGrants []skill.Tool
+	granted := make(map[string]bool)
+	for _, t := range tools {
+		granted[strings.ToLower(t.Name)] = true
+		if shellTools[strings.ToLower(t.Name)] {
+			shellGrants = append(shellGrants, t)
+		}
+	}
+
+	// Shell commands the grants don't cover
+	if len(commands) > 0 {
+		if len(shellGrants) == 0 {
+			results = append(results, ctx.Warnf("instructions run shell commands (%s) but allowed-tools does not grant a shell tool",
+				strings.Join(commandNames(commands), ", ")))
+		} else {
+			var uncovered []string
+			for _, c := range commands {
+				if !shellCovers(shellGrants, c) {
+					uncovered = append(uncovered, c)
+				}
+			}
+			if len(uncovered) > 0 {
+				results = append(results, ctx.Warnf("instructions run %s, which no allowed-tools pattern covers (granted: %s)",
+					strings.Join(commandNames(uncovered), ", "), joinTools(shellGrants)))
+			}
+		}
+	}
+
+	// Tools the instructions name but allowed-tools doesn't grant. Only
+	// capitalized names are matched; lowercase ones read as ordinary words.
+	prose := util.CodeBlockStrip.ReplaceAllString(body, "")
+	for _, name := range vocab {
+		if granted[strings.ToLower(name)] || shellTools[strings.ToLower(name)] || name[0] < 'A' || name[0] > 'Z' {
+			continue
+		}
+		re, ok := toolMentionPatterns[name]
+		if !ok {
+			// A --known-tools name
+			re = toolMentionPattern(name)
+		}
+		if re.MatchString(prose) {
+			results = append(results, ctx.Warnf("instructions refer to the %s tool, which allowed-tools does not grant", name))
+			granted[strings.ToLower(name)] = true
+		}
+	}
+
+	// Grants the instructions never use
+	lowerBody := strings.ToLower(body)
+	for _, t := range tools {
+		lower := strings.ToLower(t.Name)
+		switch {
+		case readOnlyTools[lower] || strings.HasPrefix(t.Name, "mcp__"):
+			continue
+		case shellTools[lower] && (t.Pattern == "" || t.Pattern == "*"):
+			if len(commands) == 0 {
+				results = append(results, ctx.Infof("allowed-tools grants %s but the instructions never run a shell command", t))
+			}
+		case shellTools[lower]:
+			if !shellPatternUsed(t.Pattern, commands, lowerBody) {
+				results = append(results, ctx.Infof("allowed-tools grants %s but the instructions never run %s", t, patternCommand(t.Pattern)))
+			}
+		case !containsWord(lowerBody, lower):
+			results = append(results, ctx.Infof("allowed-tools grants %s but the instructions never mention it", t))
+		}
+	}
+	return results
+}
+
+// bodyCommands returns the shell command lines in the body: each line of a
+// shell code fence, and scripts/ paths the prose tells the agent to run.
+// Duplicates are removed; order is first appearance.
+func bodyCommands(body string) []string {
+	var commands []string
+	seen := make(map[string]bool)
+	add := func(c string) {
+		if c != "" && !seen[c] {
+			seen[c] = true
+			commands = append(commands, c)
+		}
+	}
+	for _, m := range fencePattern.FindAllStringSubmatch(body, -1) {
+		if !shellFenceLangs[strings.ToLower(m[2])] {
+			continue
+		}
+		for _, line := range strings.Split(m[3], "\n") {
+			add(commandLine(line))
+		}
+	}
+	for _, m := range runScriptPattern.FindAllStringSubmatch(util.CodeBlockStrip.ReplaceAllString(body, ""), -1) {
+		add(strings.TrimPrefix(strings.TrimRight(m[1], "."), "./"))
+	}
+	return commands
+}
+
+// commandLine normalizes one line of a shell fence into the command it runs,
+// dropping prompts, comments, environment assignments, and sudo. Returns ""
+// for lines that run nothing, such as output or continuation lines.
+func commandLine(line string) string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "$ ")
+	if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "-") {
+		return ""
+	}
+	fields := strings.Fields(line)
+	for len(fields) > 0 && (envAssignPattern.MatchString(fields[0]) || fields[0] == "sudo") {
+		fields = fields[1:]
+	}
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.TrimPrefix(strings.Join(fields, " "), "./")
+}
+
+// commandNames returns the distinct program names of commands, sorted.
+func commandNames(commands []string) []string {
+	set := make(map[string]bool)
+	for _, c := range commands {
+		set[strings.Fields(c)[0]] = true
+	}
+	return util.SortedKeys(set)
+}
+
+// shellCovers reports whether any shell grant permits command. A pattern
+// ending in ":*" or "*" is a prefix; anything else must match exactly.
+func shellCovers(grants []skill.Tool, command string) bool {
+	for _, g := range grants {
+		p := strings.TrimPrefix(g.Pattern, "./")
+		switch {
+		case p == "" || p == "*":
+			return true
+		case strings.HasSuffix(p, ":*"):
+			prefix := strings.TrimSuffix(p, ":*")
+			if command == prefix || strings.HasPrefix(command, prefix+" ") {
+				return true
+			}
+		case strings.HasSuffix(p, "*"):
+			if strings.HasPrefix(command, strings.TrimSuffix(p, "*")) {
+				return true
+			}
+		case command == p:
+			return true
+		}
+	}
+	return false
+}
+
+// patternCommand returns the program a shell pattern grants, e.g. "git" for
+// "git log:*".
+func patternCommand(pattern string) string {
+	p := strings.TrimSuffix(strings.TrimSuffix(pattern, ":*"), "*")
+	if f := strings.Fields(p); len(f) > 0 {
+		return strings.TrimPrefix(f[0], "./")
+	}
+	return p
+}
+
+// shellPatternUsed reports whether the instructions use the program a shell
+// pattern grants, either in a command or anywhere in the body.
+func shellPatternUsed(pattern string, commands []string, lowerBody string) bool {
+	prog := patternCommand(pattern)
+	if prog == "" {
+		return true
+	}
+	for _, c := range commands {
+		if strings.Fields(c)[0] == prog {
+			return true
+		}
+	}
+	return strings.Contains(lowerBody, strings.ToLower(prog))
+}
+
+// joinTools formats tools as a comma-separated list.
+func joinTools(tools []skill.Tool) string {
+	parts := make([]string, len(tools))
+	for i, t := range tools {
+		parts[i] = t.String()
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,156 @@
+package structure
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/agent-ecosystem/skill-validator/skill"
+	"github.com/agent-ecosystem/skill-validator/types"
+)
+
+func toolSkill(allowed, body string) *skill.Skill {
+	return &skill.Skill{
+		Frontmatter: skill.Frontmatter{
+			Name:         "s",
+			Description:  "d",
+			AllowedTools: skill.AllowedTools{Value: allowed},
+		},
+		Body: body,
+	}
+}
+
+func checkTools(allowed, body string, opts Options) []types.Result {
+	return checkAllowedTools(types.ResultContext{Category: "Frontmatter"}, toolSkill(allowed, body), opts)
+}
+
+func TestCheckAllowedTools(t *testing.T) {
+	t.Run("known tools used by the body", func(t *testing.T) {
+		body := "Use the `Write` tool to save the report.\n\n```bash\ngit log --oneline\n```\n"
+		results := checkTools("Bash(git:*) Read Write", body, Options{})
+		requireNoLevel(t, results, types.Warning)
+		requireNoLevel(t, results, types.Info)
+	})
+
+	t.Run("unknown tool with suggestion", func(t *testing.T) {
+		results := checkTools("Grepp", "", Options{})
+		requireResult(t, results, types.Warning, `allowed-tools: unknown tool "Grepp" for any known platform (did you mean "Grep"?)`)
+	})
+
+	t.Run("unknown tool without suggestion", func(t *testing.T) {
+		results := checkTools("Teleport", "", Options{})
+		requireResult(t, results, types.Warning, `allowed-tools: unknown tool "Teleport" for any known platform`)
+	})
+
+	t.Run("platform vocabulary is case sensitive", func(t *testing.T) {
+		results := checkTools("read", "", Options{ToolPlatform: "claude-code"})
+		requireResult(t, results, types.Warning, `allowed-tools: unknown tool "read" for claude-code (did you mean "Read"?)`)
+		requireNoLevel(t, checkTools("read", "", Options{ToolPlatform: "opencode"}), types.Warning)
+	})
+
+	t.Run("known tools option", func(t *testing.T) {
+		results := checkTools("DeployTool", "Use the deploytool.", Options{KnownTools: []string{"DeployTool"}})
+		requireNoLevel(t, results, types.Warning)
+	})
+
+	t.Run("mcp tools are accepted", func(t *testing.T) {
+		results := checkTools("mcp__github__create_issue", "", Options{ToolPlatform: "claude-code"})
+		requireNoLevel(t, results, types.Warning)
+		requireNoLevel(t, results, types.Info)
+	})
+
+	t.Run("parse error", func(t *testing.T) {
+		results := checkTools("Bash(git:*", "", Options{})
+		requireResultContaining(t, results, types.Error, "unclosed")
+	})
+
+	t.Run("unrestricted bash", func(t *testing.T) {
+		body := "```bash\nnpm test\n```\n"
+		requireResult(t, checkTools("Bash", body, Options{}), types.Warning,
+			"allowed-tools grants unrestricted Bash; scope it to the commands the skill runs, e.g. Bash(git:*)")
+		requireResult(t, checkTools("Bash(*)", body, Options{}), types.Warning,
+			"allowed-tools grants unrestricted Bash(*); scope it to the commands the skill runs, e.g. Bash(git:*)")
+	})
+
+	t.Run("shell commands without a shell grant", func(t *testing.T) {
+		body := "```sh\n$ npm install\n$ npm test\n```\n\nThen run scripts/check.sh.\n"
+		results := checkTools("Read", body, Options{})
+		requireResult(t, results, types.Warning,
+			"instructions run shell commands (npm, scripts/check.sh) but allowed-tools does not grant a shell tool")
+	})
+
+	t.Run("command not covered by a pattern", func(t *testing.T) {
+		body := "```bash\ngit status\nnpm test\n```\n"
+		results := checkTools("Bash(git:*)", body, Options{})
+		requireResult(t, results, types.Warning, "instructions run npm, which no allowed-tools pattern covers (granted: Bash(git:*))")
+	})
+
+	t.Run("exact script pattern", func(t *testing.T) {
+		body := "Run `scripts/setup.sh` first.\n"
+		requireNoLevel(t, checkTools("Bash(scripts/setup.sh)", body, Options{}), types.Warning)
+		requireNoLevel(t, checkTools("Bash(./scripts/setup.sh)", body, Options{}), types.Warning)
+	})
+
+	t.Run("named tool not granted", func(t *testing.T) {
+		body := "Fetch the page with the WebFetch tool, then summarize it.\n"
+		results := checkTools("Read", body, Options{})
+		requireResult(t, results, types.Warning, "instructions refer to the WebFetch tool, which allowed-tools does not grant")
+	})
+
+	t.Run("tool names inside code are not mentions", func(t *testing.T) {
+		body := "```python\nclient = WebFetch()\n```\n"
+		requireNoLevel(t, checkTools("Read", body, Options{}), types.Warning)
+	})
+
+	t.Run("unused grants", func(t *testing.T) {
+		results := checkTools("Bash Bash(docker:*) Write Read", "Summarize the file.\n", Options{})
+		requireResult(t, results, types.Info, "allowed-tools grants Bash but the instructions never run a shell command")
+		requireResult(t, results, types.Info, "allowed-tools grants Bash(docker:*) but the instructions never run docker")
+		requireResult(t, results, types.Info, "allowed-tools grants Write but the instructions never mention it")
+		requireNoResultContaining(t, results, types.Info, "grants Read")
+	})
+}
+
+func TestShellCovers(t *testing.T) {
+	tests := []struct {
+		pattern string
+		command string
+		want    bool
+	}{
+		{"", "rm -rf build", true},
+		{"*", "anything", true},
+		{"git:*", "git", true},
+		{"git:*", "git log", true},
+		{"git:*", "gitk", false},
+		{"git log:*", "git log -5", true},
+		{"git log:*", "git push", false},
+		{"npm run*", "npm run build", true},
+		{"scripts/setup.sh", "scripts/setup.sh", true},
+		{"scripts/setup.sh", "scripts/setup.sh --force", false},
+	}
+	for _, tt := range tests {
+		got := shellCovers([]skill.Tool{{Name: "Bash", Pattern: tt.pattern}}, tt.command)
+		if got != tt.want {
+			t.Errorf("shellCovers(%q, %q) = %v, want %v", tt.pattern, tt.command, got, tt.want)
+		}
+	}
+}
+
+func TestValidate_ToolsFixture(t *testing.T) {
+	r := Validate("../testdata/tools-skill", Options{})
+	var tools []types.Result
+	for _, res := range r.Results {
+		if res.Category == "Frontmatter" && strings.Contains(res.Message, "tool") {
+			tools = append(tools, res)
+		}
+	}
+	requireResult(t, tools, types.Warning, `allowed-tools: unknown tool "Grepp" for any known platform (did you mean "Grep"?)`)
+	requireResult(t, tools, types.Warning, "instructions run npm, which no allowed-tools pattern covers (granted: Bash(git:*), Bash(scripts/report.sh))")
+	requireResult(t, tools, types.Warning, "instructions refer to the WebFetch tool, which allowed-tools does not grant")
+	requireResult(t, tools, types.Info, "allowed-tools grants WebSearch but the instructions never mention it")
+	// Scoped shell grants cover git and the bundled script, and Write is used.
+	requireNoResultContaining(t, tools, types.Warning, "unrestricted")
+	requireNoResultContaining(t, tools, types.Info, "grants Write")
+	if r.Errors != 0 {
+		t.Errorf("expected no errors, got %d", r.Errors)
+	}
+}
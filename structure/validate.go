@@ -1,9 +1,11 @@
 // Package structure validates the directory layout, frontmatter, token counts,
-// markdown syntax, internal links, and orphan files of a skill package. It is
-// the main validation entry point used by the CLI.
+// markdown syntax, scripts, internal links, and orphan files of a skill
+// package. It is the main validation entry point used by the CLI.
 package structure
 
 import (
+	"errors"
+
 	"github.com/agent-ecosystem/skill-validator/skill"
 	"github.com/agent-ecosystem/skill-validator/types"
 	"github.com/agent-ecosystem/skill-validator/util"
@@ -15,9 +17,35 @@ type Options struct {
 	AllowExtraFrontmatter bool
 	AllowFlatLayouts      bool
 	AllowDirs             []string
+	// ToolPlatform restricts allowed-tools to one platform's vocabulary
+	// (see ToolPlatforms). Empty accepts any known platform's tool names.
+	ToolPlatform string
+	// KnownTools adds tool names to the allowed-tools vocabulary.
+	KnownTools []string
+	// AllowedLicenses, when set, is the license policy: each skill's license
+	// must be an SPDX expression satisfiable using only these licenses.
+	AllowedLicenses []string
+	// Profile selects a platform profile (see Profiles): its frontmatter
+	// extensions, limits, tool vocabulary, and skills directories.
+	Profile string
+	// FrontmatterSchema, when set, validates the whole frontmatter
+	// (see LoadFrontmatterSchema).
+	FrontmatterSchema *FrontmatterSchema
+	// Tokenizer selects how tokens are counted (see Tokenizers). Empty uses
+	// DefaultTokenizer.
+	Tokenizer string
+	// ContextWindow, when set, is the context window size in tokens: the
+	// reference and non-standard file limits scale with it, and reports give
+	// each file as a percentage of it (see ParseContextWindow).
+	ContextWindow int
+	// DescriptionBudget, when set, is the most tokens the names and
+	// descriptions of a multi-skill collection may total (see
+	// CheckDescriptionBudget).
+	DescriptionBudget int
 }
 
-// ValidateMulti validates each directory and returns an aggregated report.
+// ValidateMulti validates each directory, then the skills together (see
+// CheckCollection), and returns an aggregated report.
 func ValidateMulti(dirs []string, opts Options) *types.MultiReport {
 	mr := &types.MultiReport{}
 	for _, dir := range dirs {
@@ -26,6 +54,7 @@ func ValidateMulti(dirs []string, opts Options) *types.MultiReport {
 		mr.Errors += r.Errors
 		mr.Warnings += r.Warnings
 	}
+	mr.AddCollection(CheckCollection(dirs, opts))
 	return mr
 }
 
@@ -36,6 +65,7 @@ func Validate(dir string, opts Options) *types.Report {
 	// Structure checks
 	structResults := CheckStructure(dir, opts)
 	report.Results = append(report.Results, structResults...)
+	report.Results = append(report.Results, checkProfileLocation(dir, opts)...)
 
 	// Check if SKILL.md was found; if not, skip further checks
 	hasSkillMD := false
@@ -53,8 +83,13 @@ func Validate(dir string, opts Options) *types.Report {
 	// Parse skill
 	s, err := skill.Load(dir)
 	if err != nil {
-		report.Results = append(report.Results,
-			types.ResultContext{Category: "Frontmatter", File: "SKILL.md"}.Error(err.Error()))
+		ctx := types.ResultContext{Category: "Frontmatter", File: "SKILL.md"}
+		var fe *skill.FrontmatterError
+		if errors.As(err, &fe) {
+			report.Results = append(report.Results, ctx.ErrorAtLine("SKILL.md", fe.Line, fe.Msg))
+		} else {
+			report.Results = append(report.Results, ctx.Error(err.Error()))
+		}
 		report.Tally()
 		return report
 	}
@@ -62,20 +97,35 @@ func Validate(dir string, opts Options) *types.Report {
 	// Frontmatter checks
 	report.Results = append(report.Results, CheckFrontmatter(s, opts)...)
 
+	// Compatibility claims and their consistency with the skill's commands
+	compatResults, compat := CheckCompatibility(dir, s)
+	report.Results = append(report.Results, compatResults...)
+	report.Compatibility = compat
+
 	// Token checks
 	tokenResults, tokenCounts, otherCounts := CheckTokens(dir, s.Body, opts)
 	report.Results = append(report.Results, tokenResults...)
 	report.TokenCounts = tokenCounts
 	report.OtherTokenCounts = otherCounts
+	report.TokenBudget = opts.TokenBudget()
+	report.ScriptTokenCounts, report.BinaryFiles = CountSupportFiles(dir, opts)
+
+	// Duplicated paragraphs and code blocks spend tokens twice
+	report.Results = append(report.Results, CheckDuplicates(dir, s, opts)...)
 
 	// Holistic structure check: is this actually a skill?
 	report.Results = append(report.Results, checkSkillRatio(report.TokenCounts, report.OtherTokenCounts)...)
 
-	// Markdown structure checks (unclosed code fences)
+	// Markdown structure checks (unclosed code fences, headings, numbered lists)
 	report.Results = append(report.Results, CheckMarkdown(dir, s.Body)...)
+	report.Results = append(report.Results, CheckDocumentStructure(dir, s)...)
+
+	// Script hygiene checks (shebangs, permissions, interpreters, imports)
+	report.Results = append(report.Results, CheckScripts(dir, s)...)
 
 	// Internal link checks (broken relative links are a structural issue)
-	report.Results = append(report.Results, CheckInternalLinks(dir, s.Body)...)
+	report.Results = append(report.Results, CheckInternalLinks(dir, s.Body, s.BodyLineOffset())...)
+	report.Results = append(report.Results, CheckInternalLinksInFiles(dir)...)
 
 	// Orphan file checks (files in recognized dirs that are never referenced)
 	if !opts.SkipOrphans {
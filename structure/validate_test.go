@@ -166,6 +166,23 @@ func TestValidate(t *testing.T) {
 			t.Errorf("expected 1 error, got %d", report.Errors)
 		}
 		requireResultContaining(t, report.Results, types.Error, "parsing frontmatter YAML")
+		for _, r := range report.Results {
+			if r.Level == types.Error && r.Line != 2 {
+				t.Errorf("expected the parse error on line 2, got %d", r.Line)
+			}
+		}
+	})
+
+	t.Run("frontmatter syntax error line", func(t *testing.T) {
+		dir := t.TempDir()
+		writeSkill(t, dir, "---\nname: "+dirName(dir)+"\ndescription: d\n\tbad: indent\n---\nBody\n")
+		report := Validate(dir, Options{})
+		requireResultContaining(t, report.Results, types.Error, "line 4: found a tab character")
+		for _, r := range report.Results {
+			if r.Level == types.Error && r.Line != 4 {
+				t.Errorf("expected the parse error on line 4, got %d", r.Line)
+			}
+		}
 	})
 
 	t.Run("allow-dirs suppresses unknown dir warning in full validation", func(t *testing.T) {
@@ -57,6 +57,11 @@ func (c ResultContext) Errorf(format string, args ...any) Result {
 // PassFile creates a pass result with an explicit file.
 func (c ResultContext) PassFile(file, msg string) Result { return c.result(Pass, file, 0, msg) }
 
+// InfoFilef creates a formatted info result with an explicit file.
+func (c ResultContext) InfoFilef(file, format string, args ...any) Result {
+	return c.result(Info, file, 0, fmt.Sprintf(format, args...))
+}
+
 // WarnFile creates a warning result with an explicit file.
 func (c ResultContext) WarnFile(file, msg string) Result { return c.result(Warning, file, 0, msg) }
 
@@ -82,3 +87,23 @@ func (c ResultContext) ErrorAtLine(file string, line int, msg string) Result {
 func (c ResultContext) ErrorAtLinef(file string, line int, format string, args ...any) Result {
 	return c.result(Error, file, line, fmt.Sprintf(format, args...))
 }
+
+// PassAtLinef creates a formatted pass result with an explicit file and line number.
+func (c ResultContext) PassAtLinef(file string, line int, format string, args ...any) Result {
+	return c.result(Pass, file, line, fmt.Sprintf(format, args...))
+}
+
+// InfoAtLinef creates a formatted info result with an explicit file and line number.
+func (c ResultContext) InfoAtLinef(file string, line int, format string, args ...any) Result {
+	return c.result(Info, file, line, fmt.Sprintf(format, args...))
+}
+
+// WarnAtLine creates a warning result with an explicit file and line number.
+func (c ResultContext) WarnAtLine(file string, line int, msg string) Result {
+	return c.result(Warning, file, line, msg)
+}
+
+// WarnAtLinef creates a formatted warning result with an explicit file and line number.
+func (c ResultContext) WarnAtLinef(file string, line int, format string, args ...any) Result {
+	return c.result(Warning, file, line, fmt.Sprintf(format, args...))
+}
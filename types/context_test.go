@@ -74,10 +74,15 @@ func TestResultContext_Formatters(t *testing.T) {
 		{"Pass", ctx.Pass("ok"), Pass},
 		{"Info", ctx.Info("note"), Info},
 		{"PassFile", ctx.PassFile("other.md", "ok"), Pass},
+		{"InfoFilef", ctx.InfoFilef("other.md", "i: %d", 1), Info},
 		{"WarnFilef", ctx.WarnFilef("other.md", "w: %d", 1), Warning},
 		{"ErrorFile", ctx.ErrorFile("other.md", "e"), Error},
 		{"ErrorFilef", ctx.ErrorFilef("other.md", "e: %d", 2), Error},
 		{"ErrorAtLine", ctx.ErrorAtLine("f.md", 10, "err"), Error},
+		{"PassAtLinef", ctx.PassAtLinef("f.md", 3, "ok: %d", 1), Pass},
+		{"InfoAtLinef", ctx.InfoAtLinef("f.md", 4, "note: %d", 1), Info},
+		{"WarnAtLine", ctx.WarnAtLine("f.md", 5, "warn"), Warning},
+		{"WarnAtLinef", ctx.WarnAtLinef("f.md", 6, "warn: %d", 1), Warning},
 	}
 
 	for _, tt := range tests {
@@ -3,6 +3,11 @@
 // skill modes, and aggregated reports.
 package types
 
+import (
+	"math"
+	"strings"
+)
+
 // Level represents the severity of a validation result.
 type Level int
 
@@ -35,11 +40,35 @@ func (l Level) String() string {
 
 // Result represents a single validation finding.
 type Result struct {
-	Level    Level
-	Category string
-	Message  string
-	File     string // path relative to skill dir, e.g. "SKILL.md", "references/guide.md"
-	Line     int    // 0 = no line info
+	Level     Level
+	Category  string
+	Message   string
+	File      string     // path relative to skill dir, e.g. "SKILL.md", "references/guide.md"
+	Line      int        // 0 = no line info
+	Column    int        // 1-based column within Line, in characters; 0 = no column info
+	Fix       *Fix       // mechanical edit that resolves the finding; nil if none
+	Redirects *Redirects // redirects an external link followed; nil if none
+}
+
+// Fix describes a text replacement in Result.File that resolves a finding,
+// such as swapping a permanently redirected URL for its new location.
+type Fix struct {
+	Old string
+	New string
+}
+
+// Redirects is the redirect chain of a link: each hop in order, and the URL
+// the chain ended at.
+type Redirects struct {
+	Hops     []RedirectHop
+	FinalURL string
+}
+
+// RedirectHop is one redirect: the server answered with Status and sent the
+// client on to URL.
+type RedirectHop struct {
+	Status int    `json:"status"`
+	URL    string `json:"url"`
 }
 
 // TokenCount holds the token count for a single file.
@@ -48,6 +77,82 @@ type TokenCount struct {
 	Tokens int
 }
 
+// FileSize holds the size of a file that is not counted in tokens.
+type FileSize struct {
+	File  string `json:"file"`
+	Bytes int64  `json:"bytes"`
+}
+
+// DiffReport compares two versions of a skill.
+type DiffReport struct {
+	Old string
+	New string
+	// Totals compares the SKILL.md body, references and assets, other
+	// files, scripts, and the total an agent can read.
+	Totals []TokenDelta
+	// Files holds each file whose token count changed, largest change first.
+	Files   []TokenDelta
+	Metrics []MetricDelta
+	// NewFindings and ResolvedFindings are the errors and warnings only the
+	// new or only the old version has.
+	NewFindings      []Result
+	ResolvedFindings []Result
+	// Results holds the threshold checks.
+	Results  []Result
+	Errors   int
+	Warnings int
+}
+
+// TokenDelta is a token count in two versions of a skill.
+type TokenDelta struct {
+	Name string `json:"name"`
+	Old  int    `json:"old"`
+	New  int    `json:"new"`
+	// Status is "added", "removed", or "changed" for files, and empty for
+	// totals.
+	Status string `json:"status,omitempty"`
+}
+
+// Delta returns the change in tokens.
+func (d TokenDelta) Delta() int { return d.New - d.Old }
+
+// Growth returns the change as a percentage of the old count: +Inf if the
+// old count is zero and the new one is not.
+func (d TokenDelta) Growth() float64 {
+	if d.Old == 0 {
+		if d.New == 0 {
+			return 0
+		}
+		return math.Inf(1)
+	}
+	return float64(d.New-d.Old) / float64(d.Old) * 100
+}
+
+// MetricDelta is a content or contamination metric in two versions of a
+// skill.
+type MetricDelta struct {
+	Name string  `json:"name"`
+	Old  float64 `json:"old"`
+	New  float64 `json:"new"`
+}
+
+// TokenBudget records the tokenizer a report's token counts were made with
+// and the context window they are measured against.
+type TokenBudget struct {
+	Tokenizer string
+	// ContextWindow is the window size in tokens, or 0 if none was given.
+	ContextWindow int
+}
+
+// Percent returns tokens as a percentage of the context window, or 0 if no
+// window is set.
+func (b *TokenBudget) Percent(tokens int) float64 {
+	if b == nil || b.ContextWindow <= 0 {
+		return 0
+	}
+	return 100 * float64(tokens) / float64(b.ContextWindow)
+}
+
 // ContentReport holds content quality metrics computed by the content analyzer.
 type ContentReport struct {
 	WordCount              int      `json:"word_count"`
@@ -80,6 +185,124 @@ type ContaminationReport struct {
 	ContaminationLevel   string             `json:"contamination_level"`
 }
 
+// CompatibilityReport holds the claims recognized in a skill's compatibility
+// field.
+type CompatibilityReport struct {
+	Platforms []string             `json:"platforms,omitempty"`
+	OS        []string             `json:"os,omitempty"`
+	Binaries  []string             `json:"binaries,omitempty"`
+	Runtimes  []RuntimeRequirement `json:"runtimes,omitempty"`
+	Network   string               `json:"network,omitempty"` // "required" or "none"; empty if not stated
+}
+
+// RuntimeRequirement is a language runtime a skill claims to need, with the
+// version and constraint operator (">=", "<", "=", ...) if one was given.
+type RuntimeRequirement struct {
+	Name       string `json:"name"`
+	Version    string `json:"version,omitempty"`
+	Constraint string `json:"constraint,omitempty"`
+}
+
+// LoadingReport models progressive disclosure: the tokens an agent loads at
+// startup, when the skill activates, and on demand as it follows the skill's
+// references.
+type LoadingReport struct {
+	// MetadataTokens is the skill's name and description, which agents load
+	// at startup whether or not the skill is used.
+	MetadataTokens int `json:"metadata_tokens"`
+	// CollectionMetadataTokens is the metadata of every skill analyzed
+	// together with this one, and CollectionSkills their number; both are 0
+	// for a single skill.
+	CollectionMetadataTokens int `json:"collection_metadata_tokens,omitempty"`
+	CollectionSkills         int `json:"collection_skills,omitempty"`
+	// BodyTokens is the SKILL.md body, loaded when the skill activates.
+	BodyTokens int `json:"body_tokens"`
+	// OnDemandTokens is every file reachable from SKILL.md.
+	OnDemandTokens int `json:"on_demand_tokens"`
+	// TypicalTokens is metadata, body, and the median path's typical cost;
+	// WorstCaseTokens is metadata, body, and everything on demand.
+	TypicalTokens   int           `json:"typical_tokens"`
+	WorstCaseTokens int           `json:"worst_case_tokens"`
+	Paths           []LoadingPath `json:"paths,omitempty"`
+}
+
+// LoadingPath is one way into a skill's on-demand content: a file SKILL.md
+// references, or an instruction to read a whole directory.
+type LoadingPath struct {
+	// Entry is the referenced file, or "all of <dir>/" for an instruction
+	// to read a whole directory.
+	Entry string `json:"entry"`
+	// Line is the SKILL.md line of the reference.
+	Line int `json:"line,omitempty"`
+	// Files are the files the path can load, entry first.
+	Files []string `json:"files"`
+	// TypicalTokens is what following the path loads when the agent reads
+	// only what it was pointed to; WorstCaseTokens adds every file reachable
+	// from there.
+	TypicalTokens   int `json:"typical_tokens"`
+	WorstCaseTokens int `json:"worst_case_tokens"`
+}
+
+// SectionsReport breaks SKILL.md and its markdown references down by
+// heading.
+type SectionsReport struct {
+	// Files holds SKILL.md first, then each markdown file in references/.
+	Files []FileSections `json:"files"`
+	// Largest ranks the SKILL.md sections with the most tokens, counting
+	// their subsections: the candidates for moving into references/.
+	Largest []SectionSummary `json:"largest,omitempty"`
+}
+
+// FileSections is the section breakdown of one markdown file.
+type FileSections struct {
+	File        string          `json:"file"`
+	TotalTokens int             `json:"total_tokens"`
+	Sections    []SectionReport `json:"sections"`
+}
+
+// SectionReport holds the metrics of one section: the text under a heading
+// up to the next heading of any level.
+type SectionReport struct {
+	// Heading is the heading text, or "" for text before the first heading.
+	Heading string `json:"heading"`
+	// Level is the heading level, or 0 for text before the first heading.
+	Level int `json:"level"`
+	Line  int `json:"line"`
+	// Tokens counts the section's own text; SubtreeTokens adds its
+	// subsections.
+	Tokens          int     `json:"tokens"`
+	SubtreeTokens   int     `json:"subtree_tokens"`
+	WordCount       int     `json:"word_count"`
+	CodeBlockRatio  float64 `json:"code_block_ratio"`
+	ImperativeRatio float64 `json:"imperative_ratio"`
+	WeakMarkers     int     `json:"weak_markers"`
+}
+
+// Label formats the section's heading as it appears in markdown, e.g.
+// "## Setup".
+func (s SectionReport) Label() string { return sectionLabel(s.Level, s.Heading) }
+
+// SectionSummary names a section and its tokens, subsections included.
+type SectionSummary struct {
+	File    string `json:"file"`
+	Heading string `json:"heading"`
+	Level   int    `json:"level"`
+	Line    int    `json:"line"`
+	Tokens  int    `json:"tokens"`
+	// Percent is the section's share of the file's tokens.
+	Percent float64 `json:"percent"`
+}
+
+// Label formats the section's heading as it appears in markdown.
+func (s SectionSummary) Label() string { return sectionLabel(s.Level, s.Heading) }
+
+func sectionLabel(level int, heading string) string {
+	if level == 0 {
+		return "(before first heading)"
+	}
+	return strings.Repeat("#", level) + " " + heading
+}
+
 // ReferenceFileReport holds per-file content and contamination analysis for a single reference file.
 type ReferenceFileReport struct {
 	File                string
@@ -93,11 +316,17 @@ type Report struct {
 	Results                       []Result
 	TokenCounts                   []TokenCount
 	OtherTokenCounts              []TokenCount
+	ScriptTokenCounts             []TokenCount
+	BinaryFiles                   []FileSize
 	ContentReport                 *ContentReport
 	ReferencesContentReport       *ContentReport
 	ContaminationReport           *ContaminationReport
 	ReferencesContaminationReport *ContaminationReport
 	ReferenceReports              []ReferenceFileReport
+	Compatibility                 *CompatibilityReport
+	Loading                       *LoadingReport
+	Sections                      *SectionsReport
+	TokenBudget                   *TokenBudget
 	Errors                        int
 	Warnings                      int
 }
@@ -130,9 +359,48 @@ const (
 
 // MultiReport holds aggregated results from validating multiple skills.
 type MultiReport struct {
-	Skills   []*Report
-	Errors   int
-	Warnings int
+	Skills []*Report
+	// Collection holds the checks that apply to the skills together; nil if
+	// none were run.
+	Collection *CollectionReport
+	Errors     int
+	Warnings   int
+}
+
+// AddCollection attaches c to the report and adds its errors and warnings to
+// the totals.
+func (mr *MultiReport) AddCollection(c *CollectionReport) {
+	mr.Collection = c
+	for _, r := range c.Results {
+		switch r.Level {
+		case Error:
+			mr.Errors++
+		case Warning:
+			mr.Warnings++
+		}
+	}
+}
+
+// CollectionReport holds the results of checks across a set of skills.
+type CollectionReport struct {
+	Results      []Result
+	Descriptions *DescriptionFootprint
+}
+
+// DescriptionFootprint is the always-on context cost of a skill collection:
+// agents keep every installed skill's name and description in context.
+type DescriptionFootprint struct {
+	TotalTokens int `json:"total_tokens"`
+	// Budget is the token budget the total was checked against, or 0.
+	Budget int `json:"budget,omitempty"`
+	// Skills holds each skill's cost, largest first.
+	Skills []SkillTokens `json:"skills"`
+}
+
+// SkillTokens is a token count for one skill.
+type SkillTokens struct {
+	Skill  string `json:"skill"`
+	Tokens int    `json:"tokens"`
 }
 
 // DimensionScore holds a single scoring dimension's display name and value.
@@ -1,13 +1,15 @@
 // Package util provides shared utility functions used across the
 // skill-validator codebase: number formatting, pluralization, rounding,
-// sorted-key extraction, and ANSI color helpers.
+// sorted-key extraction, text detection, and ANSI color helpers.
 package util
 
 import (
+	"bytes"
 	"fmt"
 	"math"
 	"path/filepath"
 	"sort"
+	"unicode/utf8"
 )
 
 // --- Color constants for terminal output ---
@@ -76,6 +78,34 @@ func SkillNameFromDir(dir string) string {
 	return filepath.Base(dir)
 }
 
+// --- Text detection ---
+
+// SniffLen is how much of a file IsText inspects, as in git's binary
+// detection.
+const SniffLen = 8000
+
+// IsText reports whether data looks like text: its first SniffLen bytes
+// hold no NUL byte and are valid UTF-8, allowing a rune cut off at the end
+// of the sample.
+func IsText(data []byte) bool {
+	sample := data[:min(len(data), SniffLen)]
+	if bytes.IndexByte(sample, 0) >= 0 {
+		return false
+	}
+	if len(sample) < len(data) {
+		// Allow a rune split by the sample boundary.
+		for i := 1; i < utf8.UTFMax && i <= len(sample); i++ {
+			if utf8.RuneStart(sample[len(sample)-i]) {
+				if !utf8.FullRune(sample[len(sample)-i:]) {
+					sample = sample[:len(sample)-i]
+				}
+				break
+			}
+		}
+	}
+	return utf8.Valid(sample)
+}
+
 // --- Map helpers ---
 
 // SortedKeys returns the keys of any map[string]V sorted alphabetically.
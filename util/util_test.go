@@ -2,6 +2,7 @@ package util
 
 import (
 	"math"
+	"strings"
 	"testing"
 )
 
@@ -83,3 +84,25 @@ func TestSortedKeys(t *testing.T) {
 		t.Errorf("SortedKeys(empty) = %v, want []", empty)
 	}
 }
+
+func TestIsText(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want bool
+	}{
+		{"empty", "", true},
+		{"ascii", "echo hello\n", true},
+		{"utf-8", "Déployez 日本語", true},
+		{"nul byte", "abc\x00def", false},
+		{"png header", "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR", false},
+		{"latin-1", "caf\xe9", false},
+		{"rune split by the sample", strings.Repeat("a", SniffLen-1) + "é", true},
+		{"nul past the sample", strings.Repeat("a", SniffLen) + "\x00", true},
+	}
+	for _, tt := range tests {
+		if got := IsText([]byte(tt.data)); got != tt.want {
+			t.Errorf("%s: IsText = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}